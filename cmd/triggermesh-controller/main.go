@@ -29,7 +29,9 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/flow/reconciler/transformation"
 	"github.com/triggermesh/triggermesh/pkg/flow/reconciler/xmltojsontransformation"
 	"github.com/triggermesh/triggermesh/pkg/flow/reconciler/xslttransformation"
+	"github.com/triggermesh/triggermesh/pkg/routing/reconciler/aggregator"
 	"github.com/triggermesh/triggermesh/pkg/routing/reconciler/filter"
+	"github.com/triggermesh/triggermesh/pkg/routing/reconciler/router"
 	"github.com/triggermesh/triggermesh/pkg/routing/reconciler/splitter"
 	"github.com/triggermesh/triggermesh/pkg/sources/reconciler/awscloudwatchlogssource"
 	"github.com/triggermesh/triggermesh/pkg/sources/reconciler/awscloudwatchsource"
@@ -196,7 +198,9 @@ func main() {
 		// extensions
 		function.NewController,
 		// routing
+		aggregator.NewController,
 		filter.NewController,
+		router.NewController,
 		splitter.NewController,
 	)
 }
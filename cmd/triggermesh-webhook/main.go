@@ -43,6 +43,8 @@ var validationTypes = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{}
 var defaultingTypes = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	sourcesv1alpha1.SchemeGroupVersion.WithKind("CloudEventsSource"): &sourcesv1alpha1.CloudEventsSource{},
 	routingv1alpha1.SchemeGroupVersion.WithKind("Filter"):            &routingv1alpha1.Filter{},
+	routingv1alpha1.SchemeGroupVersion.WithKind("Router"):            &routingv1alpha1.Router{},
+	routingv1alpha1.SchemeGroupVersion.WithKind("Aggregator"):        &routingv1alpha1.Aggregator{},
 	flowv1alpha1.SchemeGroupVersion.WithKind("XSLTTransformation"):   &flowv1alpha1.XSLTTransformation{},
 }
 
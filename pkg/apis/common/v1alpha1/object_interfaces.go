@@ -95,6 +95,19 @@ func WantsOwnServiceAccount(r Reconcilable) bool {
 	return ok && saProvider.WantsOwnServiceAccount()
 }
 
+// ConfigMapWriter is implemented by types which receive adapter may need to
+// create and update ConfigMaps in the namespace of the component instance.
+type ConfigMapWriter interface {
+	WritesConfigMaps() bool
+}
+
+// WritesConfigMaps returns whether the receive adapter of the given component
+// instance requires permissions to write ConfigMaps.
+func WritesConfigMaps(r Reconcilable) bool {
+	cmWriter, ok := r.(ConfigMapWriter)
+	return ok && cmWriter.WritesConfigMaps()
+}
+
 // ServiceAccountOptions returns functional options for mutating the
 // ServiceAccount associated with a given component instance.
 func ServiceAccountOptions(r Reconcilable) []resource.ServiceAccountOption {
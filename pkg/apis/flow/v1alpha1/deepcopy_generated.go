@@ -22,12 +22,65 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apis "github.com/triggermesh/triggermesh/pkg/apis"
 	commonv1alpha1 "github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 	cloudevents "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Branch) DeepCopyInto(out *Branch) {
+	*out = *in
+	in.Condition.DeepCopyInto(&out.Condition)
+	if in.Then != nil {
+		in, out := &in.Then, &out.Then
+		*out = make([]Transform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Else != nil {
+		in, out := &in.Else, &out.Else
+		*out = make([]Transform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Branch.
+func (in *Branch) DeepCopy() *Branch {
+	if in == nil {
+		return nil
+	}
+	out := new(Branch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(Path)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Correlation) DeepCopyInto(out *Correlation) {
 	*out = *in
@@ -169,9 +222,45 @@ func (in *Path) DeepCopy() *Path {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisStorage) DeepCopyInto(out *RedisStorage) {
+	*out = *in
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(commonv1alpha1.ValueFromField)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisStorage.
+func (in *RedisStorage) DeepCopy() *RedisStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Response) DeepCopyInto(out *Response) {
 	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ResponseMode)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(apis.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -250,7 +339,17 @@ func (in *SynchronizerList) DeepCopyObject() runtime.Object {
 func (in *SynchronizerSpec) DeepCopyInto(out *SynchronizerSpec) {
 	*out = *in
 	out.CorrelationKey = in.CorrelationKey
-	out.Response = in.Response
+	in.Response.DeepCopyInto(&out.Response)
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(ClientProtocol)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(SynchronizerStorage)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
@@ -270,6 +369,27 @@ func (in *SynchronizerSpec) DeepCopy() *SynchronizerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynchronizerStorage) DeepCopyInto(out *SynchronizerStorage) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynchronizerStorage.
+func (in *SynchronizerStorage) DeepCopy() *SynchronizerStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SynchronizerStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Transform) DeepCopyInto(out *Transform) {
 	*out = *in
@@ -278,6 +398,26 @@ func (in *Transform) DeepCopyInto(out *Transform) {
 		*out = make([]Path, len(*in))
 		copy(*out, *in)
 	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = new(Condition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.If != nil {
+		in, out := &in.If, &out.If
+		*out = new(Branch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(VariableScope)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(apis.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -369,6 +509,11 @@ func (in *TransformationSpec) DeepCopyInto(out *TransformationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(TransformationStorage)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
@@ -388,6 +533,27 @@ func (in *TransformationSpec) DeepCopy() *TransformationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformationStorage) DeepCopyInto(out *TransformationStorage) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformationStorage.
+func (in *TransformationStorage) DeepCopy() *TransformationStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformationStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromField) DeepCopyInto(out *ValueFromField) {
 	*out = *in
@@ -66,8 +66,3 @@ func (s *Synchronizer) GetAdapterOverrides() *v1alpha1.AdapterOverrides {
 // SetDefaults implements apis.Defaultable
 func (s *Synchronizer) SetDefaults(ctx context.Context) {
 }
-
-// Validate implements apis.Validatable
-func (s *Synchronizer) Validate(ctx context.Context) *apis.FieldError {
-	return nil
-}
@@ -50,6 +50,17 @@ type SynchronizerSpec struct {
 	CorrelationKey Correlation `json:"correlationKey"`
 	Response       Response    `json:"response"`
 
+	// Protocol spoken by the clients of the Synchronizer. Defaults to "cloudevents".
+	// +optional
+	Protocol *ClientProtocol `json:"protocol,omitempty"`
+
+	// Storage describes a backend of the client sessions and asynchronous
+	// responses shared by all replicas of the Synchronizer, allowing it to
+	// scale horizontally. Sessions and responses are kept in the memory of
+	// a single replica if not set.
+	// +optional
+	Storage *SynchronizerStorage `json:"storage,omitempty"`
+
 	// Support sending to an event sink instead of replying.
 	duckv1.SourceSpec `json:",inline"`
 
@@ -64,9 +75,53 @@ type Correlation struct {
 	Length    int    `json:"length"`
 }
 
+// ClientProtocol is the protocol spoken by the clients of the Synchronizer.
+type ClientProtocol string
+
+// Supported client protocols.
+const (
+	// ClientProtocolCloudEvents is used by clients which send and receive CloudEvents.
+	ClientProtocolCloudEvents ClientProtocol = "cloudevents"
+	// ClientProtocolHTTP is used by plain HTTP clients. The body and the
+	// headers of their requests are converted to CloudEvents, and they
+	// receive the data of the response events.
+	ClientProtocolHTTP ClientProtocol = "http"
+)
+
 // Response defines the response handling configuration.
 type Response struct {
 	Timeout apis.Duration `json:"timeout"`
+
+	// Mode defines whether clients are blocked until the response is
+	// received, or immediately receive a URL to poll the response from.
+	// Defaults to "sync".
+	// +optional
+	Mode *ResponseMode `json:"mode,omitempty"`
+
+	// Retention is the duration during which responses remain available
+	// for polling in the "async" mode. Expressed as a duration string,
+	// which format is documented at https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	Retention *apis.Duration `json:"retention,omitempty"`
+}
+
+// ResponseMode is the mode of delivery of the responses to the clients.
+type ResponseMode string
+
+// Supported response modes.
+const (
+	// ResponseModeSync blocks clients until the response is received.
+	ResponseModeSync ResponseMode = "sync"
+	// ResponseModeAsync replies to clients with a 202 Accepted status and
+	// the URL of the response in the Location header.
+	ResponseModeAsync ResponseMode = "async"
+)
+
+// SynchronizerStorage describes the backend of the client sessions.
+type SynchronizerStorage struct {
+	// Redis configures a Redis-compatible storage.
+	// +optional
+	Redis *RedisStorage `json:"redis,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
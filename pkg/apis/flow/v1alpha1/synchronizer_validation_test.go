@@ -0,0 +1,53 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"knative.dev/pkg/apis"
+)
+
+func TestSynchronizerValidate(t *testing.T) {
+	testCases := map[string]struct {
+		storage     *SynchronizerStorage
+		expectError *apis.FieldError
+	}{
+		"In-memory storage": {
+			storage:     nil,
+			expectError: nil,
+		},
+		"Redis storage": {
+			storage:     &SynchronizerStorage{Redis: &RedisStorage{Address: "redis:6379"}},
+			expectError: nil,
+		},
+		"Redis storage without address": {
+			storage:     &SynchronizerStorage{Redis: &RedisStorage{}},
+			expectError: apis.ErrMissingField("address").ViaField("storage", "redis").ViaField("spec"),
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			s := &Synchronizer{Spec: SynchronizerSpec{Storage: tc.storage}}
+			assert.Equal(t, tc.expectError.Error(), s.Validate(context.Background()).Error())
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -54,6 +55,11 @@ type TransformationSpec struct {
 	// Data contains Transformations that must be applied on CE Data
 	Data []Transform `json:"data,omitempty"`
 
+	// Storage configures the backend of the variables shared across events.
+	// Variables are kept in memory if not set.
+	// +optional
+	Storage *TransformationStorage `json:"storage,omitempty"`
+
 	// Support sending to an event sink instead of replying.
 	duckv1.SourceSpec `json:",inline"`
 
@@ -64,8 +70,83 @@ type TransformationSpec struct {
 
 // Transform describes transformation schemes for different CE types.
 type Transform struct {
-	Operation string `json:"operation"`
-	Paths     []Path `json:"paths"`
+	Operation string `json:"operation,omitempty"`
+	Paths     []Path `json:"paths,omitempty"`
+
+	// When is an optional predicate. If set, the operation is applied
+	// only to events matching the predicate.
+	// +optional
+	When *Condition `json:"when,omitempty"`
+
+	// If is a conditional block of Transformations. Mutually exclusive
+	// with Operation.
+	// +optional
+	If *Branch `json:"if,omitempty"`
+
+	// Scope of the variables created by the store operation. Variables of the
+	// "event" scope exist only while the event is being transformed, variables
+	// of the "global" scope are persisted across events.
+	// +optional
+	Scope *VariableScope `json:"scope,omitempty"`
+	// TTL of the global scope variables. Variables do not expire if not set.
+	// Expressed as a duration string, which format is documented at https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	TTL *tmapis.Duration `json:"ttl,omitempty"`
+}
+
+// VariableScope is the lifetime scope of the Transformation variables.
+type VariableScope string
+
+// Supported variable scopes.
+const (
+	VariableScopeEvent  VariableScope = "event"
+	VariableScopeGlobal VariableScope = "global"
+)
+
+// TransformationStorage describes the backend of the global scope variables.
+type TransformationStorage struct {
+	// Redis configures a Redis-compatible storage.
+	// +optional
+	Redis *RedisStorage `json:"redis,omitempty"`
+}
+
+// RedisStorage contains the parameters of the connection to a Redis server.
+type RedisStorage struct {
+	// Address of the Redis server in the "host:port" format.
+	Address string `json:"address"`
+	// Password used to authenticate with the Redis server.
+	// +optional
+	Password *v1alpha1.ValueFromField `json:"password,omitempty"`
+	// Database number.
+	// +optional
+	Database *int `json:"database,omitempty"`
+}
+
+// Condition is a predicate evaluated against the event.
+type Condition struct {
+	// Expression is a CEL expression in the format used by the Filter
+	// component, e.g. `$foo.bar.(string) == "baz"`.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+	// Path is a JSON path predicate. It matches if the key exists in the
+	// transformed JSON and, when Value is not empty, its value is equal to Value.
+	// +optional
+	Path *Path `json:"path,omitempty"`
+}
+
+// Branch is an if/else block of Transformations. Like the predicates of
+// operations, its condition is evaluated when the block is reached, against the
+// JSON as transformed by the preceding operations. All the operations of the
+// block run at their position, including those which otherwise run before any
+// other operation, such as store and parse.
+type Branch struct {
+	Condition `json:",inline"`
+
+	// Then contains Transformations applied if the condition matches.
+	Then []Transform `json:"then,omitempty"`
+	// Else contains Transformations applied if the condition does not match.
+	// +optional
+	Else []Transform `json:"else,omitempty"`
 }
 
 // Path is a key-value pair that represents JSON object path
@@ -18,8 +18,11 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
 	"knative.dev/pkg/apis"
+
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
 )
 
 // Validate implements apis.Validatable
@@ -29,5 +32,77 @@ func (t *Transformation) Validate(ctx context.Context) *apis.FieldError {
 
 // Validate implements apis.Validatable
 func (ts *TransformationSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, t := range ts.Context {
+		errs = errs.Also(t.Validate(ctx).ViaFieldIndex("context", i))
+	}
+	for i, t := range ts.Data {
+		errs = errs.Also(t.Validate(ctx).ViaFieldIndex("data", i))
+	}
+	if ts.Storage != nil && ts.Storage.Redis != nil {
+		errs = errs.Also(ts.Storage.Redis.Validate(ctx).ViaField("storage", "redis"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (r *RedisStorage) Validate(_ context.Context) *apis.FieldError {
+	if r.Address == "" {
+		return apis.ErrMissingField("address")
+	}
+	return nil
+}
+
+// Validate implements apis.Validatable
+func (t *Transform) Validate(ctx context.Context) *apis.FieldError {
+	if t.Operation == "" && t.If == nil {
+		return apis.ErrMissingOneOf("operation", "if")
+	}
+	if t.Operation != "" && t.If != nil {
+		return apis.ErrMultipleOneOf("operation", "if")
+	}
+	if t.Scope != nil && *t.Scope != VariableScopeEvent && *t.Scope != VariableScopeGlobal {
+		return apis.ErrInvalidValue(*t.Scope, "scope")
+	}
+
+	if t.If != nil {
+		if t.When != nil {
+			return apis.ErrDisallowedFields("when")
+		}
+		errs := t.If.Condition.Validate(ctx).ViaField("if")
+		for i, b := range t.If.Then {
+			errs = errs.Also(b.Validate(ctx).ViaFieldIndex("then", i).ViaField("if"))
+		}
+		for i, b := range t.If.Else {
+			errs = errs.Also(b.Validate(ctx).ViaFieldIndex("else", i).ViaField("if"))
+		}
+		return errs
+	}
+
+	if t.When != nil {
+		return t.When.Validate(ctx).ViaField("when")
+	}
+
+	return nil
+}
+
+// Validate implements apis.Validatable
+func (c *Condition) Validate(ctx context.Context) *apis.FieldError {
+	if c.Expression == "" && c.Path == nil {
+		return apis.ErrMissingOneOf("expression", "path")
+	}
+	if c.Expression != "" && c.Path != nil {
+		return apis.ErrMultipleOneOf("expression", "path")
+	}
+
+	if c.Expression != "" {
+		if _, err := cel.CompileExpression(c.Expression); err != nil {
+			return apis.ErrInvalidValue(fmt.Sprintf("Cannot compile expression: %v", err), "expression")
+		}
+	}
+	if c.Path != nil && c.Path.Key == "" {
+		return apis.ErrMissingField("path.key")
+	}
+
 	return nil
 }
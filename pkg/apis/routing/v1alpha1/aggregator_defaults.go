@@ -0,0 +1,42 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
+)
+
+// Default values of the Aggregator spec.
+const (
+	defaultAggregatorTimeout = 1 * time.Minute
+)
+
+// SetDefaults implements apis.Defaultable
+func (a *Aggregator) SetDefaults(ctx context.Context) {
+	if a.Spec.Timeout == nil {
+		timeout := tmapis.Duration(defaultAggregatorTimeout)
+		a.Spec.Timeout = &timeout
+	}
+
+	if a.Spec.OnTimeout == nil {
+		policy := AggregatorTimeoutPolicyEmit
+		a.Spec.OnTimeout = &policy
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+)
+
+// Supported event types
+const (
+	AggregatorGenericEventType = "io.triggermesh.routing.aggregator"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (*Aggregator) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Aggregator")
+}
+
+// GetConditionSet implements duckv1.KRShaped.
+func (*Aggregator) GetConditionSet() apis.ConditionSet {
+	return v1alpha1.DefaultConditionSet
+}
+
+// GetStatus implements duckv1.KRShaped.
+func (s *Aggregator) GetStatus() *duckv1.Status {
+	return &s.Status.Status
+}
+
+// GetStatusManager implements Reconcilable.
+func (s *Aggregator) GetStatusManager() *v1alpha1.StatusManager {
+	return &v1alpha1.StatusManager{
+		ConditionSet: s.GetConditionSet(),
+		Status:       &s.Status,
+	}
+}
+
+// GetEventTypes implements EventSource.
+func (*Aggregator) GetEventTypes() []string {
+	return []string{
+		AggregatorGenericEventType,
+	}
+}
+
+// AsEventSource implements EventSource.
+func (s *Aggregator) AsEventSource() string {
+	return "aggregator/" + s.Name
+}
+
+// GetSink implements EventSender.
+func (s *Aggregator) GetSink() *duckv1.Destination {
+	return s.Spec.Sink
+}
+
+// IsMultiTenant implements MultiTenant.
+func (*Aggregator) IsMultiTenant() bool {
+	return true
+}
+
+// GetAdapterOverrides implements AdapterConfigurable.
+func (s *Aggregator) GetAdapterOverrides() *v1alpha1.AdapterOverrides {
+	return s.Spec.AdapterOverrides
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
+	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Aggregator is an addressable object that collects incoming events sharing
+// a correlation attribute and combines them into a single event.
+//
+// Pending aggregations are kept in the memory of the adapter, and incoming
+// events are acknowledged as soon as they are added to an aggregation. Pending
+// aggregations are therefore lost if the adapter restarts, without the sender
+// redelivering their events.
+type Aggregator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AggregatorSpec  `json:"spec,omitempty"`
+	Status v1alpha1.Status `json:"status,omitempty"`
+}
+
+var (
+	_ apis.Validatable = (*Aggregator)(nil)
+	_ apis.Defaultable = (*Aggregator)(nil)
+
+	_ v1alpha1.Reconcilable        = (*Aggregator)(nil)
+	_ v1alpha1.AdapterConfigurable = (*Aggregator)(nil)
+	_ v1alpha1.EventSender         = (*Aggregator)(nil)
+	_ v1alpha1.EventSource         = (*Aggregator)(nil)
+	_ v1alpha1.MultiTenant         = (*Aggregator)(nil)
+)
+
+// AggregatorSpec defines the desired state of the component.
+type AggregatorSpec struct {
+	// CorrelationKey is the name of the CloudEvent context attribute or
+	// extension which value identifies the events to aggregate together,
+	// e.g. "splitid" for events produced by a Splitter.
+	CorrelationKey string `json:"correlationKey"`
+
+	// Count is the number of events after which the aggregated event is emitted.
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// CountKey is the name of the CloudEvent extension which value is the
+	// number of events to aggregate, e.g. "splitcount". Takes precedence
+	// over Count when present in the incoming events.
+	// +optional
+	CountKey *string `json:"countKey,omitempty"`
+
+	// IndexKey is the name of the CloudEvent extension which value is the
+	// position of the event in the aggregated event, e.g. "splitindex".
+	// Events are aggregated in order of arrival if not set.
+	// +optional
+	IndexKey *string `json:"indexKey,omitempty"`
+
+	// Completion is a CEL expression in the format used by the Filter
+	// component, evaluated against each incoming event. The aggregated event
+	// is emitted as soon as an event matches the expression.
+	// +optional
+	Completion *string `json:"completion,omitempty"`
+
+	// Timeout is the maximum duration of an aggregation, counted from the
+	// reception of its first event. Expressed as a duration string, which
+	// format is documented at https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	Timeout *tmapis.Duration `json:"timeout,omitempty"`
+
+	// OnTimeout defines whether incomplete aggregations are emitted or
+	// discarded when the timeout elapses. Defaults to "emit".
+	// +optional
+	OnTimeout *AggregatorTimeoutPolicy `json:"onTimeout,omitempty"`
+
+	CEContext CloudEventContext   `json:"ceContext"`
+	Sink      *duckv1.Destination `json:"sink"`
+
+	// Adapter spec overrides parameters.
+	// +optional
+	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
+}
+
+// AggregatorTimeoutPolicy is the behavior of the Aggregator upon timeout.
+type AggregatorTimeoutPolicy string
+
+// Supported timeout policies.
+const (
+	// AggregatorTimeoutPolicyEmit emits the partial aggregation.
+	AggregatorTimeoutPolicyEmit AggregatorTimeoutPolicy = "emit"
+	// AggregatorTimeoutPolicyDiscard discards the partial aggregation.
+	AggregatorTimeoutPolicyDiscard AggregatorTimeoutPolicy = "discard"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AggregatorList is a list of component instances.
+type AggregatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Aggregator `json:"items"`
+}
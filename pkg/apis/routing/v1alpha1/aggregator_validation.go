@@ -0,0 +1,64 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
+)
+
+// Validate implements apis.Validatable
+func (a *Aggregator) Validate(ctx context.Context) *apis.FieldError {
+	return a.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (as *AggregatorSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if as.CorrelationKey == "" {
+		errs = errs.Also(apis.ErrMissingField("correlationKey"))
+	}
+
+	if as.Count != nil && *as.Count < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*as.Count, "count"))
+	}
+
+	if as.Completion != nil {
+		if _, err := cel.CompileExpression(*as.Completion); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("Cannot compile expression: %v", err), "completion"))
+		}
+	}
+
+	if as.Timeout != nil && *as.Timeout <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*as.Timeout, "timeout"))
+	}
+
+	if as.OnTimeout != nil {
+		switch *as.OnTimeout {
+		case AggregatorTimeoutPolicyEmit, AggregatorTimeoutPolicyDiscard:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(*as.OnTimeout, "onTimeout"))
+		}
+	}
+
+	return errs
+}
@@ -49,6 +49,8 @@ var (
 var AllTypes = []v1alpha1.GroupObject{
 	{Single: &Filter{}, List: &FilterList{}},
 	{Single: &Splitter{}, List: &SplitterList{}},
+	{Single: &Router{}, List: &RouterList{}},
+	{Single: &Aggregator{}, List: &AggregatorList{}},
 }
 
 // Adds the list of known types to Scheme.
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (*Router) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Router")
+}
+
+// GetStatus implements duckv1.KRShaped.
+func (r *Router) GetStatus() *duckv1.Status {
+	return &r.Status.Status.Status
+}
+
+// GetConditionSet implements duckv1.KRShaped.
+func (*Router) GetConditionSet() apis.ConditionSet {
+	return v1alpha1.DefaultConditionSet
+}
+
+// GetStatusManager implements Reconcilable.
+func (r *Router) GetStatusManager() *v1alpha1.StatusManager {
+	return &v1alpha1.StatusManager{
+		ConditionSet: r.GetConditionSet(),
+		Status:       &r.Status.Status,
+	}
+}
+
+// GetSink implements EventSender.
+func (r *Router) GetSink() *duckv1.Destination {
+	if r.Spec.Sink == nil {
+		return &duckv1.Destination{}
+	}
+	return r.Spec.Sink
+}
+
+// IsMultiTenant implements MultiTenant.
+func (*Router) IsMultiTenant() bool {
+	return true
+}
+
+// GetAdapterOverrides implements AdapterConfigurable.
+func (r *Router) GetAdapterOverrides() *v1alpha1.AdapterOverrides {
+	return r.Spec.AdapterOverrides
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Router is an addressable object that routes incoming events to
+// different destinations according to an ordered list of rules.
+type Router struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouterSpec   `json:"spec,omitempty"`
+	Status RouterStatus `json:"status,omitempty"`
+}
+
+var (
+	_ apis.Validatable = (*Router)(nil)
+	_ apis.Defaultable = (*Router)(nil)
+
+	_ v1alpha1.Reconcilable        = (*Router)(nil)
+	_ v1alpha1.AdapterConfigurable = (*Router)(nil)
+	_ v1alpha1.EventSender         = (*Router)(nil)
+	_ v1alpha1.MultiTenant         = (*Router)(nil)
+)
+
+// RouterSpec defines the desired state of the component.
+type RouterSpec struct {
+	// Routes is the ordered list of routing rules.
+	Routes []Route `json:"routes"`
+
+	// Mode defines whether events are sent to the first matching route
+	// only, or to all matching routes. Defaults to "first". In the "all"
+	// mode, an event is rejected only if none of the routes accepted it.
+	// +optional
+	Mode *RouterMode `json:"mode,omitempty"`
+
+	// Sink is the default destination of the events that do not match any route.
+	// Unmatched events are dropped if not set.
+	// +optional
+	Sink *duckv1.Destination `json:"sink,omitempty"`
+
+	// Adapter spec overrides parameters.
+	// +optional
+	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
+}
+
+// Route is a routing rule.
+type Route struct {
+	// Expression is a CEL expression in the format used by the Filter
+	// component, e.g. `ce.type == "foo"`.
+	Expression string `json:"expression"`
+
+	// Sink is the destination of the events matching the expression.
+	Sink duckv1.Destination `json:"sink"`
+}
+
+// RouterMode is the mode of the route matching.
+type RouterMode string
+
+// Supported routing modes.
+const (
+	// RouterModeFirst sends events to the first matching route.
+	RouterModeFirst RouterMode = "first"
+	// RouterModeAll sends events to all matching routes.
+	RouterModeAll RouterMode = "all"
+)
+
+// RouterStatus defines the observed state of the component.
+type RouterStatus struct {
+	v1alpha1.Status `json:",inline"`
+
+	// RouteURIs are the resolved URIs of the routes sinks, in the order of the routes.
+	// +optional
+	RouteURIs []*apis.URL `json:"routeURIs,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RouterList is a list of component instances.
+type RouterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Router `json:"items"`
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
+)
+
+// Validate implements apis.Validatable
+func (r *Router) Validate(ctx context.Context) *apis.FieldError {
+	return r.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (rs *RouterSpec) Validate(ctx context.Context) *apis.FieldError {
+	if len(rs.Routes) == 0 {
+		return apis.ErrMissingField("routes")
+	}
+
+	if rs.Mode != nil && *rs.Mode != RouterModeFirst && *rs.Mode != RouterModeAll {
+		return apis.ErrInvalidValue(*rs.Mode, "mode")
+	}
+
+	var errs *apis.FieldError
+	for i, r := range rs.Routes {
+		if r.Expression == "" {
+			errs = errs.Also(apis.ErrMissingField("expression").ViaFieldIndex("routes", i))
+			continue
+		}
+		if _, err := cel.CompileExpression(r.Expression); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("Cannot compile expression: %v", err), "expression").
+				ViaFieldIndex("routes", i))
+		}
+	}
+
+	return errs
+}
@@ -22,6 +22,7 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -56,6 +57,22 @@ type SplitterSpec struct {
 	CEContext CloudEventContext   `json:"ceContext"`
 	Sink      *duckv1.Destination `json:"sink"`
 
+	// PreserveContext copies the subject, the extensions and the data
+	// content type of the original event to the resulting events.
+	// +optional
+	PreserveContext *bool `json:"preserveContext,omitempty"`
+
+	// IndexExtensions sets the "splitid", "splitindex" and "splitcount"
+	// extensions on the resulting events, containing respectively the ID of
+	// the original event, the position of the event in the original
+	// collection and the size of this collection.
+	// +optional
+	IndexExtensions *bool `json:"indexExtensions,omitempty"`
+
+	// Delivery defines how failures to deliver the resulting events are handled.
+	// +optional
+	Delivery *SplitterDelivery `json:"delivery,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
@@ -68,6 +85,39 @@ type CloudEventContext struct {
 	Extensions map[string]string `json:"extensions"`
 }
 
+// SplitterDelivery defines the delivery options of the resulting events.
+type SplitterDelivery struct {
+	// OnFailure is the behavior of the Splitter when some of the resulting
+	// events could not be delivered. Defaults to "ignore".
+	// +optional
+	OnFailure *SplitterFailurePolicy `json:"onFailure,omitempty"`
+
+	// Retries is the maximum number of delivery attempts of each failed
+	// event when the "retry" policy is used.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// BackoffDelay is the delay before the first retry, doubled at each
+	// subsequent attempt. Expressed as a duration string, which format is
+	// documented at https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	BackoffDelay *tmapis.Duration `json:"backoffDelay,omitempty"`
+}
+
+// SplitterFailurePolicy is the behavior of the Splitter upon delivery failures.
+type SplitterFailurePolicy string
+
+// Supported failure policies.
+const (
+	// SplitterFailurePolicyIgnore logs delivery failures and acknowledges the original event.
+	SplitterFailurePolicyIgnore SplitterFailurePolicy = "ignore"
+	// SplitterFailurePolicyFail rejects the original event if any resulting event could not be delivered.
+	SplitterFailurePolicyFail SplitterFailurePolicy = "fail"
+	// SplitterFailurePolicyRetry retries the delivery of the failed events
+	// only, and rejects the original event if retries are exhausted.
+	SplitterFailurePolicyRetry SplitterFailurePolicy = "retry"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SplitterList is a list of component instances.
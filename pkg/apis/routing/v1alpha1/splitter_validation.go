@@ -29,5 +29,33 @@ func (s *Splitter) Validate(ctx context.Context) *apis.FieldError {
 
 // Validate implements apis.Validatable
 func (ss *SplitterSpec) Validate(ctx context.Context) *apis.FieldError {
-	return nil
+	if ss.Delivery == nil {
+		return nil
+	}
+	return ss.Delivery.Validate(ctx).ViaField("delivery")
+}
+
+// Validate implements apis.Validatable
+func (d *SplitterDelivery) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if d.OnFailure != nil {
+		switch *d.OnFailure {
+		case SplitterFailurePolicyIgnore,
+			SplitterFailurePolicyFail,
+			SplitterFailurePolicyRetry:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(*d.OnFailure, "onFailure"))
+		}
+	}
+
+	if d.Retries != nil && *d.Retries < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*d.Retries, "retries"))
+	}
+
+	if d.BackoffDelay != nil && *d.BackoffDelay < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*d.BackoffDelay, "backoffDelay"))
+	}
+
+	return errs
 }
@@ -63,6 +63,24 @@ const (
 
 // GetEventTypes implements EventSource.
 func (s *AWSSQSSource) GetEventTypes() []string {
+	var mp string
+	if s.Spec.MessageProcessor != nil {
+		mp = *s.Spec.MessageProcessor
+	}
+
+	switch mp {
+	case "sns":
+		return []string{
+			AWSEventType("sns", AWSSNSGenericEventType),
+		}
+	case "eventbridge":
+		// messages which don't originate from EventBridge are forwarded as generic SQS messages
+		return []string{
+			AWSEventType("events", AWSEventBridgeGenericEventType),
+			AWSEventType(s.Spec.ARN.Service, AWSSQSGenericEventType),
+		}
+	}
+
 	return []string{
 		AWSEventType(s.Spec.ARN.Service, AWSSQSGenericEventType),
 	}
@@ -59,7 +59,7 @@ type AWSSQSSourceSpec struct {
 	ReceiveOptions *AWSSQSSourceReceiveOptions `json:"receiveOptions,omitempty"`
 
 	// Name of the message processor to use for converting SQS messages to CloudEvents.
-	// Supported values are "default" and "s3".
+	// Supported values are "default", "s3", "eventbridge", "sns" and "cloudevents".
 	// +optional
 	MessageProcessor *string `json:"messageProcessor,omitempty"`
 
@@ -89,6 +89,18 @@ type AWSSQSSourceReceiveOptions struct {
 	//
 	// +optional
 	VisibilityTimeout *apis.Duration `json:"visibilityTimeout,omitempty"`
+
+	// Number of messages processed concurrently by the source.
+	//
+	// Messages which belong to the same message group of a FIFO queue are
+	// always processed sequentially, in the order they were received.
+	//
+	// If not defined, a value proportional to the number of CPUs available
+	// to the source is used. The number of concurrent requests for receiving
+	// messages grows with this value, so that all processors are kept busy.
+	//
+	// +optional
+	Concurrency *int32 `json:"concurrency,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1319,6 +1319,11 @@ func (in *AWSSQSSourceReceiveOptions) DeepCopyInto(out *AWSSQSSourceReceiveOptio
 		*out = new(apis.Duration)
 		**out = **in
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -3558,6 +3563,37 @@ func (in *KafkaSourceAuth) DeepCopy() *KafkaSourceAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSourceDelivery) DeepCopyInto(out *KafkaSourceDelivery) {
+	*out = *in
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffDelay != nil {
+		in, out := &in.BackoffDelay, &out.BackoffDelay
+		*out = new(apis.Duration)
+		**out = **in
+	}
+	if in.DeadLetterTopic != nil {
+		in, out := &in.DeadLetterTopic, &out.DeadLetterTopic
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSourceDelivery.
+func (in *KafkaSourceDelivery) DeepCopy() *KafkaSourceDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSourceDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSourceKerberos) DeepCopyInto(out *KafkaSourceKerberos) {
 	*out = *in
@@ -3656,7 +3692,42 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopicPattern != nil {
+		in, out := &in.TopicPattern, &out.TopicPattern
+		*out = new(string)
+		**out = **in
+	}
+	if in.InitialOffset != nil {
+		in, out := &in.InitialOffset, &out.InitialOffset
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int)
+		**out = **in
+	}
+	if in.EventType != nil {
+		in, out := &in.EventType, &out.EventType
+		*out = new(string)
+		**out = **in
+	}
+	if in.EventSource != nil {
+		in, out := &in.EventSource, &out.EventSource
+		*out = new(string)
+		**out = **in
+	}
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(KafkaSourceDelivery)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -3757,6 +3828,58 @@ func (in *MongoDBSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSourceCheckpoint) DeepCopyInto(out *MongoDBSourceCheckpoint) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(string)
+		**out = **in
+	}
+	if in.Collection != nil {
+		in, out := &in.Collection, &out.Collection
+		*out = new(MongoDBSourceCheckpointCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSourceCheckpoint.
+func (in *MongoDBSourceCheckpoint) DeepCopy() *MongoDBSourceCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSourceCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSourceCheckpointCollection) DeepCopyInto(out *MongoDBSourceCheckpointCollection) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSourceCheckpointCollection.
+func (in *MongoDBSourceCheckpointCollection) DeepCopy() *MongoDBSourceCheckpointCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSourceCheckpointCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBSourceList) DeepCopyInto(out *MongoDBSourceList) {
 	*out = *in
@@ -3794,6 +3917,21 @@ func (in *MongoDBSourceList) DeepCopyObject() runtime.Object {
 func (in *MongoDBSourceSpec) DeepCopyInto(out *MongoDBSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(string)
+		**out = **in
+	}
+	if in.FullDocument != nil {
+		in, out := &in.FullDocument, &out.FullDocument
+		*out = new(string)
+		**out = **in
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(MongoDBSourceCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4566,6 +4704,21 @@ func (in *WebhookSourceSpec) DeepCopyInto(out *WebhookSourceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RateLimiter != nil {
+		in, out := &in.RateLimiter, &out.RateLimiter
+		*out = new(RateLimiter)
+		**out = **in
+	}
+	if in.MaxInFlightRequests != nil {
+		in, out := &in.MaxInFlightRequests, &out.MaxInFlightRequests
+		*out = new(int)
+		**out = **in
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
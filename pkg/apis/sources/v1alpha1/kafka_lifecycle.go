@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"regexp"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -33,7 +34,13 @@ const (
 )
 
 // GetEventTypes implements EventSource.
-func (*KafkaSource) GetEventTypes() []string {
+func (s *KafkaSource) GetEventTypes() []string {
+	if s.Spec.EventType != nil {
+		return []string{
+			*s.Spec.EventType,
+		}
+	}
+
 	return []string{
 		KafkaSourceEventType,
 	}
@@ -69,7 +76,20 @@ func (s *KafkaSource) GetStatusManager() *v1alpha1.StatusManager {
 
 // AsEventSource implements EventSource.
 func (s *KafkaSource) AsEventSource() string {
-	return s.Spec.Topic
+	if s.Spec.EventSource != nil {
+		return *s.Spec.EventSource
+	}
+
+	switch {
+	case s.Spec.Topic != "":
+		return s.Spec.Topic
+	case len(s.Spec.Topics) > 0:
+		return s.Spec.Topics[0]
+	case s.Spec.TopicPattern != nil:
+		return *s.Spec.TopicPattern
+	}
+
+	return ""
 }
 
 // GetAdapterOverrides implements AdapterConfigurable.
@@ -83,5 +103,31 @@ func (s *KafkaSource) SetDefaults(ctx context.Context) {
 
 // Validate implements apis.Validatable
 func (s *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
-	return nil
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate KafkaSource spec
+func (s *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if s.Topic == "" && len(s.Topics) == 0 && (s.TopicPattern == nil || *s.TopicPattern == "") {
+		errs = errs.Also(&apis.FieldError{
+			Message: "expected at least one, got none",
+			Paths:   []string{"topic", "topics", "topicPattern"},
+		})
+	}
+
+	for i, t := range s.Topics {
+		if t == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(t, "topics", i))
+		}
+	}
+
+	if s.TopicPattern != nil {
+		if _, err := regexp.Compile(*s.TopicPattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*s.TopicPattern, "topicPattern", err.Error()))
+		}
+	}
+
+	return errs
 }
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaSourceValidate(t *testing.T) {
+	pattern, badPattern := "orders-.*", "orders-("
+
+	testCases := map[string]struct {
+		spec      KafkaSourceSpec
+		expectErr string
+	}{
+		"topic": {
+			spec: KafkaSourceSpec{Topic: "orders"},
+		},
+		"topics": {
+			spec: KafkaSourceSpec{Topics: []string{"orders", "invoices"}},
+		},
+		"topic pattern": {
+			spec: KafkaSourceSpec{TopicPattern: &pattern},
+		},
+		"no topic": {
+			spec:      KafkaSourceSpec{},
+			expectErr: "expected at least one, got none: spec.topic, spec.topicPattern, spec.topics",
+		},
+		"empty topic name": {
+			spec:      KafkaSourceSpec{Topics: []string{"orders", ""}},
+			expectErr: "spec.topics[1]",
+		},
+		"invalid topic pattern": {
+			spec:      KafkaSourceSpec{TopicPattern: &badPattern},
+			expectErr: "invalid value: orders-(: spec.topicPattern",
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			s := &KafkaSource{Spec: tc.spec}
+
+			err := s.Validate(context.Background())
+			if tc.expectErr == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Contains(t, err.Error(), tc.expectErr)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -52,20 +53,88 @@ type KafkaSourceSpec struct {
 	BootstrapServers []string `json:"bootstrapServers"`
 
 	// Topic holds the name of the Kafka Topic.
-	Topic string `json:"topic"`
+	// +optional
+	Topic string `json:"topic,omitempty"`
+
+	// Topics holds the names of additional Kafka topics to consume from.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
+	// TopicPattern is a regular expression matching the names of
+	// additional Kafka topics to consume from. Matching topics are
+	// discovered periodically.
+	// +optional
+	TopicPattern *string `json:"topicPattern,omitempty"`
+
+	// InitialOffset is the position from which records are consumed in
+	// partitions which have no committed offset for the consumer group.
+	// Accepted values are "earliest", "latest" and RFC 3339 timestamps.
+	// Defaults to "latest".
+	// +optional
+	InitialOffset *string `json:"initialOffset,omitempty"`
+
+	// MaxInFlight is the maximum number of records of a single partition
+	// being delivered concurrently. Partitions are always processed in
+	// parallel. Defaults to 1, which preserves the order of records within
+	// partitions.
+	// +optional
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
 
 	// GroupID holds the name of the Kafka Group ID.
 	GroupID string `json:"groupID"`
 
+	// Value of the CloudEvents 'type' attribute to set on events created
+	// from records which do not use the CloudEvents Kafka binding.
+	// Defaults to "io.triggermesh.kafka.event".
+	// +optional
+	EventType *string `json:"eventType,omitempty"`
+
+	// Value of the CloudEvents 'source' attribute to set on events created
+	// from records which do not use the CloudEvents Kafka binding.
+	// Defaults to the topic of the record.
+	// +optional
+	EventSource *string `json:"eventSource,omitempty"`
+
 	// Auth contains Authentication method used to interact with Kafka.
 	// +optional
 	Auth KafkaSourceAuth `json:"auth"`
 
+	// Delivery is the policy applied to records which fail to be delivered
+	// to the sink.
+	// +optional
+	Delivery *KafkaSourceDelivery `json:"delivery,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
 }
 
+// KafkaSourceDelivery is the policy applied to records which fail to be
+// delivered to the sink.
+//
+// Failed deliveries are retried with an exponential backoff. Once retries
+// are exhausted, the record is produced to the dead-letter topic, if any, and
+// its offset is committed. Records which can neither be delivered nor
+// produced to the dead-letter topic are consumed again.
+type KafkaSourceDelivery struct {
+	// Retries is the maximum number of delivery retries of each record.
+	// Defaults to 3.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// BackoffDelay is the delay before the first retry, doubled at each
+	// subsequent attempt. Expressed as a duration string, which format is
+	// documented at https://pkg.go.dev/time#ParseDuration. Defaults to 1s.
+	// +optional
+	BackoffDelay *tmapis.Duration `json:"backoffDelay,omitempty"`
+
+	// DeadLetterTopic is the Kafka topic records are produced to once
+	// delivery retries are exhausted, with the delivery error attached as
+	// a header. Records are discarded if no dead-letter topic is set.
+	// +optional
+	DeadLetterTopic *string `json:"deadLetterTopic,omitempty"`
+}
+
 // KafkaSourceAuth contains Authentication method used to interact with Kafka.
 type KafkaSourceAuth struct {
 	Kerberos *KafkaSourceKerberos `json:"kerberos,omitempty"`
@@ -27,16 +27,36 @@ import (
 
 // Managed event types
 const (
+	// MongoDBSourceEventType is the type of change events which operation
+	// type is unknown.
 	MongoDBSourceEventType = "io.triggermesh.mongodb.event"
+
+	// MongoDBSourceChangeEventTypePrefix is the prefix of the type of change
+	// events, which is followed by their operation type.
+	MongoDBSourceChangeEventTypePrefix = "io.triggermesh.mongodb.change."
+
+	MongoDBSourceEventTypeInsert  = MongoDBSourceChangeEventTypePrefix + "insert"
+	MongoDBSourceEventTypeUpdate  = MongoDBSourceChangeEventTypePrefix + "update"
+	MongoDBSourceEventTypeReplace = MongoDBSourceChangeEventTypePrefix + "replace"
+	MongoDBSourceEventTypeDelete  = MongoDBSourceChangeEventTypePrefix + "delete"
 )
 
 // GetEventTypes implements EventSource.
 func (*MongoDBSource) GetEventTypes() []string {
 	return []string{
+		MongoDBSourceEventTypeInsert,
+		MongoDBSourceEventTypeUpdate,
+		MongoDBSourceEventTypeReplace,
+		MongoDBSourceEventTypeDelete,
 		MongoDBSourceEventType,
 	}
 }
 
+// WritesConfigMaps implements ConfigMapWriter.
+func (s *MongoDBSource) WritesConfigMaps() bool {
+	return s.Spec.Checkpoint != nil && s.Spec.Checkpoint.ConfigMap != nil
+}
+
 // GetGroupVersionKind implements kmeta.OwnerRefable.
 func (*MongoDBSource) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("MongoDBSource")
@@ -76,5 +96,26 @@ func (s *MongoDBSource) SetDefaults(ctx context.Context) {
 
 // Validate implements apis.Validatable
 func (s *MongoDBSource) Validate(ctx context.Context) *apis.FieldError {
+	if c := s.Spec.Checkpoint; c != nil {
+		var stores []string
+		if c.ConfigMap != nil {
+			stores = append(stores, "configMap")
+		}
+		if c.Collection != nil {
+			stores = append(stores, "collection")
+		}
+		if c.File != nil {
+			stores = append(stores, "file")
+		}
+
+		switch len(stores) {
+		case 0:
+			return apis.ErrMissingOneOf("configMap", "collection", "file").ViaField("spec", "checkpoint")
+		case 1:
+		default:
+			return apis.ErrMultipleOneOf(stores...).ViaField("spec", "checkpoint")
+		}
+	}
+
 	return nil
 }
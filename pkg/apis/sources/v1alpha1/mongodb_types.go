@@ -38,9 +38,10 @@ type MongoDBSource struct {
 
 // Check the interfaces the event source should be implementing.
 var (
-	_ v1alpha1.Reconcilable = (*MongoDBSource)(nil)
-	_ v1alpha1.EventSender  = (*MongoDBSource)(nil)
-	_ v1alpha1.EventSource  = (*MongoDBSource)(nil)
+	_ v1alpha1.Reconcilable    = (*MongoDBSource)(nil)
+	_ v1alpha1.EventSender     = (*MongoDBSource)(nil)
+	_ v1alpha1.EventSource     = (*MongoDBSource)(nil)
+	_ v1alpha1.ConfigMapWriter = (*MongoDBSource)(nil)
 )
 
 // MongoDBSourceSpec defines the desired state of the event source.
@@ -55,6 +56,52 @@ type MongoDBSourceSpec struct {
 
 	// Collection holds the name of the MongoDB collection.
 	Collection string `json:"collection"`
+
+	// Aggregation pipeline applied to the change stream on the server, as
+	// a JSON array of stages in MongoDB Extended JSON format, e.g.
+	// [{"$match": {"operationType": {"$in": ["insert", "update"]}}}].
+	// +optional
+	Pipeline *string `json:"pipeline,omitempty"`
+
+	// Whether change events of update operations include the full document.
+	// Accepted values are 'default', 'updateLookup', 'whenAvailable' and
+	// 'required'.
+	// https://www.mongodb.com/docs/manual/changeStreams/#lookup-full-document-for-update-operations
+	// +optional
+	FullDocument *string `json:"fullDocument,omitempty"`
+
+	// Store where the resume token of the change stream is persisted, so
+	// that changes are neither lost nor replayed when the source restarts.
+	// +optional
+	Checkpoint *MongoDBSourceCheckpoint `json:"checkpoint,omitempty"`
+}
+
+// MongoDBSourceCheckpoint is the store where the resume token of the change
+// stream is persisted. Exactly one store must be set.
+type MongoDBSourceCheckpoint struct {
+	// Name of a ConfigMap, in the namespace of the source.
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+
+	// MongoDB collection.
+	// +optional
+	Collection *MongoDBSourceCheckpointCollection `json:"collection,omitempty"`
+
+	// Path of a file in the file system of the adapter. The file should be
+	// located on a persistent volume.
+	// +optional
+	File *string `json:"file,omitempty"`
+}
+
+// MongoDBSourceCheckpointCollection is a MongoDB collection where resume
+// tokens are persisted.
+type MongoDBSourceCheckpointCollection struct {
+	// Name of the database. Defaults to the database of the source.
+	// +optional
+	Database *string `json:"database,omitempty"`
+
+	// Name of the collection.
+	Collection string `json:"collection"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
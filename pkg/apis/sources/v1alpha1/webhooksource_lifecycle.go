@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"math"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -87,5 +88,23 @@ func (s *WebhookSource) SetDefaults(ctx context.Context) {
 
 // Validate implements apis.Validatable
 func (s *WebhookSource) Validate(ctx context.Context) *apis.FieldError {
-	return nil
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate WebhookSource spec
+func (s *WebhookSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if s.MaxBodySize != nil && *s.MaxBodySize < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*s.MaxBodySize, 1, math.MaxInt64, "maxBodySize"))
+	}
+	if s.RateLimiter != nil && s.RateLimiter.RequestsPerSecond < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(s.RateLimiter.RequestsPerSecond, 1, math.MaxInt32,
+			"rateLimiter.requestsPerSecond"))
+	}
+	if s.MaxInFlightRequests != nil && *s.MaxInFlightRequests < 1 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*s.MaxInFlightRequests, 1, math.MaxInt32, "maxInFlightRequests"))
+	}
+
+	return errs
 }
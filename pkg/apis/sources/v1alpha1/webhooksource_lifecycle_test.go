@@ -0,0 +1,71 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSourceValidate(t *testing.T) {
+	negSize, negInFlight := int64(-1), -1
+	size, inFlight := int64(1024), 10
+
+	testCases := map[string]struct {
+		spec      WebhookSourceSpec
+		expectErr string
+	}{
+		"no limits": {
+			spec: WebhookSourceSpec{},
+		},
+		"valid limits": {
+			spec: WebhookSourceSpec{
+				MaxBodySize:         &size,
+				RateLimiter:         &RateLimiter{RequestsPerSecond: 100},
+				MaxInFlightRequests: &inFlight,
+			},
+		},
+		"negative limits": {
+			spec: WebhookSourceSpec{
+				MaxBodySize:         &negSize,
+				RateLimiter:         &RateLimiter{RequestsPerSecond: -1},
+				MaxInFlightRequests: &negInFlight,
+			},
+			expectErr: "expected 1 <= -1 <= ",
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			s := &WebhookSource{Spec: tc.spec}
+
+			err := s.Validate(context.Background())
+			if tc.expectErr == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Contains(t, err.Error(), tc.expectErr)
+			for _, f := range []string{"spec.maxBodySize", "spec.rateLimiter.requestsPerSecond", "spec.maxInFlightRequests"} {
+				assert.Contains(t, err.Error(), f)
+			}
+		})
+	}
+}
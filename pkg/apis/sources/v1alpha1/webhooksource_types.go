@@ -79,6 +79,21 @@ type WebhookSourceSpec struct {
 	// +optional
 	CORSAllowOrigin *string `json:"corsAllowOrigin,omitempty"`
 
+	// Maximum size in bytes of the body of accepted requests.
+	// Larger requests are rejected with a 413 status.
+	// +optional
+	MaxBodySize *int64 `json:"maxBodySize,omitempty"`
+
+	// RateLimiter for incoming requests per adapter instance. Requests
+	// exceeding the limit are rejected with a 429 status.
+	// +optional
+	RateLimiter *RateLimiter `json:"rateLimiter,omitempty"`
+
+	// Maximum number of requests processed concurrently by an adapter
+	// instance. Additional requests are rejected with a 429 status.
+	// +optional
+	MaxInFlightRequests *int `json:"maxInFlightRequests,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
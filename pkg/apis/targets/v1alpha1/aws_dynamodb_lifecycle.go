@@ -28,6 +28,18 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/reconciler/resource"
 )
 
+// Accepted event types
+const (
+	// EventTypeAWSDynamoDBPut creates or replaces an item.
+	EventTypeAWSDynamoDBPut = "io.triggermesh.targets.aws.dynamodb.item.put"
+	// EventTypeAWSDynamoDBUpdate updates the attributes of an item.
+	EventTypeAWSDynamoDBUpdate = "io.triggermesh.targets.aws.dynamodb.item.update"
+	// EventTypeAWSDynamoDBDelete deletes an item.
+	EventTypeAWSDynamoDBDelete = "io.triggermesh.targets.aws.dynamodb.item.delete"
+	// EventTypeAWSDynamoDBBatchWrite puts and deletes multiple items.
+	EventTypeAWSDynamoDBBatchWrite = "io.triggermesh.targets.aws.dynamodb.items.batchwrite"
+)
+
 // Returned event types
 const (
 	// EventTypeAWSDynamoDBResult contains the result of the processing of an S3 event.
@@ -57,6 +69,17 @@ func (t *AWSDynamoDBTarget) GetStatusManager() *v1alpha1.StatusManager {
 	}
 }
 
+// AcceptedEventTypes implements IntegrationTarget.
+func (*AWSDynamoDBTarget) AcceptedEventTypes() []string {
+	return []string{
+		EventTypeAWSDynamoDBPut,
+		EventTypeAWSDynamoDBUpdate,
+		EventTypeAWSDynamoDBDelete,
+		EventTypeAWSDynamoDBBatchWrite,
+		EventTypeWildcard,
+	}
+}
+
 // GetEventTypes implements EventSource.
 func (*AWSDynamoDBTarget) GetEventTypes() []string {
 	return []string{
@@ -52,11 +52,81 @@ type AWSDynamoDBTargetSpec struct {
 	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazondynamodb.html#amazondynamodb-resources-for-iam-policies
 	ARN string `json:"arn"`
 
+	// Operation performed on the table for events which type doesn't select
+	// an operation. Defaults to 'put'.
+	// +optional
+	Operation *AWSDynamoDBTargetOperation `json:"operation,omitempty"`
+
+	// Primary key of the table, which values are extracted from the data of
+	// events. Required by the 'update' and 'delete' operations.
+	// +optional
+	Key *AWSDynamoDBTargetKey `json:"key,omitempty"`
+
+	// Update expression of the 'update' operation.
+	// https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Expressions.UpdateExpressions.html
+	// +optional
+	UpdateExpression *string `json:"updateExpression,omitempty"`
+
+	// Condition which must be satisfied for the 'put', 'update' and 'delete'
+	// operations to succeed, such as an optimistic concurrency check on a
+	// version attribute.
+	// https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Expressions.ConditionExpressions.html
+	// +optional
+	ConditionExpression *string `json:"conditionExpression,omitempty"`
+
+	// Substitution tokens for attribute names in expressions, e.g. {"#st": "status"}.
+	// +optional
+	ExpressionAttributeNames map[string]string `json:"expressionAttributeNames,omitempty"`
+
+	// Values substituted in expressions, indexed by their placeholder and
+	// selected from the data of events by their path in GJSON syntax,
+	// e.g. {":status": "order.status"}.
+	// https://github.com/tidwall/gjson/blob/master/SYNTAX.md
+	// +optional
+	ExpressionAttributeValues map[string]string `json:"expressionAttributeValues,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
 }
 
+// AWSDynamoDBTargetOperation is an operation performed on a DynamoDB table.
+type AWSDynamoDBTargetOperation string
+
+// Operations performed on a DynamoDB table.
+const (
+	// AWSDynamoDBTargetOperationPut creates or replaces an item.
+	AWSDynamoDBTargetOperationPut AWSDynamoDBTargetOperation = "put"
+	// AWSDynamoDBTargetOperationUpdate updates the attributes of an item
+	// using the update expression.
+	AWSDynamoDBTargetOperationUpdate AWSDynamoDBTargetOperation = "update"
+	// AWSDynamoDBTargetOperationDelete deletes an item.
+	AWSDynamoDBTargetOperationDelete AWSDynamoDBTargetOperation = "delete"
+	// AWSDynamoDBTargetOperationBatchWrite puts and deletes multiple items,
+	// described by a JSON array in the data of events.
+	AWSDynamoDBTargetOperationBatchWrite AWSDynamoDBTargetOperation = "batchWrite"
+)
+
+// AWSDynamoDBTargetKey describes the primary key of a DynamoDB table.
+type AWSDynamoDBTargetKey struct {
+	// Partition key of the table.
+	PartitionKey AWSDynamoDBTargetKeyAttribute `json:"partitionKey"`
+	// Sort key of the table, if the table has a composite primary key.
+	// +optional
+	SortKey *AWSDynamoDBTargetKeyAttribute `json:"sortKey,omitempty"`
+}
+
+// AWSDynamoDBTargetKeyAttribute is a key attribute of a DynamoDB table.
+type AWSDynamoDBTargetKeyAttribute struct {
+	// Name of the attribute.
+	Name string `json:"name"`
+	// Path of the value of the attribute in the data of events, in GJSON
+	// syntax. Defaults to the name of the attribute.
+	// https://github.com/tidwall/gjson/blob/master/SYNTAX.md
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AWSDynamoDBTargetList is a list of event target instances.
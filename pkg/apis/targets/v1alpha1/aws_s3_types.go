@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -58,11 +59,72 @@ type AWSS3TargetSpec struct {
 	// When this property is true, only the CloudEvent data is included.
 	DiscardCEContext bool `json:"discardCloudEventContext"`
 
+	// Go template used to generate the keys of objects created in S3.
+	// The template has access to the CloudEvent context attributes and
+	// extensions (e.g. .type, .id, .myextension), to the JSON-decoded
+	// CloudEvent data (.data), and to a 'time' function which formats the
+	// time of the CloudEvent using the given Go time layout.
+	// Example: {{.type}}/dt={{time "2006-01-02"}}/{{.id}}.json
+	//
+	// When batching is enabled, the template generates the key prefix of
+	// the batch objects which events are buffered in, and defaults to the
+	// type of the CloudEvent.
+	//
+	// If not defined, the subject of the CloudEvent is used as the object
+	// key, or a key composed of the type, source and time of the CloudEvent
+	// if the subject is empty.
+	// +optional
+	KeyTemplate *string `json:"keyTemplate,omitempty"`
+
+	// Batching of events into objects containing newline-delimited JSON
+	// (NDJSON) records. When this property is not set, each event is written
+	// to its own object.
+	// +optional
+	Batching *AWSS3TargetBatching `json:"batching,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
 }
 
+// AWSS3TargetBatching defines how events are batched into S3 objects.
+//
+// Events are buffered by key prefix, as generated by the key template, and
+// each buffer is written to a new object whenever one of the limits below is
+// reached. Each event is replied to once the object which contains it was
+// written, so MaxAge should remain below the delivery timeout of senders.
+type AWSS3TargetBatching struct {
+	// Maximum number of events in a batch.
+	// Defaults to 1000.
+	// +optional
+	MaxEvents *int32 `json:"maxEvents,omitempty"`
+
+	// Maximum size of a batch in bytes, before compression.
+	// Defaults to 5242880 (5 MiB).
+	// +optional
+	MaxBytes *int64 `json:"maxBytes,omitempty"`
+
+	// Maximum amount of time events are buffered before being written.
+	// Expressed as a duration string, which format is documented at https://pkg.go.dev/time#ParseDuration.
+	// Defaults to 1m.
+	// +optional
+	MaxAge *tmapis.Duration `json:"maxAge,omitempty"`
+
+	// Compression applied to batch objects.
+	// Supported values are "none" (default) and "gzip".
+	// +optional
+	Compression *AWSS3TargetCompression `json:"compression,omitempty"`
+}
+
+// AWSS3TargetCompression is the compression applied to batch objects.
+type AWSS3TargetCompression string
+
+// Supported compression types.
+const (
+	AWSS3TargetCompressionNone AWSS3TargetCompression = "none"
+	AWSS3TargetCompressionGzip AWSS3TargetCompression = "gzip"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AWSS3TargetList is a list of AWSS3Target resources
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 )
 
@@ -42,3 +43,34 @@ type EventOptions struct {
 	// +optional
 	PayloadPolicy *cloudevents.PayloadPolicy `json:"payloadPolicy,omitempty"`
 }
+
+// BatchOptions controls the batching of events which are sent to the
+// target's backend. Each event is replied to once the batch which contains
+// it was sent.
+type BatchOptions struct {
+	// Number of buffered events which triggers the sending of a batch.
+	// Defaults to 1, which disables batching.
+	// +optional
+	MaxEvents *int `json:"maxEvents,omitempty"`
+
+	// Size in bytes of buffered events which triggers the sending of a
+	// batch, before compression. Defaults to 1048576 (1 MiB).
+	// +optional
+	MaxBytes *int `json:"maxBytes,omitempty"`
+
+	// Maximum amount of time events are buffered before being sent as a
+	// batch. Expressed as a duration string, which format is documented at
+	// https://pkg.go.dev/time#ParseDuration. Defaults to 1s.
+	// +optional
+	Linger *tmapis.Duration `json:"linger,omitempty"`
+
+	// Whether batches are compressed with gzip. Defaults to false.
+	// +optional
+	Compress *bool `json:"compress,omitempty"`
+
+	// Number of times events which failed to be sent because of a
+	// transient error are retried, with an exponential backoff.
+	// Defaults to 0.
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
@@ -59,6 +59,10 @@ type DatadogTargetSpec struct {
 	// EventOptions for targets
 	EventOptions *EventOptions `json:"eventOptions,omitempty"`
 
+	// Batching of the log entries sent to the Datadog logs API.
+	// +optional
+	Batch *BatchOptions `json:"batch,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apis "github.com/triggermesh/triggermesh/pkg/apis"
 	commonv1alpha1 "github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 	cloudevents "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 	v1 "k8s.io/api/core/v1"
@@ -144,6 +145,49 @@ func (in *AWSDynamoDBTarget) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDynamoDBTargetKey) DeepCopyInto(out *AWSDynamoDBTargetKey) {
+	*out = *in
+	in.PartitionKey.DeepCopyInto(&out.PartitionKey)
+	if in.SortKey != nil {
+		in, out := &in.SortKey, &out.SortKey
+		*out = new(AWSDynamoDBTargetKeyAttribute)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDynamoDBTargetKey.
+func (in *AWSDynamoDBTargetKey) DeepCopy() *AWSDynamoDBTargetKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDynamoDBTargetKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDynamoDBTargetKeyAttribute) DeepCopyInto(out *AWSDynamoDBTargetKeyAttribute) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDynamoDBTargetKeyAttribute.
+func (in *AWSDynamoDBTargetKeyAttribute) DeepCopy() *AWSDynamoDBTargetKeyAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDynamoDBTargetKeyAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSDynamoDBTargetList) DeepCopyInto(out *AWSDynamoDBTargetList) {
 	*out = *in
@@ -181,6 +225,40 @@ func (in *AWSDynamoDBTargetList) DeepCopyObject() runtime.Object {
 func (in *AWSDynamoDBTargetSpec) DeepCopyInto(out *AWSDynamoDBTargetSpec) {
 	*out = *in
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(AWSDynamoDBTargetOperation)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(AWSDynamoDBTargetKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateExpression != nil {
+		in, out := &in.UpdateExpression, &out.UpdateExpression
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConditionExpression != nil {
+		in, out := &in.ConditionExpression, &out.ConditionExpression
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpressionAttributeNames != nil {
+		in, out := &in.ExpressionAttributeNames, &out.ExpressionAttributeNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpressionAttributeValues != nil {
+		in, out := &in.ExpressionAttributeValues, &out.ExpressionAttributeValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -476,6 +554,42 @@ func (in *AWSS3Target) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSS3TargetBatching) DeepCopyInto(out *AWSS3TargetBatching) {
+	*out = *in
+	if in.MaxEvents != nil {
+		in, out := &in.MaxEvents, &out.MaxEvents
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(apis.Duration)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(AWSS3TargetCompression)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSS3TargetBatching.
+func (in *AWSS3TargetBatching) DeepCopy() *AWSS3TargetBatching {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSS3TargetBatching)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSS3TargetList) DeepCopyInto(out *AWSS3TargetList) {
 	*out = *in
@@ -513,6 +627,16 @@ func (in *AWSS3TargetList) DeepCopyObject() runtime.Object {
 func (in *AWSS3TargetSpec) DeepCopyInto(out *AWSS3TargetSpec) {
 	*out = *in
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.KeyTemplate != nil {
+		in, out := &in.KeyTemplate, &out.KeyTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.Batching != nil {
+		in, out := &in.Batching, &out.Batching
+		*out = new(AWSS3TargetBatching)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -1057,6 +1181,47 @@ func (in *AzureServicePrincipal) DeepCopy() *AzureServicePrincipal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchOptions) DeepCopyInto(out *BatchOptions) {
+	*out = *in
+	if in.MaxEvents != nil {
+		in, out := &in.MaxEvents, &out.MaxEvents
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.Linger != nil {
+		in, out := &in.Linger, &out.Linger
+		*out = new(apis.Duration)
+		**out = **in
+	}
+	if in.Compress != nil {
+		in, out := &in.Compress, &out.Compress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchOptions.
+func (in *BatchOptions) DeepCopy() *BatchOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudEventsCredentials) DeepCopyInto(out *CloudEventsCredentials) {
 	*out = *in
@@ -1316,6 +1481,11 @@ func (in *DatadogTargetSpec) DeepCopyInto(out *DatadogTargetSpec) {
 		*out = new(EventOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(BatchOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -1362,6 +1532,32 @@ func (in *ElasticsearchTarget) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTargetBulk) DeepCopyInto(out *ElasticsearchTargetBulk) {
+	*out = *in
+	if in.FlushBytes != nil {
+		in, out := &in.FlushBytes, &out.FlushBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(apis.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTargetBulk.
+func (in *ElasticsearchTargetBulk) DeepCopy() *ElasticsearchTargetBulk {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTargetBulk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchTargetList) DeepCopyInto(out *ElasticsearchTargetList) {
 	*out = *in
@@ -1399,6 +1595,16 @@ func (in *ElasticsearchTargetList) DeepCopyObject() runtime.Object {
 func (in *ElasticsearchTargetSpec) DeepCopyInto(out *ElasticsearchTargetSpec) {
 	*out = *in
 	in.Connection.DeepCopyInto(&out.Connection)
+	if in.DocumentIDFromEvent != nil {
+		in, out := &in.DocumentIDFromEvent, &out.DocumentIDFromEvent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Bulk != nil {
+		in, out := &in.Bulk, &out.Bulk
+		*out = new(ElasticsearchTargetBulk)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EventOptions != nil {
 		in, out := &in.EventOptions, &out.EventOptions
 		*out = new(EventOptions)
@@ -2406,6 +2612,32 @@ func (in *KafkaTargetAuth) DeepCopy() *KafkaTargetAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTargetHeader) DeepCopyInto(out *KafkaTargetHeader) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(KafkaTargetValueSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTargetHeader.
+func (in *KafkaTargetHeader) DeepCopy() *KafkaTargetHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTargetHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaTargetKerberos) DeepCopyInto(out *KafkaTargetKerberos) {
 	*out = *in
@@ -2495,6 +2727,57 @@ func (in *KafkaTargetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTargetProducer) DeepCopyInto(out *KafkaTargetProducer) {
+	*out = *in
+	if in.Async != nil {
+		in, out := &in.Async, &out.Async
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Linger != nil {
+		in, out := &in.Linger, &out.Linger
+		*out = new(apis.Duration)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.BatchBytes != nil {
+		in, out := &in.BatchBytes, &out.BatchBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(KafkaTargetCompression)
+		**out = **in
+	}
+	if in.Acks != nil {
+		in, out := &in.Acks, &out.Acks
+		*out = new(KafkaTargetAcks)
+		**out = **in
+	}
+	if in.Idempotent != nil {
+		in, out := &in.Idempotent, &out.Idempotent
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTargetProducer.
+func (in *KafkaTargetProducer) DeepCopy() *KafkaTargetProducer {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTargetProducer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaTargetSpec) DeepCopyInto(out *KafkaTargetSpec) {
 	*out = *in
@@ -2518,6 +2801,28 @@ func (in *KafkaTargetSpec) DeepCopyInto(out *KafkaTargetSpec) {
 		*out = new(KafkaTargetAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ContentMode != nil {
+		in, out := &in.ContentMode, &out.ContentMode
+		*out = new(KafkaTargetContentMode)
+		**out = **in
+	}
+	if in.PartitionKey != nil {
+		in, out := &in.PartitionKey, &out.PartitionKey
+		*out = new(KafkaTargetValueSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]KafkaTargetHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Producer != nil {
+		in, out := &in.Producer, &out.Producer
+		*out = new(KafkaTargetProducer)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -2572,6 +2877,37 @@ func (in *KafkaTargetTLSAuth) DeepCopy() *KafkaTargetTLSAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTargetValueSelector) DeepCopyInto(out *KafkaTargetValueSelector) {
+	*out = *in
+	if in.Attribute != nil {
+		in, out := &in.Attribute, &out.Attribute
+		*out = new(string)
+		**out = **in
+	}
+	if in.Extension != nil {
+		in, out := &in.Extension, &out.Extension
+		*out = new(string)
+		**out = **in
+	}
+	if in.DataPath != nil {
+		in, out := &in.DataPath, &out.DataPath
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTargetValueSelector.
+func (in *KafkaTargetValueSelector) DeepCopy() *KafkaTargetValueSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTargetValueSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Keystore) DeepCopyInto(out *Keystore) {
 	*out = *in
@@ -2671,7 +3007,17 @@ func (in *LogzMetricsTargetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogzMetricsTargetSpec) DeepCopyInto(out *LogzMetricsTargetSpec) {
 	*out = *in
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(OpenTelemetryExporter)
+		**out = **in
+	}
 	in.Connection.DeepCopyInto(&out.Connection)
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(OTLPConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Instruments != nil {
 		in, out := &in.Instruments, &out.Instruments
 		*out = make([]Instrument, len(*in))
@@ -2772,6 +3118,11 @@ func (in *LogzTargetSpec) DeepCopyInto(out *LogzTargetSpec) {
 		*out = new(EventOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(BatchOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -2894,6 +3245,39 @@ func (in *MongoDBTargetSpec) DeepCopy() *MongoDBTargetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OTLPConnection) DeepCopyInto(out *OTLPConnection) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(OTLPProtocol)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OTLPConnection.
+func (in *OTLPConnection) DeepCopy() *OTLPConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(OTLPConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OracleFunctionSpecSpec) DeepCopyInto(out *OracleFunctionSpecSpec) {
 	*out = *in
@@ -3314,6 +3698,16 @@ func (in *SlackTargetList) DeepCopyObject() runtime.Object {
 func (in *SlackTargetSpec) DeepCopyInto(out *SlackTargetSpec) {
 	*out = *in
 	in.Token.DeepCopyInto(&out.Token)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(SlackTargetTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventOptions != nil {
+		in, out := &in.EventOptions, &out.EventOptions
+		*out = new(EventOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
@@ -3332,6 +3726,37 @@ func (in *SlackTargetSpec) DeepCopy() *SlackTargetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackTargetTemplate) DeepCopyInto(out *SlackTargetTemplate) {
+	*out = *in
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = new(string)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValueFromConfigMap != nil {
+		in, out := &in.ValueFromConfigMap, &out.ValueFromConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackTargetTemplate.
+func (in *SlackTargetTemplate) DeepCopy() *SlackTargetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackTargetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SolaceTarget) DeepCopyInto(out *SolaceTarget) {
 	*out = *in
@@ -3572,6 +3997,11 @@ func (in *SplunkTargetSpec) DeepCopyInto(out *SplunkTargetSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(BatchOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdapterOverrides != nil {
 		in, out := &in.AdapterOverrides, &out.AdapterOverrides
 		*out = new(commonv1alpha1.AdapterOverrides)
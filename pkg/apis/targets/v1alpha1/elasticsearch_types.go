@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -51,8 +52,27 @@ type ElasticsearchTargetSpec struct {
 	Connection Connection `json:"connection"`
 
 	// IndexName to write to.
+	//
+	// The index name can be a Go template, which has access to the CloudEvent
+	// context attributes and extensions (e.g. .type, .source, .myextension),
+	// to the JSON-decoded CloudEvent data (.data), and to a 'time' function
+	// which formats the time of the CloudEvent using the given Go time layout.
+	// Rendered index names are lowercased.
+	// Example: logs-{{.type}}-{{time "2006.01.02"}}
 	IndexName string `json:"indexName"`
 
+	// Whether the ID of the CloudEvent is used as the ID of the indexed
+	// document, so that redelivered events overwrite the document they
+	// created instead of creating duplicates.
+	// +optional
+	DocumentIDFromEvent *bool `json:"documentIDFromEvent,omitempty"`
+
+	// Bulk indexing of events. When this property is set, events are
+	// buffered and indexed using the Elasticsearch Bulk API. Each event is
+	// replied to with the result of the indexing of its own document.
+	// +optional
+	Bulk *ElasticsearchTargetBulk `json:"bulk,omitempty"`
+
 	// Whether to omit CloudEvent context attributes in documents created in Elasticsearch.
 	// When this property is false (default), the entire CloudEvent payload is included.
 	// When this property is true, only the CloudEvent data is included.
@@ -84,6 +104,21 @@ type Connection struct {
 	APIKey *SecretValueFromSource `json:"apiKey,omitempty"`
 }
 
+// ElasticsearchTargetBulk contains the options of bulk indexing.
+type ElasticsearchTargetBulk struct {
+	// Size in bytes of buffered documents which triggers a bulk request.
+	// Defaults to 5242880 (5 MiB).
+	// +optional
+	FlushBytes *int32 `json:"flushBytes,omitempty"`
+
+	// Maximum amount of time documents are buffered before a bulk request
+	// is sent. Expressed as a duration string, which format is documented
+	// at https://pkg.go.dev/time#ParseDuration.
+	// Defaults to 1s.
+	// +optional
+	FlushInterval *tmapis.Duration `json:"flushInterval,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ElasticsearchTargetList is a list of event target instances.
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 )
 
@@ -66,11 +67,138 @@ type KafkaTargetSpec struct {
 	// When this property is true, only the CloudEvent data is included.
 	DiscardCEContext bool `json:"discardCloudEventContext"`
 
+	// ContentMode of the records produced to Kafka, as defined by the
+	// CloudEvents Kafka protocol binding. Defaults to "structured".
+	// Ignored when the CloudEvent context is discarded.
+	// +optional
+	ContentMode *KafkaTargetContentMode `json:"contentMode,omitempty"`
+
+	// PartitionKey selects the value of the event used as the key of
+	// produced records. Defaults to the ID of the event.
+	// +optional
+	PartitionKey *KafkaTargetValueSelector `json:"partitionKey,omitempty"`
+
+	// Headers to set on produced records, either static or derived from
+	// the event.
+	// +optional
+	Headers []KafkaTargetHeader `json:"headers,omitempty"`
+
+	// Producer tunes how records are produced to Kafka.
+	// +optional
+	Producer *KafkaTargetProducer `json:"producer,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
 }
 
+// KafkaTargetContentMode is the content mode of the CloudEvents Kafka protocol binding.
+type KafkaTargetContentMode string
+
+// Content modes of the CloudEvents Kafka protocol binding.
+const (
+	// KafkaTargetContentModeStructured encodes the entire event as the value of the record.
+	KafkaTargetContentModeStructured KafkaTargetContentMode = "structured"
+	// KafkaTargetContentModeBinary encodes the event data as the value of the record,
+	// and event attributes as 'ce_' prefixed record headers.
+	KafkaTargetContentModeBinary KafkaTargetContentMode = "binary"
+)
+
+// KafkaTargetValueSelector selects a value from a CloudEvent. Exactly one
+// of its fields must be set.
+type KafkaTargetValueSelector struct {
+	// Name of a CloudEvent context attribute (e.g. "subject").
+	// +optional
+	Attribute *string `json:"attribute,omitempty"`
+
+	// Name of a CloudEvent extension attribute.
+	// +optional
+	Extension *string `json:"extension,omitempty"`
+
+	// Path of a value inside the JSON data of the CloudEvent, in GJSON syntax
+	// (e.g. "customer.id").
+	// +optional
+	DataPath *string `json:"dataPath,omitempty"`
+}
+
+// KafkaTargetHeader is a header set on produced records. Either Value or
+// ValueFrom must be set.
+type KafkaTargetHeader struct {
+	// Name of the header.
+	Name string `json:"name"`
+
+	// Static value of the header.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// Selector of the event value to use as the value of the header.
+	// +optional
+	ValueFrom *KafkaTargetValueSelector `json:"valueFrom,omitempty"`
+}
+
+// KafkaTargetProducer contains the tuning parameters of the Kafka producer.
+type KafkaTargetProducer struct {
+	// Async enables the asynchronous producer, which batches records from
+	// concurrent events instead of producing them one at a time. Each event
+	// is still only acknowledged once its record is acknowledged by Kafka.
+	// +optional
+	Async *bool `json:"async,omitempty"`
+
+	// Linger is the maximum amount of time records are buffered before
+	// being sent as a batch.
+	// +optional
+	Linger *tmapis.Duration `json:"linger,omitempty"`
+
+	// BatchSize is the number of records which triggers the sending of a
+	// batch.
+	// +optional
+	BatchSize *int `json:"batchSize,omitempty"`
+
+	// BatchBytes is the size in bytes of buffered records which triggers the
+	// sending of a batch.
+	// +optional
+	BatchBytes *int `json:"batchBytes,omitempty"`
+
+	// Compression codec applied to produced batches.
+	// +optional
+	Compression *KafkaTargetCompression `json:"compression,omitempty"`
+
+	// Acks is the level of acknowledgement required from the brokers
+	// before a record is considered produced. Defaults to "leader".
+	// +optional
+	Acks *KafkaTargetAcks `json:"acks,omitempty"`
+
+	// Idempotent ensures that records are written exactly once per
+	// partition. Requires "all" acks.
+	// +optional
+	Idempotent *bool `json:"idempotent,omitempty"`
+}
+
+// KafkaTargetCompression is a compression codec for produced records.
+type KafkaTargetCompression string
+
+// Supported compression codecs.
+const (
+	KafkaTargetCompressionNone   KafkaTargetCompression = "none"
+	KafkaTargetCompressionGZIP   KafkaTargetCompression = "gzip"
+	KafkaTargetCompressionSnappy KafkaTargetCompression = "snappy"
+	KafkaTargetCompressionLZ4    KafkaTargetCompression = "lz4"
+	KafkaTargetCompressionZSTD   KafkaTargetCompression = "zstd"
+)
+
+// KafkaTargetAcks is a level of acknowledgement of produced records.
+type KafkaTargetAcks string
+
+// Supported acknowledgement levels.
+const (
+	// KafkaTargetAcksNone does not wait for any acknowledgement.
+	KafkaTargetAcksNone KafkaTargetAcks = "none"
+	// KafkaTargetAcksLeader waits for the partition leader to commit the record.
+	KafkaTargetAcksLeader KafkaTargetAcks = "leader"
+	// KafkaTargetAcksAll waits for all in-sync replicas to commit the record.
+	KafkaTargetAcksAll KafkaTargetAcks = "all"
+)
+
 // KafkaTargetAuth contains Authentication method used to interact with Kafka.
 type KafkaTargetAuth struct {
 	Kerberos *KafkaTargetKerberos `json:"kerberos,omitempty"`
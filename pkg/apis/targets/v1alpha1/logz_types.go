@@ -53,6 +53,10 @@ type LogzTargetSpec struct {
 	// EventOptions for targets
 	EventOptions *EventOptions `json:"eventOptions,omitempty"`
 
+	// Batching of the logs sent to the listener.
+	// +optional
+	Batch *BatchOptions `json:"batch,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
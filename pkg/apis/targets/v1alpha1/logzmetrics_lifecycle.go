@@ -30,6 +30,8 @@ import (
 // Managed event types
 const (
 	EventTypeOpenTelemetryMetricsPush = "io.triggermesh.opentelemetry.metrics.push"
+	EventTypeOpenTelemetryLogsPush    = "io.triggermesh.opentelemetry.logs.push"
+	EventTypeOpenTelemetryTracesPush  = "io.triggermesh.opentelemetry.traces.push"
 )
 
 // GetGroupVersionKind implements kmeta.OwnerRefable.
@@ -56,10 +58,20 @@ func (t *LogzMetricsTarget) GetStatusManager() *v1alpha1.StatusManager {
 }
 
 // AcceptedEventTypes implements IntegrationTarget.
-func (*LogzMetricsTarget) AcceptedEventTypes() []string {
-	return []string{
+func (t *LogzMetricsTarget) AcceptedEventTypes() []string {
+	types := []string{
 		EventTypeOpenTelemetryMetricsPush,
 	}
+
+	// logs and traces can only be pushed to an OTLP receiver
+	if t.Spec.Exporter != nil && *t.Spec.Exporter == OpenTelemetryExporterOTLP {
+		types = append(types,
+			EventTypeOpenTelemetryLogsPush,
+			EventTypeOpenTelemetryTracesPush,
+		)
+	}
+
+	return types
 }
 
 // GetAdapterOverrides implements AdapterConfigurable.
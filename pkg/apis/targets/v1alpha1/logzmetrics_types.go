@@ -31,7 +31,10 @@ import (
 // to push new observations.
 //
 // The target works using an OpenTelemetry to Cortex adapter, and is able to manage
-// OpenTelemetry Synchronous Kinds.
+// OpenTelemetry Synchronous Kinds. Metrics can alternatively be pushed to an OTLP
+// receiver, such as an OpenTelemetry Collector, which also enables the forwarding of
+// OTLP logs and traces received as `io.triggermesh.opentelemetry.logs.push` and
+// `io.triggermesh.opentelemetry.traces.push` CloudEvents.
 // In case of an error a CloudEvent response conformant with https://docs.triggermesh.io/schemas/triggermesh.error.json
 // and with an the attribute extension `category: error` can be produced.
 //
@@ -54,9 +57,23 @@ var (
 
 // LogzMetricsTargetSpec defines the desired state of the event target.
 type LogzMetricsTargetSpec struct {
+	// Exporter used to push telemetry data. Supported values are:
+	//
+	// - cortex: pushes metrics to Logz using the Prometheus remote write protocol (default).
+	// - otlp: pushes metrics, logs and traces to an OTLP receiver.
+	// +optional
+	Exporter *OpenTelemetryExporter `json:"exporter,omitempty"`
+
 	// Connection information for LogzMetrics.
+	// Required by the cortex exporter.
+	// +optional
 	Connection LogzMetricsConnection `json:"connection"`
 
+	// Connection information for the OTLP receiver.
+	// Required by the otlp exporter.
+	// +optional
+	OTLP *OTLPConnection `json:"otlp,omitempty"`
+
 	// Instruments configured for pushing metrics. It is mandatory that all metrics
 	// pushed by using this target are pre-registered using this list.
 	Instruments []Instrument `json:"instruments"`
@@ -79,6 +96,44 @@ type LogzMetricsConnection struct {
 	ListenerURL string `json:"listenerURL"`
 }
 
+// OpenTelemetryExporter is the exporter used to push telemetry data.
+type OpenTelemetryExporter string
+
+// Supported exporters.
+const (
+	OpenTelemetryExporterCortex OpenTelemetryExporter = "cortex"
+	OpenTelemetryExporterOTLP   OpenTelemetryExporter = "otlp"
+)
+
+// OTLPProtocol is the transport protocol of OTLP.
+type OTLPProtocol string
+
+// Supported OTLP transport protocols.
+const (
+	OTLPProtocolGRPC OTLPProtocol = "grpc"
+	OTLPProtocolHTTP OTLPProtocol = "http"
+)
+
+// OTLPConnection contains the information to connect to an OTLP receiver.
+type OTLPConnection struct {
+	// Address of the OTLP receiver. It is a host:port address when using
+	// gRPC, and the base URL of the receiver, to which the path of each
+	// signal is appended, when using HTTP.
+	Endpoint string `json:"endpoint"`
+
+	// Transport protocol. Supported values are grpc (default) and http.
+	// +optional
+	Protocol *OTLPProtocol `json:"protocol,omitempty"`
+
+	// Disables TLS when using gRPC.
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+
+	// Headers sent with each request, e.g. for authentication.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
 // InstrumentKind as defined by OpenTelemetry.
 type InstrumentKind string
 
@@ -27,9 +27,12 @@ import (
 
 // Managed event types
 const (
-	EventTypeMongoDBInsert  = "io.triggermesh.mongodb.insert"
-	EventTypeMongoDBQueryKV = "io.triggermesh.mongodb.query.kv"
-	EventTypeMongoDBUpdate  = "io.triggermesh.mongodb.update"
+	EventTypeMongoDBInsert         = "io.triggermesh.mongodb.insert"
+	EventTypeMongoDBQueryKV        = "io.triggermesh.mongodb.query.kv"
+	EventTypeMongoDBQueryAggregate = "io.triggermesh.mongodb.query.aggregate"
+	EventTypeMongoDBUpdate         = "io.triggermesh.mongodb.update"
+	EventTypeMongoDBDelete         = "io.triggermesh.mongodb.delete"
+	EventTypeMongoDBBulkWrite      = "io.triggermesh.mongodb.bulkwrite"
 
 	EventTypeMongoDBStaticResponse = "io.triggermesh.mongodb.response"
 	EventTypeMongoDBQueryResponse  = "io.triggermesh.mongodb.query.response"
@@ -63,7 +66,10 @@ func (*MongoDBTarget) AcceptedEventTypes() []string {
 	return []string{
 		EventTypeMongoDBInsert,
 		EventTypeMongoDBQueryKV,
+		EventTypeMongoDBQueryAggregate,
 		EventTypeMongoDBUpdate,
+		EventTypeMongoDBDelete,
+		EventTypeMongoDBBulkWrite,
 	}
 }
 
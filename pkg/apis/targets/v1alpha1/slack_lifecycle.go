@@ -62,12 +62,19 @@ func (t *SlackTarget) GetStatusManager() *v1alpha1.StatusManager {
 }
 
 // AcceptedEventTypes implements IntegrationTarget.
-func (*SlackTarget) AcceptedEventTypes() []string {
-	return []string{
+func (t *SlackTarget) AcceptedEventTypes() []string {
+	types := []string{
 		EventTypeSlackPostMessage,
 		EventTypeSlackScheduleMessage,
 		EventTypeSlackUpdateMessage,
 	}
+
+	// events of any type are rendered using the template
+	if t.Spec.Template != nil {
+		types = append(types, EventTypeWildcard)
+	}
+
+	return types
 }
 
 // GetEventTypes implements EventSource.
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
@@ -40,11 +41,38 @@ type SlackTargetSpec struct {
 	// Token for Slack App
 	Token SecretValueFromSource `json:"token"`
 
+	// Template renders the payload of Slack Web API calls from events of
+	// any type. Events which type is prefixed with "com.slack.webapi." are
+	// still sent as is.
+	// +optional
+	Template *SlackTargetTemplate `json:"template,omitempty"`
+
+	// EventOptions for targets
+	// +optional
+	EventOptions *EventOptions `json:"eventOptions,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
 }
 
+// SlackTargetTemplate is a Go template which renders the payload of a Slack
+// Web API method, such as a Block Kit message. Only one of the template
+// sources may be specified.
+type SlackTargetTemplate struct {
+	// Slack Web API method invoked with the rendered payload.
+	// Defaults to "chat.postMessage".
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Inline template.
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// Template sourced from a Kubernetes ConfigMap.
+	// +optional
+	ValueFromConfigMap *corev1.ConfigMapKeySelector `json:"valueFromConfigMap,omitempty"`
+}
+
 // Check the interfaces the event target should be implementing.
 var (
 	_ v1alpha1.Reconcilable        = (*SlackTarget)(nil)
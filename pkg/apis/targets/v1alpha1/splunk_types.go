@@ -61,6 +61,10 @@ type SplunkTargetSpec struct {
 	// +optional
 	SkipTLSVerify *bool `json:"skipTLSVerify,omitempty"`
 
+	// Batching of the events sent to the HEC.
+	// +optional
+	Batch *BatchOptions `json:"batch,omitempty"`
+
 	// Adapter spec overrides parameters.
 	// +optional
 	AdapterOverrides *v1alpha1.AdapterOverrides `json:"adapterOverrides,omitempty"`
@@ -28,10 +28,18 @@ type FakeRoutingV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeRoutingV1alpha1) Aggregators(namespace string) v1alpha1.AggregatorInterface {
+	return &FakeAggregators{c, namespace}
+}
+
 func (c *FakeRoutingV1alpha1) Filters(namespace string) v1alpha1.FilterInterface {
 	return &FakeFilters{c, namespace}
 }
 
+func (c *FakeRoutingV1alpha1) Routers(namespace string) v1alpha1.RouterInterface {
+	return &FakeRouters{c, namespace}
+}
+
 func (c *FakeRoutingV1alpha1) Splitters(namespace string) v1alpha1.SplitterInterface {
 	return &FakeSplitters{c, namespace}
 }
@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha1
 
+type AggregatorExpansion interface{}
+
 type FilterExpansion interface{}
 
+type RouterExpansion interface{}
+
 type SplitterExpansion interface{}
@@ -28,7 +28,9 @@ import (
 
 type RoutingV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AggregatorsGetter
 	FiltersGetter
+	RoutersGetter
 	SplittersGetter
 }
 
@@ -37,10 +39,18 @@ type RoutingV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *RoutingV1alpha1Client) Aggregators(namespace string) AggregatorInterface {
+	return newAggregators(c, namespace)
+}
+
 func (c *RoutingV1alpha1Client) Filters(namespace string) FilterInterface {
 	return newFilters(c, namespace)
 }
 
+func (c *RoutingV1alpha1Client) Routers(namespace string) RouterInterface {
+	return newRouters(c, namespace)
+}
+
 func (c *RoutingV1alpha1Client) Splitters(namespace string) SplitterInterface {
 	return newSplitters(c, namespace)
 }
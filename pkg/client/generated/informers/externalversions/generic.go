@@ -73,8 +73,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Flow().V1alpha1().XSLTTransformations().Informer()}, nil
 
 		// Group=routing.triggermesh.io, Version=v1alpha1
+	case routingv1alpha1.SchemeGroupVersion.WithResource("aggregators"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Routing().V1alpha1().Aggregators().Informer()}, nil
 	case routingv1alpha1.SchemeGroupVersion.WithResource("filters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Routing().V1alpha1().Filters().Informer()}, nil
+	case routingv1alpha1.SchemeGroupVersion.WithResource("routers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Routing().V1alpha1().Routers().Informer()}, nil
 	case routingv1alpha1.SchemeGroupVersion.WithResource("splitters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Routing().V1alpha1().Splitters().Informer()}, nil
 
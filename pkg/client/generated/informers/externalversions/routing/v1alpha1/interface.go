@@ -24,8 +24,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Aggregators returns a AggregatorInformer.
+	Aggregators() AggregatorInformer
 	// Filters returns a FilterInformer.
 	Filters() FilterInformer
+	// Routers returns a RouterInformer.
+	Routers() RouterInformer
 	// Splitters returns a SplitterInformer.
 	Splitters() SplitterInformer
 }
@@ -41,11 +45,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Aggregators returns a AggregatorInformer.
+func (v *version) Aggregators() AggregatorInformer {
+	return &aggregatorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Filters returns a FilterInformer.
 func (v *version) Filters() FilterInformer {
 	return &filterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Routers returns a RouterInformer.
+func (v *version) Routers() RouterInformer {
+	return &routerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Splitters returns a SplitterInformer.
 func (v *version) Splitters() SplitterInformer {
 	return &splitterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
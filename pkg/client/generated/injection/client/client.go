@@ -935,6 +935,137 @@ func (w *wrapRoutingV1alpha1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapRoutingV1alpha1) Aggregators(namespace string) typedroutingv1alpha1.AggregatorInterface {
+	return &wrapRoutingV1alpha1AggregatorImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "routing.triggermesh.io",
+			Version:  "v1alpha1",
+			Resource: "aggregators",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapRoutingV1alpha1AggregatorImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedroutingv1alpha1.AggregatorInterface = (*wrapRoutingV1alpha1AggregatorImpl)(nil)
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Create(ctx context.Context, in *routingv1alpha1.Aggregator, opts v1.CreateOptions) (*routingv1alpha1.Aggregator, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Aggregator",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Aggregator{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*routingv1alpha1.Aggregator, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Aggregator{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) List(ctx context.Context, opts v1.ListOptions) (*routingv1alpha1.AggregatorList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.AggregatorList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *routingv1alpha1.Aggregator, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Aggregator{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Update(ctx context.Context, in *routingv1alpha1.Aggregator, opts v1.UpdateOptions) (*routingv1alpha1.Aggregator, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Aggregator",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Aggregator{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) UpdateStatus(ctx context.Context, in *routingv1alpha1.Aggregator, opts v1.UpdateOptions) (*routingv1alpha1.Aggregator, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Aggregator",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Aggregator{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1AggregatorImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapRoutingV1alpha1) Filters(namespace string) typedroutingv1alpha1.FilterInterface {
 	return &wrapRoutingV1alpha1FilterImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -1066,6 +1197,137 @@ func (w *wrapRoutingV1alpha1FilterImpl) Watch(ctx context.Context, opts v1.ListO
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapRoutingV1alpha1) Routers(namespace string) typedroutingv1alpha1.RouterInterface {
+	return &wrapRoutingV1alpha1RouterImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "routing.triggermesh.io",
+			Version:  "v1alpha1",
+			Resource: "routers",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapRoutingV1alpha1RouterImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedroutingv1alpha1.RouterInterface = (*wrapRoutingV1alpha1RouterImpl)(nil)
+
+func (w *wrapRoutingV1alpha1RouterImpl) Create(ctx context.Context, in *routingv1alpha1.Router, opts v1.CreateOptions) (*routingv1alpha1.Router, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Router",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Router{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*routingv1alpha1.Router, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Router{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) List(ctx context.Context, opts v1.ListOptions) (*routingv1alpha1.RouterList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.RouterList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *routingv1alpha1.Router, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Router{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) Update(ctx context.Context, in *routingv1alpha1.Router, opts v1.UpdateOptions) (*routingv1alpha1.Router, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Router",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Router{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) UpdateStatus(ctx context.Context, in *routingv1alpha1.Router, opts v1.UpdateOptions) (*routingv1alpha1.Router, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "routing.triggermesh.io",
+		Version: "v1alpha1",
+		Kind:    "Router",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &routingv1alpha1.Router{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapRoutingV1alpha1RouterImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapRoutingV1alpha1) Splitters(namespace string) typedroutingv1alpha1.SplitterInterface {
 	return &wrapRoutingV1alpha1SplitterImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -0,0 +1,235 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Acknowledgement levels of produced records.
+const (
+	AcksNone   = "none"
+	AcksLeader = "leader"
+	AcksAll    = "all"
+)
+
+// ProducerOptions contains the tuning parameters of a Kafka producer.
+// Zero values leave the sarama defaults untouched.
+type ProducerOptions struct {
+	// Maximum amount of time records are buffered before being sent.
+	Linger time.Duration
+	// Number of buffered records which triggers the sending of a batch.
+	BatchSize int
+	// Size in bytes of buffered records which triggers the sending of a batch.
+	BatchBytes int
+	// Compression codec (none|gzip|snappy|lz4|zstd).
+	Compression string
+	// Acknowledgement level (none|leader|all).
+	Acks string
+	// Whether records are written exactly once per partition.
+	Idempotent bool
+}
+
+// ApplyProducerOptions sets the given producer options on a sarama
+// configuration, adjusting the settings those options depend on.
+func ApplyProducerOptions(cfg *sarama.Config, opts ProducerOptions) error {
+	if opts.Linger > 0 {
+		cfg.Producer.Flush.Frequency = opts.Linger
+	}
+	if opts.BatchSize > 0 {
+		cfg.Producer.Flush.Messages = opts.BatchSize
+	}
+	if opts.BatchBytes > 0 {
+		cfg.Producer.Flush.Bytes = opts.BatchBytes
+	}
+
+	if opts.Compression != "" {
+		var codec sarama.CompressionCodec
+		if err := codec.UnmarshalText([]byte(opts.Compression)); err != nil {
+			return err
+		}
+		cfg.Producer.Compression = codec
+
+		// zstd is only supported by Kafka >= 2.1
+		if codec == sarama.CompressionZSTD && !cfg.Version.IsAtLeast(sarama.V2_1_0_0) {
+			cfg.Version = sarama.V2_1_0_0
+		}
+	}
+
+	switch opts.Acks {
+	case "":
+	case AcksNone:
+		cfg.Producer.RequiredAcks = sarama.NoResponse
+	case AcksLeader:
+		cfg.Producer.RequiredAcks = sarama.WaitForLocal
+	case AcksAll:
+		cfg.Producer.RequiredAcks = sarama.WaitForAll
+	default:
+		return fmt.Errorf("unsupported acknowledgement level %q", opts.Acks)
+	}
+
+	if opts.Idempotent {
+		if opts.Acks != "" && opts.Acks != AcksAll {
+			return fmt.Errorf("idempotent producer requires %q acks, got %q", AcksAll, opts.Acks)
+		}
+
+		cfg.Producer.Idempotent = true
+		cfg.Producer.RequiredAcks = sarama.WaitForAll
+		cfg.Net.MaxOpenRequests = 1
+		if cfg.Producer.Retry.Max == 0 {
+			cfg.Producer.Retry.Max = 1
+		}
+		if !cfg.Version.IsAtLeast(sarama.V0_11_0_0) {
+			cfg.Version = sarama.V0_11_0_0
+		}
+	}
+
+	return nil
+}
+
+// errProducerClosed is returned when sending messages to a closed producer.
+var errProducerClosed = errors.New("kafka producer is closed")
+
+// AsyncProducer produces messages to Kafka asynchronously, letting sarama
+// batch the records of concurrent callers, while still notifying each
+// caller once its record is acknowledged.
+type AsyncProducer struct {
+	client   sarama.Client
+	producer sarama.AsyncProducer
+
+	// closing the done channel rejects new messages.
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// tracks the goroutines dispatching producer results.
+	wg sync.WaitGroup
+
+	// prevents sending to the producer input after it has been closed.
+	m sync.RWMutex
+}
+
+// NewAsyncProducer returns an AsyncProducer connected to the given brokers.
+// The producer has its own client, which is not affected by the connection
+// refreshes of a SaramaCachedClient.
+func NewAsyncProducer(bootstrapServers []string, cfg *sarama.Config) (*AsyncProducer, error) {
+	// results are required to notify callers.
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Return.Errors = true
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("sarama kafka producer configuration is not valid: %w", err)
+	}
+
+	client, err := sarama.NewClient(bootstrapServers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create sarama kafka client: %w", err)
+	}
+
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("could not create sarama kafka async producer: %w", err)
+	}
+
+	p := &AsyncProducer{
+		client:   client,
+		producer: producer,
+		done:     make(chan struct{}),
+	}
+
+	p.wg.Add(2)
+	go func() {
+		defer p.wg.Done()
+		for msg := range producer.Successes() {
+			notify(msg, nil)
+		}
+	}()
+	go func() {
+		defer p.wg.Done()
+		for perr := range producer.Errors() {
+			notify(perr.Msg, perr.Err)
+		}
+	}()
+
+	return p, nil
+}
+
+// SendMessage enqueues the given message and blocks until it is either
+// acknowledged by Kafka or failed to be produced, or until the context is
+// done.
+func (p *AsyncProducer) SendMessage(ctx context.Context, msg *sarama.ProducerMessage) error {
+	res := make(chan error, 1)
+	msg.Metadata = res
+
+	p.m.RLock()
+	select {
+	case <-p.done:
+		p.m.RUnlock()
+		return errProducerClosed
+	default:
+	}
+
+	select {
+	case p.producer.Input() <- msg:
+		p.m.RUnlock()
+	case <-ctx.Done():
+		p.m.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-res:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes buffered messages and closes the producer and its client.
+func (p *AsyncProducer) Close() error {
+	var err error
+
+	p.closeOnce.Do(func() {
+		close(p.done)
+
+		// wait for in-progress sends to be enqueued
+		p.m.Lock()
+		defer p.m.Unlock()
+
+		// results of flushed messages are dispatched to their callers
+		// until the producer closes its output channels.
+		p.producer.AsyncClose()
+		p.wg.Wait()
+
+		err = p.client.Close()
+	})
+
+	return err
+}
+
+// notify sends the result of a produced message to its caller.
+func notify(msg *sarama.ProducerMessage, err error) {
+	if res, ok := msg.Metadata.(chan error); ok {
+		res <- err
+	}
+}
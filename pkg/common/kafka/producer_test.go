@@ -0,0 +1,160 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProducerOptions(t *testing.T) {
+	testCases := map[string]struct {
+		opts      ProducerOptions
+		expectErr bool
+		check     func(*testing.T, *sarama.Config)
+	}{
+		"defaults untouched": {
+			opts: ProducerOptions{},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				def := sarama.NewConfig()
+				assert.Equal(t, def.Producer.Flush, cfg.Producer.Flush)
+				assert.Equal(t, def.Producer.Compression, cfg.Producer.Compression)
+				assert.Equal(t, def.Producer.RequiredAcks, cfg.Producer.RequiredAcks)
+				assert.False(t, cfg.Producer.Idempotent)
+			},
+		},
+		"batching": {
+			opts: ProducerOptions{
+				Linger:     20 * time.Millisecond,
+				BatchSize:  100,
+				BatchBytes: 1 << 20,
+			},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				assert.Equal(t, 20*time.Millisecond, cfg.Producer.Flush.Frequency)
+				assert.Equal(t, 100, cfg.Producer.Flush.Messages)
+				assert.Equal(t, 1<<20, cfg.Producer.Flush.Bytes)
+			},
+		},
+		"zstd compression": {
+			opts: ProducerOptions{Compression: "zstd"},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				assert.Equal(t, sarama.CompressionZSTD, cfg.Producer.Compression)
+				assert.True(t, cfg.Version.IsAtLeast(sarama.V2_1_0_0))
+			},
+		},
+		"unknown compression": {
+			opts:      ProducerOptions{Compression: "brotli"},
+			expectErr: true,
+		},
+		"acks": {
+			opts: ProducerOptions{Acks: AcksNone},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				assert.Equal(t, sarama.NoResponse, cfg.Producer.RequiredAcks)
+			},
+		},
+		"unknown acks": {
+			opts:      ProducerOptions{Acks: "some"},
+			expectErr: true,
+		},
+		"idempotent": {
+			opts: ProducerOptions{Idempotent: true},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				assert.True(t, cfg.Producer.Idempotent)
+				assert.Equal(t, sarama.WaitForAll, cfg.Producer.RequiredAcks)
+				assert.Equal(t, 1, cfg.Net.MaxOpenRequests)
+			},
+		},
+		"idempotent with leader acks": {
+			opts:      ProducerOptions{Idempotent: true, Acks: AcksLeader},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			cfg := sarama.NewConfig()
+
+			err := ApplyProducerOptions(cfg, tc.opts)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+
+			tc.check(t, cfg)
+		})
+	}
+}
+
+func TestAsyncProducer(t *testing.T) {
+	const topic = "test-topic"
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t).SetVersion(3),
+	})
+
+	cfg := sarama.NewConfig()
+	require.NoError(t, ApplyProducerOptions(cfg, ProducerOptions{
+		Linger:    10 * time.Millisecond,
+		BatchSize: 10,
+	}))
+
+	p, err := NewAsyncProducer([]string{broker.Addr()}, cfg)
+	require.NoError(t, err)
+
+	const numMsgs = 25
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numMsgs)
+
+	for i := 0; i < numMsgs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- p.SendMessage(context.Background(), &sarama.ProducerMessage{
+				Topic: topic,
+				Value: sarama.StringEncoder("test"),
+			})
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	require.NoError(t, p.Close())
+
+	err = p.SendMessage(context.Background(), &sarama.ProducerMessage{Topic: topic})
+	assert.ErrorIs(t, err, errProducerClosed)
+}
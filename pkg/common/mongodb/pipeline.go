@@ -0,0 +1,37 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mongodb contains helpers shared by the MongoDB source and target.
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ParsePipeline parses an aggregation pipeline expressed as a JSON array of
+// stages in MongoDB Extended JSON format.
+func ParsePipeline(pipeline []byte) (mongo.Pipeline, error) {
+	// Extended JSON can only be unmarshaled into a document
+	var doc struct {
+		Pipeline mongo.Pipeline `bson:"pipeline"`
+	}
+	extJSON := append(append([]byte(`{"pipeline":`), pipeline...), '}')
+	if err := bson.UnmarshalExtJSON(extJSON, false, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Pipeline, nil
+}
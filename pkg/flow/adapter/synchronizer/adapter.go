@@ -18,6 +18,7 @@ package synchronizer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"knative.dev/pkg/logging"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/flow"
+	"github.com/triggermesh/triggermesh/pkg/apis/flow/v1alpha1"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
 	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 )
@@ -45,7 +47,14 @@ type adapter struct {
 	correlationKey  *correlationKey
 	responseTimeout time.Duration
 
-	sessions *storage
+	// plainHTTP enables the support of clients which do not speak CloudEvents.
+	plainHTTP   bool
+	eventSource string
+	// async enables the polling of responses by the clients.
+	async             bool
+	responseRetention time.Duration
+
+	sessions sessionStorage
 	sinkURL  string
 	bridgeID string
 }
@@ -69,6 +78,16 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		logger.Panic("Cannot create an instance of Correlation Key: %v", err)
 	}
 
+	var sessions sessionStorage = newStorage()
+	if env.RedisAddress != "" {
+		keyPrefix := fmt.Sprintf("synchronizer/%s/%s/", envAcc.GetNamespace(), envAcc.GetName())
+		sessions, err = newRedisStorage(ctx, env.RedisAddress, env.RedisPassword, env.RedisDatabase,
+			keyPrefix, env.ResponseWaitTimeout, logger)
+		if err != nil {
+			logger.Panicf("Cannot create Redis session storage: %v", err)
+		}
+	}
+
 	return &adapter{
 		ceClient: ceClient,
 		logger:   logger,
@@ -79,7 +98,12 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		correlationKey:  key,
 		responseTimeout: env.ResponseWaitTimeout,
 
-		sessions: newStorage(),
+		plainHTTP:         env.ClientProtocol == string(v1alpha1.ClientProtocolHTTP),
+		eventSource:       "synchronizer/" + envAcc.GetName(),
+		async:             env.ResponseMode == string(v1alpha1.ResponseModeAsync),
+		responseRetention: env.ResponseRetention,
+
+		sessions: sessions,
 		sinkURL:  env.Sink,
 		bridgeID: env.BridgeIdentifier,
 	}
@@ -89,6 +113,12 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 func (a *adapter) Start(ctx context.Context) error {
 	a.logger.Info("Starting Synchronizer Adapter")
 	ctx = pkgadapter.ContextWithMetricTag(ctx, a.mt)
+
+	// plain HTTP clients and asynchronous responses require access to the
+	// underlying HTTP requests and responses
+	if a.plainHTTP || a.async {
+		return a.startHTTPServer(ctx)
+	}
 	return a.ceClient.StartReceiver(ctx, a.dispatch)
 }
 
@@ -107,7 +137,7 @@ func (a *adapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloud
 func (a *adapter) serveRequest(ctx context.Context, correlationID string, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
 	a.logger.Debugf("Handling request %q", correlationID)
 
-	respChan, err := a.sessions.add(correlationID)
+	respChan, err := a.sessions.add(ctx, correlationID)
 	if err != nil {
 		return nil, cloudevents.NewHTTPResult(http.StatusInternalServerError, "cannot add session %q: %w", correlationID, err)
 	}
@@ -146,21 +176,22 @@ func (a *adapter) serveRequest(ctx context.Context, correlationID string, event
 func (a *adapter) serveResponse(ctx context.Context, correlationID string, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
 	a.logger.Debugf("Handling response %q", correlationID)
 
-	responseChan, exists := a.sessions.get(correlationID)
-	if !exists {
+	a.logger.Debugf("Forwarding response %q", correlationID)
+
+	switch err := a.sessions.respond(ctx, correlationID, &event); {
+	case errors.Is(err, errSessionNotFound):
 		a.logger.Errorw("Session not found", zap.Error(fmt.Errorf("client session with ID %q does not exist", correlationID)))
 		return nil, cloudevents.NewHTTPResult(http.StatusBadGateway, "client session does not exist")
-	}
-
-	a.logger.Debugf("Forwarding response %q", correlationID)
-	select {
-	case responseChan <- &event:
-		a.logger.Debugf("Response %q completed", correlationID)
-		return nil, cloudevents.ResultACK
-	default:
+	case errors.Is(err, errSessionClosed):
 		a.logger.Errorw("Unable to forward the response", zap.Error(fmt.Errorf("client connection with ID %q is closed", correlationID)))
 		return nil, cloudevents.NewHTTPResult(http.StatusBadGateway, "client connection is closed")
+	case err != nil:
+		a.logger.Errorw("Unable to forward the response", zap.Error(err))
+		return nil, cloudevents.NewHTTPResult(http.StatusInternalServerError, "unable to forward the response: %v", err)
 	}
+
+	a.logger.Debugf("Response %q completed", correlationID)
+	return nil, cloudevents.ResultACK
 }
 
 // withBridgeIdentifier adds Bridge ID to the event context.
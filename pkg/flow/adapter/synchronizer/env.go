@@ -34,6 +34,15 @@ type envAccessor struct {
 	CorrelationKeyLength int           `envconfig:"CORRELATION_KEY_LENGTH"`
 	ResponseWaitTimeout  time.Duration `envconfig:"RESPONSE_WAIT_TIMEOUT"`
 
+	ClientProtocol    string        `envconfig:"CLIENT_PROTOCOL" default:"cloudevents"`
+	ResponseMode      string        `envconfig:"RESPONSE_MODE" default:"sync"`
+	ResponseRetention time.Duration `envconfig:"RESPONSE_RETENTION" default:"5m"`
+
+	// Redis storage of the client sessions
+	RedisAddress  string `envconfig:"STORAGE_REDIS_ADDRESS"`
+	RedisPassword string `envconfig:"STORAGE_REDIS_PASSWORD"`
+	RedisDatabase int    `envconfig:"STORAGE_REDIS_DATABASE"`
+
 	// BridgeIdentifier is the name of the bridge workflow this target is part of
 	BridgeIdentifier string `envconfig:"EVENTS_BRIDGE_IDENTIFIER"`
 }
@@ -0,0 +1,272 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+const (
+	serverPort                uint16 = 8080
+	serverShutdownGracePeriod        = time.Second * 10
+
+	// pollPath is the path prefix of the URLs asynchronous responses are polled from.
+	pollPath = "/responses/"
+
+	// httpRequestEventType is the type of the events created from plain HTTP requests.
+	httpRequestEventType = "io.triggermesh.synchronizer.request"
+
+	headerPrefix = "h"
+	queryPrefix  = "q"
+)
+
+// startHTTPServer runs the HTTP server handling plain HTTP clients and
+// asynchronous responses until ctx gets cancelled.
+func (a *adapter) startHTTPServer(ctx context.Context) error {
+	s := &http.Server{
+		Addr:    fmt.Sprintf(":%d", serverPort),
+		Handler: a.handle(ctx),
+	}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.ListenAndServe()
+	}()
+
+	handleServerError := func(err error) error {
+		if err != http.ErrServerClosed {
+			return fmt.Errorf("during server runtime: %w", err)
+		}
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		a.logger.Info("HTTP server is shutting down")
+
+		ctx, cancel := context.WithTimeout(context.Background(), serverShutdownGracePeriod)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			return fmt.Errorf("during server shutdown: %w", err)
+		}
+
+		return handleServerError(<-errCh)
+
+	case err := <-errCh:
+		return handleServerError(err)
+	}
+}
+
+// handle returns the handler of the client requests, of the backend
+// responses, and of the polling of asynchronous responses.
+func (a *adapter) handle(ctx context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, pollPath) {
+			a.servePoll(r.Context(), w, strings.TrimPrefix(r.URL.Path, pollPath))
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		event, err := a.readEvent(r)
+		if err != nil {
+			a.logger.Errorw("Unable to read the request", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		a.logger.Debugf("Received the event: %s", event.String())
+
+		if correlationID, exists := a.correlationKey.get(*event); exists {
+			_, res := a.serveResponse(r.Context(), correlationID, *event)
+			a.writeReply(r.Context(), w, nil, res)
+			return
+		}
+
+		correlationID := a.correlationKey.set(event)
+
+		if a.async {
+			a.serveAsyncRequest(ctx, w, correlationID, *event)
+			return
+		}
+
+		resp, res := a.serveRequest(r.Context(), correlationID, *event)
+		a.writeReply(r.Context(), w, resp, res)
+	}
+}
+
+// readEvent returns the CloudEvent contained in the request, or a new event
+// created from the request's body and headers when plain HTTP clients are
+// supported.
+func (a *adapter) readEvent(r *http.Request) (*cloudevents.Event, error) {
+	message := cehttp.NewMessageFromHttpRequest(r)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer message.Finish(nil)
+
+	if message.ReadEncoding() != binding.EncodingUnknown {
+		return binding.ToEvent(r.Context(), message)
+	}
+
+	if !a.plainHTTP {
+		return nil, errors.New("request is not a CloudEvent")
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(uuid.NewString())
+	event.SetType(httpRequestEventType)
+	event.SetSource(a.eventSource)
+
+	event.SetExtension("method", r.Method)
+	event.SetExtension("path", r.URL.Path)
+
+	for k, v := range r.URL.Query() {
+		if len(v) != 0 {
+			event.SetExtension(sanitizeCloudEventAttributeName(queryPrefix+k), v[0])
+		}
+	}
+
+	for k, v := range r.Header {
+		// Credentials and body headers are not added as CloudEvent attributes
+		if k == "Authorization" || k == "Cookie" || k == "Content-Type" || k == "Content-Length" {
+			continue
+		}
+		if len(v) != 0 {
+			event.SetExtension(sanitizeCloudEventAttributeName(headerPrefix+k), v[0])
+		}
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if err := event.SetData(contentType, body); err != nil {
+		return nil, fmt.Errorf("setting event data: %w", err)
+	}
+
+	return &event, nil
+}
+
+// serveAsyncRequest forwards the request to the backend in the background,
+// and replies to the client with the URL the response can be polled from.
+func (a *adapter) serveAsyncRequest(ctx context.Context, w http.ResponseWriter, correlationID string, event cloudevents.Event) {
+	// pending results expire if the request is never completed
+	if err := a.sessions.addResult(ctx, correlationID, a.responseTimeout+a.responseRetention); err != nil {
+		a.logger.Errorw("Unable to register the request", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	go func() {
+		resp, res := a.serveRequest(ctx, correlationID, event)
+		if err := a.sessions.completeResult(ctx, correlationID, newAsyncResult(resp, res), a.responseRetention); err != nil {
+			a.logger.Errorw("Unable to record the response", zap.Error(err), zap.String("id", correlationID))
+		}
+	}()
+
+	w.Header().Set("Location", pollPath+correlationID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// servePoll replies to the client with the asynchronous response of the
+// request with the given correlation ID, if available.
+func (a *adapter) servePoll(ctx context.Context, w http.ResponseWriter, correlationID string) {
+	result, err := a.sessions.takeResult(ctx, correlationID)
+	switch {
+	case errors.Is(err, errResultNotFound):
+		http.Error(w, "response does not exist", http.StatusNotFound)
+	case err != nil:
+		a.logger.Errorw("Unable to read the response", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case !result.Done:
+		w.Header().Set("Location", pollPath+correlationID)
+		w.WriteHeader(http.StatusAccepted)
+	case result.Error != "":
+		http.Error(w, result.Error, result.Status)
+	default:
+		a.writeReply(ctx, w, result.Event, cloudevents.ResultACK)
+	}
+}
+
+// writeReply writes the response event to the client, in the format
+// expected by the client's protocol.
+func (a *adapter) writeReply(ctx context.Context, w http.ResponseWriter, event *cloudevents.Event, result cloudevents.Result) {
+	if !cloudevents.IsACK(result) {
+		http.Error(w, result.Error(), resultStatus(result))
+		return
+	}
+
+	if event == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if a.plainHTTP {
+		if ct := event.DataContentType(); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(event.Data()); err != nil {
+			a.logger.Errorw("Unable to write the response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := cehttp.WriteResponseWriter(ctx, binding.ToMessage(event), http.StatusOK, w); err != nil {
+		a.logger.Errorw("Unable to write the response", zap.Error(err))
+	}
+}
+
+// sanitizeCloudEventAttributeName returns a valid CloudEvent attribute name.
+func sanitizeCloudEventAttributeName(name string) string {
+	// only lowercase alphanumeric characters are accepted
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(name))
+
+	// truncate if longer than 20 characters
+	if len(name) > 20 {
+		name = name[:20]
+	}
+
+	return name
+}
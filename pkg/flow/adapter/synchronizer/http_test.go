@@ -0,0 +1,203 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cetest "github.com/cloudevents/sdk-go/v2/client/test"
+
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestPlainHTTPRequest(t *testing.T) {
+	a := &adapter{
+		logger:      logtesting.TestLogger(t),
+		plainHTTP:   true,
+		eventSource: "synchronizer/test",
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders?id=42", strings.NewReader(`{"order":42}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "abc")
+	req.Header.Set("Authorization", "secret")
+
+	event, err := a.readEvent(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, httpRequestEventType, event.Type())
+	assert.Equal(t, "synchronizer/test", event.Source())
+	assert.Equal(t, "application/json", event.DataContentType())
+	assert.JSONEq(t, `{"order":42}`, string(event.Data()))
+
+	ext := event.Extensions()
+	assert.Equal(t, "POST", ext["method"])
+	assert.Equal(t, "/orders", ext["path"])
+	assert.Equal(t, "42", ext["qid"])
+	assert.Equal(t, "abc", ext["hxrequestid"])
+	assert.NotContains(t, ext, "hauthorization")
+
+	reply := cloudevents.NewEvent()
+	reply.SetID("reply")
+	reply.SetType("reply.type")
+	reply.SetSource("backend")
+	require.NoError(t, reply.SetData("text/plain", []byte("done")))
+
+	w := httptest.NewRecorder()
+	a.writeReply(context.Background(), w, &reply, cloudevents.ResultACK)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "done", w.Body.String())
+}
+
+func TestCloudEventsOnly(t *testing.T) {
+	a := &adapter{logger: logtesting.TestLogger(t)}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	_, err := a.readEvent(req)
+	assert.Error(t, err)
+}
+
+func TestAsyncResponse(t *testing.T) {
+	ctx := context.Background()
+
+	a := &adapter{
+		logger:   logtesting.TestLogger(t),
+		sessions: newStorage(),
+	}
+
+	w := httptest.NewRecorder()
+	a.servePoll(ctx, w, "unknown")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	require.NoError(t, a.sessions.addResult(ctx, "id", time.Minute))
+
+	w = httptest.NewRecorder()
+	a.servePoll(ctx, w, "id")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, pollPath+"id", w.Header().Get("Location"))
+
+	reply := cloudevents.NewEvent()
+	reply.SetID("reply")
+	reply.SetType("reply.type")
+	reply.SetSource("backend")
+	require.NoError(t, a.sessions.completeResult(ctx, "id", newAsyncResult(&reply, cloudevents.ResultACK), time.Minute))
+
+	w = httptest.NewRecorder()
+	a.servePoll(ctx, w, "id")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "reply", w.Header().Get("Ce-Id"))
+
+	// completed results can be polled only once
+	w = httptest.NewRecorder()
+	a.servePoll(ctx, w, "id")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// failed requests are replied with their status
+	require.NoError(t, a.sessions.addResult(ctx, "failed", time.Minute))
+	res := cloudevents.NewHTTPResult(http.StatusGatewayTimeout, "backend did not respond in time")
+	require.NoError(t, a.sessions.completeResult(ctx, "failed", newAsyncResult(nil, res), time.Minute))
+
+	w = httptest.NewRecorder()
+	a.servePoll(ctx, w, "failed")
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestAsyncResponseSharedStorage(t *testing.T) {
+	const prefix = "synchronizer/ns/name/"
+
+	mr := miniredis.RunT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newReplica := func(ceClient cloudevents.Client) *adapter {
+		logger := logtesting.TestLogger(t)
+		sessions, err := newRedisStorage(ctx, mr.Addr(), "", 0, prefix, time.Minute, logger)
+		require.NoError(t, err)
+
+		return &adapter{
+			ceClient:          ceClient,
+			logger:            logger,
+			responseTimeout:   5 * time.Second,
+			async:             true,
+			responseRetention: time.Minute,
+			sessions:          sessions,
+			sinkURL:           "http://backend",
+		}
+	}
+
+	ceClient, sent := cetest.NewMockSenderClient(t, 1)
+
+	// the request and the poll are handled by different replicas
+	a1 := newReplica(ceClient)
+	a2 := newReplica(nil)
+
+	request := cloudevents.NewEvent()
+	request.SetID("request")
+	request.SetType("request.type")
+	request.SetSource("client")
+
+	w := httptest.NewRecorder()
+	a1.serveAsyncRequest(ctx, w, "id", request)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	w = httptest.NewRecorder()
+	a2.servePoll(ctx, w, "id")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	select {
+	case event := <-sent:
+		assert.Equal(t, "request", event.ID())
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for the request to be forwarded")
+	}
+
+	reply := cloudevents.NewEvent()
+	reply.SetID("reply")
+	reply.SetType("reply.type")
+	reply.SetSource("backend")
+
+	// the response is sent to the second replica, and may reach the first one
+	// before it waits for it, in which case the backend sends it again
+	require.Eventually(t, func() bool {
+		a2.serveResponse(ctx, "id", reply)
+
+		w = httptest.NewRecorder()
+		a2.servePoll(ctx, w, "id")
+		return w.Code != http.StatusAccepted
+	}, 3*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "reply", w.Header().Get("Ce-Id"))
+
+	// completed results can be polled only once, from any replica
+	w = httptest.NewRecorder()
+	a1.servePoll(ctx, w, "id")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
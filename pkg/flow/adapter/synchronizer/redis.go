@@ -0,0 +1,252 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// replicaIDLength is the length of the random identifier of a replica.
+const replicaIDLength = 16
+
+// redisStorage is a sessionStorage shared between the replicas of a
+// Synchronizer through a Redis-compatible server.
+//
+// Client connections remain held by the replica which received the request.
+// The owner of each session is recorded in a key with a TTL matching the
+// response timeout, and responses received by other replicas are forwarded to
+// the owner through its own Pub/Sub channel.
+//
+// Results of asynchronous requests are stored in keys expiring after their
+// retention period, and can be polled from any replica.
+type redisStorage struct {
+	local  *storage
+	client *redis.Client
+	logger *zap.SugaredLogger
+
+	keyPrefix string
+	channel   string
+	ttl       time.Duration
+}
+
+var _ sessionStorage = (*redisStorage)(nil)
+
+// forwardedResponse is the message published to the channel of the replica
+// holding a client session.
+type forwardedResponse struct {
+	ID    string             `json:"id"`
+	Event *cloudevents.Event `json:"event"`
+}
+
+// newRedisStorage returns a sessionStorage backed by a Redis server, which
+// receives the responses forwarded by other replicas until ctx is done.
+// Keys are prefixed to avoid collisions between Synchronizers sharing a server.
+func newRedisStorage(ctx context.Context, address, password string, database int,
+	keyPrefix string, ttl time.Duration, logger *zap.SugaredLogger) (*redisStorage, error) {
+
+	s := &redisStorage{
+		local: newStorage(),
+		client: redis.NewClient(&redis.Options{
+			Addr:     address,
+			Password: password,
+			DB:       database,
+		}),
+		logger: logger,
+
+		keyPrefix: keyPrefix,
+		channel:   keyPrefix + "replica/" + randString(replicaIDLength),
+		ttl:       ttl,
+	}
+
+	pubsub := s.client.Subscribe(ctx, s.channel)
+	// wait for the subscription to be confirmed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return nil, fmt.Errorf("subscribing to Redis channel %q: %w", s.channel, err)
+	}
+
+	go s.receiveResponses(ctx, pubsub)
+
+	return s, nil
+}
+
+// receiveResponses delivers the responses forwarded by other replicas to the
+// local client sessions.
+func (s *redisStorage) receiveResponses(ctx context.Context, pubsub *redis.PubSub) {
+	defer pubsub.Close()
+
+	msgs := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+
+			var resp forwardedResponse
+			if err := json.Unmarshal([]byte(msg.Payload), &resp); err != nil {
+				s.logger.Errorw("Unable to decode forwarded response", zap.Error(err))
+				continue
+			}
+
+			if err := s.local.respond(ctx, resp.ID, resp.Event); err != nil {
+				s.logger.Errorw("Unable to deliver forwarded response", zap.Error(err), zap.String("id", resp.ID))
+			}
+		}
+	}
+}
+
+// add implements sessionStorage.
+func (s *redisStorage) add(ctx context.Context, id string) (<-chan *cloudevents.Event, error) {
+	c, err := s.local.add(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.Set(ctx, s.sessionKey(id), s.channel, s.ttl).Err(); err != nil {
+		s.local.delete(id)
+		return nil, fmt.Errorf("registering session in Redis: %w", err)
+	}
+
+	return c, nil
+}
+
+// delete implements sessionStorage.
+func (s *redisStorage) delete(id string) {
+	s.local.delete(id)
+
+	if err := s.client.Del(context.Background(), s.sessionKey(id)).Err(); err != nil {
+		s.logger.Errorw("Unable to unregister session from Redis", zap.Error(err), zap.String("id", id))
+	}
+}
+
+// respond implements sessionStorage.
+func (s *redisStorage) respond(ctx context.Context, id string, event *cloudevents.Event) error {
+	err := s.local.respond(ctx, id, event)
+	if !errors.Is(err, errSessionNotFound) {
+		return err
+	}
+
+	owner, err := s.client.Get(ctx, s.sessionKey(id)).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return errSessionNotFound
+	case err != nil:
+		return fmt.Errorf("reading session owner from Redis: %w", err)
+	}
+
+	b, err := json.Marshal(forwardedResponse{ID: id, Event: event})
+	if err != nil {
+		return fmt.Errorf("encoding response: %w", err)
+	}
+
+	receivers, err := s.client.Publish(ctx, owner, b).Result()
+	if err != nil {
+		return fmt.Errorf("forwarding response through Redis: %w", err)
+	}
+	if receivers == 0 {
+		return errSessionNotFound
+	}
+
+	return nil
+}
+
+// addResult implements sessionStorage.
+func (s *redisStorage) addResult(ctx context.Context, id string, ttl time.Duration) error {
+	b, err := json.Marshal(asyncResult{})
+	if err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+
+	added, err := s.client.SetNX(ctx, s.resultKey(id), b, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("registering result in Redis: %w", err)
+	}
+	if !added {
+		return fmt.Errorf("result already exists")
+	}
+
+	return nil
+}
+
+// completeResult implements sessionStorage.
+func (s *redisStorage) completeResult(ctx context.Context, id string, r *asyncResult, retention time.Duration) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+
+	if err := s.client.Set(ctx, s.resultKey(id), b, retention).Err(); err != nil {
+		return fmt.Errorf("writing result to Redis: %w", err)
+	}
+
+	return nil
+}
+
+// takeResult implements sessionStorage.
+//
+// Completed results are deleted before being returned, so that only one of
+// the replicas polled concurrently for the same result replies with it.
+func (s *redisStorage) takeResult(ctx context.Context, id string) (*asyncResult, error) {
+	b, err := s.client.Get(ctx, s.resultKey(id)).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, errResultNotFound
+	case err != nil:
+		return nil, fmt.Errorf("reading result from Redis: %w", err)
+	}
+
+	r := &asyncResult{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("decoding result: %w", err)
+	}
+
+	if !r.Done {
+		return r, nil
+	}
+
+	deleted, err := s.client.Del(ctx, s.resultKey(id)).Result()
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("deleting result from Redis: %w", err)
+	case deleted == 0:
+		return nil, errResultNotFound
+	}
+
+	return r, nil
+}
+
+// sessionKey returns the Redis key of the session with the given id.
+func (s *redisStorage) sessionKey(id string) string {
+	return s.keyPrefix + "session/" + id
+}
+
+// resultKey returns the Redis key of the result of the asynchronous request
+// with the given id.
+func (s *redisStorage) resultKey(id string) string {
+	return s.keyPrefix + "result/" + id
+}
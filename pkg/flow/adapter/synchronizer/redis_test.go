@@ -0,0 +1,147 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestRedisStorage(t *testing.T) {
+	const prefix = "synchronizer/ns/name/"
+
+	mr := miniredis.RunT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// two replicas of the same Synchronizer
+	s1, err := newRedisStorage(ctx, mr.Addr(), "", 0, prefix, time.Minute, logtesting.TestLogger(t))
+	require.NoError(t, err)
+	s2, err := newRedisStorage(ctx, mr.Addr(), "", 0, prefix, time.Minute, logtesting.TestLogger(t))
+	require.NoError(t, err)
+
+	t.Run("session registered with a TTL", func(t *testing.T) {
+		_, err := s1.add(ctx, "1")
+		require.NoError(t, err)
+
+		owner, err := mr.Get(prefix + "session/1")
+		require.NoError(t, err)
+		assert.Equal(t, s1.channel, owner)
+		assert.Equal(t, time.Minute, mr.TTL(prefix+"session/1"))
+
+		s1.delete("1")
+		assert.False(t, mr.Exists(prefix+"session/1"))
+	})
+
+	t.Run("response delivered by the owner", func(t *testing.T) {
+		c, err := s1.add(ctx, "2")
+		require.NoError(t, err)
+		defer s1.delete("2")
+
+		resp := receive(c)
+		respondEventually(t, s1, "2", newTestEvent("2"))
+
+		assert.Equal(t, "2", (<-resp).ID())
+	})
+
+	t.Run("response forwarded to the owner", func(t *testing.T) {
+		c, err := s1.add(ctx, "3")
+		require.NoError(t, err)
+		defer s1.delete("3")
+
+		resp := receive(c)
+
+		var event *cloudevents.Event
+		require.Eventually(t, func() bool {
+			require.NoError(t, s2.respond(ctx, "3", newTestEvent("3")))
+			select {
+			case event = <-resp:
+				return true
+			case <-time.After(10 * time.Millisecond):
+				return false
+			}
+		}, time.Second, time.Millisecond)
+
+		assert.Equal(t, "3", event.ID())
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		err := s2.respond(ctx, "4", newTestEvent("4"))
+		assert.ErrorIs(t, err, errSessionNotFound)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		_, err := s1.add(ctx, "5")
+		require.NoError(t, err)
+		defer s1.delete("5")
+
+		mr.FastForward(2 * time.Minute)
+
+		err = s2.respond(ctx, "5", newTestEvent("5"))
+		assert.ErrorIs(t, err, errSessionNotFound)
+	})
+
+	t.Run("result polled once", func(t *testing.T) {
+		require.NoError(t, s1.addResult(ctx, "7", time.Minute))
+		assert.Error(t, s2.addResult(ctx, "7", time.Minute))
+
+		r, err := s2.takeResult(ctx, "7")
+		require.NoError(t, err)
+		assert.False(t, r.Done)
+
+		require.NoError(t, s1.completeResult(ctx, "7", newAsyncResult(newTestEvent("7"), cloudevents.ResultACK), time.Hour))
+		assert.Equal(t, time.Hour, mr.TTL(prefix+"result/7"))
+
+		r, err = s2.takeResult(ctx, "7")
+		require.NoError(t, err)
+		assert.True(t, r.Done)
+		assert.Equal(t, "7", r.Event.ID())
+
+		_, err = s1.takeResult(ctx, "7")
+		assert.ErrorIs(t, err, errResultNotFound)
+	})
+
+	t.Run("expired result", func(t *testing.T) {
+		require.NoError(t, s1.addResult(ctx, "8", time.Minute))
+
+		mr.FastForward(2 * time.Minute)
+
+		_, err := s2.takeResult(ctx, "8")
+		assert.ErrorIs(t, err, errResultNotFound)
+	})
+
+	t.Run("server unavailable", func(t *testing.T) {
+		mr.SetError("LOADING Redis is loading the dataset in memory")
+		defer mr.SetError("")
+
+		_, err := s1.add(ctx, "6")
+		assert.Error(t, err)
+
+		// the session is not left registered locally
+		assert.ErrorIs(t, s1.local.respond(ctx, "6", newTestEvent("6")), errSessionNotFound)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+// asyncResult is the outcome of an asynchronous request, as recorded in the
+// session storage until it is polled by the client.
+type asyncResult struct {
+	Done  bool               `json:"done"`
+	Event *cloudevents.Event `json:"event,omitempty"`
+	// Status and Error describe the failure of the request, if any.
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// newAsyncResult returns the completed asyncResult of a request.
+func newAsyncResult(event *cloudevents.Event, result cloudevents.Result) *asyncResult {
+	r := &asyncResult{
+		Done:  true,
+		Event: event,
+	}
+
+	if !cloudevents.IsACK(result) {
+		r.Status = resultStatus(result)
+		r.Error = result.Error()
+	}
+
+	return r
+}
+
+// resultStatus returns the HTTP status code matching the given result.
+func resultStatus(result cloudevents.Result) int {
+	if cloudevents.IsACK(result) {
+		return http.StatusOK
+	}
+
+	var httpResult *cehttp.Result
+	if cloudevents.ResultAs(result, &httpResult) {
+		return httpResult.StatusCode
+	}
+	return http.StatusInternalServerError
+}
@@ -17,27 +17,66 @@ limitations under the License.
 package synchronizer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 )
 
-// storage holds the map of open connections and corresponding channels.
+// Errors returned when a response cannot be delivered to a client session.
+var (
+	errSessionNotFound = errors.New("client session does not exist")
+	errSessionClosed   = errors.New("client connection is closed")
+)
+
+// errResultNotFound is returned when the result of an asynchronous request
+// does not exist, has expired or was already polled.
+var errResultNotFound = errors.New("result does not exist")
+
+// sessionStorage is a registry of client sessions waiting for a response.
+type sessionStorage interface {
+	// add registers a new client session and returns the channel the
+	// response of this session is delivered to.
+	add(ctx context.Context, id string) (<-chan *cloudevents.Event, error)
+	// delete unregisters a client session.
+	delete(id string)
+	// respond delivers a response to the client session with the given id.
+	respond(ctx context.Context, id string, event *cloudevents.Event) error
+
+	// addResult registers the pending result of an asynchronous request,
+	// which expires after ttl unless it gets completed.
+	addResult(ctx context.Context, id string, ttl time.Duration) error
+	// completeResult records the outcome of an asynchronous request, which
+	// is retained for the given duration.
+	completeResult(ctx context.Context, id string, r *asyncResult, retention time.Duration) error
+	// takeResult returns the result of an asynchronous request. Completed
+	// results are removed once returned.
+	takeResult(ctx context.Context, id string) (*asyncResult, error)
+}
+
+var _ sessionStorage = (*storage)(nil)
+
+// storage holds the map of open connections and corresponding channels, and
+// the results of asynchronous requests.
 type storage struct {
 	sync.Mutex
 	sessions map[string]chan *cloudevents.Event
+	results  map[string]*asyncResult
 }
 
 // newStorage returns an instance of the sessions storage.
 func newStorage() *storage {
 	return &storage{
 		sessions: make(map[string]chan *cloudevents.Event),
+		results:  make(map[string]*asyncResult),
 	}
 }
 
 // add creates the new communication channel and adds it to the session storage.
-func (s *storage) add(id string) (<-chan *cloudevents.Event, error) {
+func (s *storage) add(_ context.Context, id string) (<-chan *cloudevents.Event, error) {
 	s.Lock()
 	defer s.Unlock()
 
@@ -59,11 +98,77 @@ func (s *storage) delete(id string) {
 	delete(s.sessions, id)
 }
 
-// open returns the communication channel for the session id.
-func (s *storage) get(id string) (chan<- *cloudevents.Event, bool) {
+// respond writes the response to the communication channel of the session id.
+func (s *storage) respond(_ context.Context, id string, event *cloudevents.Event) error {
 	s.Lock()
 	defer s.Unlock()
 
 	session, exists := s.sessions[id]
-	return session, exists
+	if !exists {
+		return errSessionNotFound
+	}
+
+	select {
+	case session <- event:
+		return nil
+	default:
+		return errSessionClosed
+	}
+}
+
+// addResult implements sessionStorage.
+func (s *storage) addResult(_ context.Context, id string, ttl time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, exists := s.results[id]; exists {
+		return fmt.Errorf("result already exists")
+	}
+
+	r := &asyncResult{}
+	s.results[id] = r
+
+	time.AfterFunc(ttl, func() {
+		s.Lock()
+		defer s.Unlock()
+		if s.results[id] == r {
+			delete(s.results, id)
+		}
+	})
+
+	return nil
+}
+
+// completeResult implements sessionStorage.
+func (s *storage) completeResult(_ context.Context, id string, r *asyncResult, retention time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.results[id] = r
+
+	time.AfterFunc(retention, func() {
+		s.Lock()
+		defer s.Unlock()
+		if s.results[id] == r {
+			delete(s.results, id)
+		}
+	})
+
+	return nil
+}
+
+// takeResult implements sessionStorage.
+func (s *storage) takeResult(_ context.Context, id string) (*asyncResult, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	r, exists := s.results[id]
+	if !exists {
+		return nil, errResultNotFound
+	}
+
+	if r.Done {
+		delete(s.results, id)
+	}
+	return r, nil
 }
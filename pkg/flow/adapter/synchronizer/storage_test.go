@@ -0,0 +1,106 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synchronizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage()
+
+	t.Run("response delivered to the session", func(t *testing.T) {
+		c, err := s.add(ctx, "1")
+		require.NoError(t, err)
+		defer s.delete("1")
+
+		resp := receive(c)
+		respondEventually(t, s, "1", newTestEvent("1"))
+
+		assert.Equal(t, "1", (<-resp).ID())
+	})
+
+	t.Run("duplicate session", func(t *testing.T) {
+		_, err := s.add(ctx, "2")
+		require.NoError(t, err)
+		defer s.delete("2")
+
+		_, err = s.add(ctx, "2")
+		assert.Error(t, err)
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		err := s.respond(ctx, "3", newTestEvent("3"))
+		assert.ErrorIs(t, err, errSessionNotFound)
+	})
+
+	t.Run("client not waiting", func(t *testing.T) {
+		_, err := s.add(ctx, "4")
+		require.NoError(t, err)
+		defer s.delete("4")
+
+		err = s.respond(ctx, "4", newTestEvent("4"))
+		assert.ErrorIs(t, err, errSessionClosed)
+	})
+
+	t.Run("deleted session", func(t *testing.T) {
+		c, err := s.add(ctx, "5")
+		require.NoError(t, err)
+
+		s.delete("5")
+
+		_, open := <-c
+		assert.False(t, open)
+		assert.ErrorIs(t, s.respond(ctx, "5", newTestEvent("5")), errSessionNotFound)
+	})
+}
+
+// respondEventually delivers a response to the given session once its
+// client is waiting for it.
+func respondEventually(t *testing.T, s sessionStorage, id string, event *cloudevents.Event) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return s.respond(context.Background(), id, event) == nil
+	}, time.Second, time.Millisecond)
+}
+
+// receive waits in the background for a response on the given session
+// channel, the way client connections do.
+func receive(c <-chan *cloudevents.Event) <-chan *cloudevents.Event {
+	resp := make(chan *cloudevents.Event, 1)
+	go func() {
+		resp <- <-c
+	}()
+	return resp
+}
+
+func newTestEvent(id string) *cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	return &event
+}
@@ -44,6 +44,11 @@ type envConfig struct {
 	// Transformation specifications
 	TransformationContext string `envconfig:"TRANSFORMATION_CONTEXT"`
 	TransformationData    string `envconfig:"TRANSFORMATION_DATA"`
+
+	// Redis storage of the global variables
+	RedisAddress  string `envconfig:"STORAGE_REDIS_ADDRESS"`
+	RedisPassword string `envconfig:"STORAGE_REDIS_PASSWORD"`
+	RedisDatabase int    `envconfig:"STORAGE_REDIS_DATABASE"`
 }
 
 // adapter contains Pipelines for CE transformations and CloudEvents client.
@@ -95,6 +100,12 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 	}
 
 	sharedStorage := storage.New()
+	if env.RedisAddress != "" {
+		keyPrefix := fmt.Sprintf("transformation/%s/%s/", envAcc.GetNamespace(), envAcc.GetName())
+		sharedStorage = storage.NewWithBackend(
+			storage.NewRedisBackend(env.RedisAddress, env.RedisPassword, env.RedisDatabase, keyPrefix),
+			logger)
+	}
 
 	contextPl, err := newPipeline(trnContext, sharedStorage)
 	if err != nil {
@@ -200,6 +211,10 @@ func (t *adapter) applyTransformations(event cloudevents.Event) (*cloudevents.Ev
 		return nil, fmt.Errorf("cannot encode CE context: %w", err)
 	}
 
+	// conditions are evaluated against the event as it was received.
+	original := event.Clone()
+	ctx := context.Background()
+
 	// init indicates if we need to run initial step transformation
 	var init = true
 	var errs []error
@@ -211,17 +226,17 @@ func (t *adapter) applyTransformations(event cloudevents.Event) (*cloudevents.Ev
 	defer t.ContextPipeline.Storage.Flush(eventUniqueID)
 
 	// Run init step such as load Pipeline variables first
-	eventContext, err := t.ContextPipeline.apply(eventUniqueID, localContextBytes, init)
+	eventContext, err := t.ContextPipeline.apply(ctx, original, eventUniqueID, localContextBytes, init)
 	if err != nil {
 		errs = append(errs, err)
 	}
-	eventPayload, err := t.DataPipeline.apply(eventUniqueID, event.Data(), init)
+	eventPayload, err := t.DataPipeline.apply(ctx, original, eventUniqueID, event.Data(), init)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
 	// CE Context transformation
-	if eventContext, err = t.ContextPipeline.apply(eventUniqueID, eventContext, !init); err != nil {
+	if eventContext, err = t.ContextPipeline.apply(ctx, original, eventUniqueID, eventContext, !init); err != nil {
 		errs = append(errs, err)
 	}
 
@@ -239,7 +254,7 @@ func (t *adapter) applyTransformations(event cloudevents.Event) (*cloudevents.Ev
 	}
 
 	// CE Data transformation
-	if eventPayload, err = t.DataPipeline.apply(eventUniqueID, eventPayload, !init); err != nil {
+	if eventPayload, err = t.DataPipeline.apply(ctx, original, eventUniqueID, eventPayload, !init); err != nil {
 		errs = append(errs, err)
 	}
 	if err = event.SetData(cloudevents.ApplicationJSON, eventPayload); err != nil {
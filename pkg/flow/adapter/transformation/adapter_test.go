@@ -461,6 +461,439 @@ func TestReceiveAndTransform(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Typed operations",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"count":"42","price":10,"name":"john doe","ts":0,"code":"A-1-B-2","flag":"true","date":"2022-01-02T03:04:05Z"}`)),
+			expectedEventData: `{"code":"A-B","count":42,"date":"2022-01-02","flag":true,"name":"John Doe Jr.","price":30,"ts":"1970-01-01T00:00:00Z"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "cast",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "count",
+							Value: "number",
+						},
+					},
+				}, {
+					Operation: "cast",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "flag",
+							Value: "boolean",
+						},
+					},
+				}, {
+					Operation: "cast",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "ts",
+							Value: "timestamp",
+						},
+					},
+				}, {
+					Operation: "math",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "price",
+							Value: "*3",
+						},
+					},
+				}, {
+					Operation: "case",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "name",
+							Value: "title",
+						},
+					},
+				}, {
+					Operation: "concat",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "name",
+							Value: " Jr.",
+						},
+					},
+				}, {
+					Operation: "replace",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "code",
+							Value: "/-[0-9]//",
+						},
+					},
+				}, {
+					Operation: "date",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "date",
+							Value: "DateOnly",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Cast operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"a":1.5,"b":0,"c":"1","d":{"x":1},"e":"abc"}`)),
+			expectedEventData: `{"a":"1.5","b":false,"c":true,"d":"{\"x\":1}","e":"abc"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "cast",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "a",
+							Value: "string",
+						},
+						{
+							Key:   "b",
+							Value: "boolean",
+						},
+						{
+							Key:   "c",
+							Value: "boolean",
+						},
+						{
+							Key:   "d",
+							Value: "string",
+						},
+						{
+							Key:   "e",
+							Value: "number",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Math operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"a":10,"b":"4","c":7,"d":5}`)),
+			expectedEventData: `{"a":15,"b":2,"c":7,"d":-2}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "store",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "$n",
+							Value: "c",
+						},
+					},
+				}, {
+					Operation: "math",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "a",
+							Value: "+ 5",
+						},
+						{
+							Key:   "b",
+							Value: "/2",
+						},
+						{
+							Key:   "c",
+							Value: "/0",
+						},
+						{
+							Key:   "d",
+							Value: "-$n",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Concat operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"first":"John","id":7,"last":"Doe"}`)),
+			expectedEventData: `{"first":"John-Doe","id":"7-x","last":"Doe"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "store",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "$last",
+							Value: "last",
+						},
+					},
+				}, {
+					Operation: "concat",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "first",
+							Value: "-",
+						},
+						{
+							Key:   "first",
+							Value: "$last",
+						},
+						{
+							Key:   "id",
+							Value: "-x",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Case operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"a":"Hello World","b":"Hello World","c":"hello-wORLD","d":1}`)),
+			expectedEventData: `{"a":"HELLO WORLD","b":"hello world","c":"Hello-WORLD","d":1}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "case",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "a",
+							Value: "upper",
+						},
+						{
+							Key:   "b",
+							Value: "lower",
+						},
+						{
+							Key:   "c",
+							Value: "title",
+						},
+						{
+							Key:   "d",
+							Value: "upper",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Replace operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"a":"2022-01-02","b":"foo bar foo","c":"user@host","d":"abc"}`)),
+			expectedEventData: `{"a":"20220102","b":"baz bar baz","c":"host at user","d":"abc"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "replace",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "a",
+							Value: "/-//",
+						},
+						{
+							Key:   "b",
+							Value: "|foo|baz|",
+						},
+						{
+							Key:   "c",
+							Value: `/(\w+)@(\w+)/$2 at $1/`,
+						},
+						{
+							Key:   "d",
+							Value: "/[/x/",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Date operation",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"a":"2022-01-02T03:04:05Z","b":"2022-01-02T03:04:05Z","c":"2022-01-02T03:04:05Z","d":"not a date"}`)),
+			expectedEventData: `{"a":1641092645,"b":"3:04AM","c":"2022/01/02","d":"not a date"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "date",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "a",
+							Value: "unix",
+						},
+						{
+							Key:   "b",
+							Value: "Kitchen",
+						},
+						{
+							Key:   "c",
+							Value: "2006/01/02",
+						},
+						{
+							Key:   "d",
+							Value: "unix",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Conditional operations",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"kind":"order","amount":10}`)),
+			expectedEventData: `{"amount":10,"kind":"order","order":"true"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "add",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "order",
+							Value: "true",
+						},
+					},
+					When: &v1alpha1.Condition{
+						Path: &v1alpha1.Path{
+							Key:   "kind",
+							Value: "order",
+						},
+					},
+				}, {
+					Operation: "add",
+					Paths: []v1alpha1.Path{
+						{
+							Key:   "refund",
+							Value: "true",
+						},
+					},
+					When: &v1alpha1.Condition{
+						Expression: `$kind.(string) == "refund"`,
+					},
+				},
+			},
+		},
+		{
+			name: "If-else block",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"kind":"refund","amount":10}`)),
+			expectedEventData: `{"kind":"refund","total":10}`,
+			data: []v1alpha1.Transform{
+				{
+					If: &v1alpha1.Branch{
+						Condition: v1alpha1.Condition{
+							Expression: `$kind.(string) == "order"`,
+						},
+						Then: []v1alpha1.Transform{
+							{
+								Operation: "shift",
+								Paths: []v1alpha1.Path{
+									{
+										Key: "amount:price",
+									},
+								},
+							},
+						},
+						Else: []v1alpha1.Transform{
+							{
+								Operation: "shift",
+								Paths: []v1alpha1.Path{
+									{
+										Key: "amount:total",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "If-else block evaluated against the transformed data",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"flag":true,"amount":10}`)),
+			expectedEventData: `{"amount":10,"copy":"none"}`,
+			data: []v1alpha1.Transform{
+				{
+					Operation: "delete",
+					Paths: []v1alpha1.Path{
+						{
+							Key: "flag",
+						},
+					},
+				}, {
+					If: &v1alpha1.Branch{
+						Condition: v1alpha1.Condition{
+							Path: &v1alpha1.Path{
+								Key: "flag",
+							},
+						},
+						Then: []v1alpha1.Transform{
+							{
+								Operation: "store",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "$amount",
+										Value: "amount",
+									},
+								},
+							}, {
+								Operation: "add",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "copy",
+										Value: "$amount",
+									},
+								},
+							},
+						},
+						Else: []v1alpha1.Transform{
+							{
+								Operation: "add",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "copy",
+										Value: "none",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Init step inside if-else block",
+			originalEvent: setData(t, newEvent(),
+				json.RawMessage(`{"flag":true,"amount":10}`)),
+			expectedEventData: `{"amount":10,"copy":10,"flag":true}`,
+			data: []v1alpha1.Transform{
+				{
+					If: &v1alpha1.Branch{
+						Condition: v1alpha1.Condition{
+							Path: &v1alpha1.Path{
+								Key: "flag",
+							},
+						},
+						Then: []v1alpha1.Transform{
+							{
+								Operation: "store",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "$amount",
+										Value: "amount",
+									},
+								},
+							}, {
+								Operation: "add",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "copy",
+										Value: "$amount",
+									},
+								},
+							},
+						},
+						Else: []v1alpha1.Transform{
+							{
+								Operation: "add",
+								Paths: []v1alpha1.Path{
+									{
+										Key:   "copy",
+										Value: "none",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -640,3 +1073,50 @@ func BenchmarkStorage(b *testing.B) {
 	}
 	wg.Wait()
 }
+
+func TestGlobalStore(t *testing.T) {
+	sharedStorage := storage.New()
+	global := v1alpha1.VariableScopeGlobal
+
+	pipeline, err := newPipeline([]v1alpha1.Transform{
+		{
+			Operation: "store",
+			Paths: []v1alpha1.Path{
+				{
+					Key:   "$customer",
+					Value: "customer",
+				},
+			},
+			Scope: &global,
+		}, {
+			Operation: "add",
+			Paths: []v1alpha1.Path{
+				{
+					Key:   "lastCustomer",
+					Value: "$customer",
+				},
+			},
+		},
+	}, sharedStorage)
+	assert.NoError(t, err)
+
+	emptyPipeline, err := newPipeline(nil, sharedStorage)
+	assert.NoError(t, err)
+
+	a := &adapter{
+		DataPipeline:    pipeline,
+		ContextPipeline: emptyPipeline,
+		logger:          logtesting.TestLogger(t),
+	}
+
+	first := setData(t, newEvent(), json.RawMessage(`{"customer":"acme"}`))
+	result, err := a.applyTransformations(first)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"customer":"acme","lastCustomer":"acme"}`, string(result.Data()))
+
+	second := setData(t, newEvent(), json.RawMessage(`{"order":1}`))
+	second.SetID("456")
+	result, err = a.applyTransformations(second)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"lastCustomer":"acme","order":1}`, string(result.Data()))
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Backend persists variables shared across events.
+type Backend interface {
+	// Set writes the value with the given TTL. Zero TTL means no expiration.
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	// Get returns the value stored under the key, or nil if it does not exist.
+	Get(ctx context.Context, key string) (interface{}, error)
+}
+
+// memoryBackend is an in-memory implementation of the Backend.
+type memoryBackend struct {
+	data map[string]memoryItem
+	mux  sync.RWMutex
+}
+
+type memoryItem struct {
+	value   interface{}
+	expires time.Time
+}
+
+var _ Backend = (*memoryBackend)(nil)
+
+// NewMemoryBackend returns a Backend that keeps variables in memory.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{
+		data: make(map[string]memoryItem),
+	}
+}
+
+// Set implements Backend.
+func (m *memoryBackend) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.data[key] = item
+	return nil
+}
+
+// Get implements Backend.
+func (m *memoryBackend) Get(_ context.Context, key string) (interface{}, error) {
+	m.mux.RLock()
+	item, exists := m.data[key]
+	m.mux.RUnlock()
+
+	if !exists {
+		return nil, nil
+	}
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		m.mux.Lock()
+		delete(m.data, key)
+		m.mux.Unlock()
+		return nil, nil
+	}
+	return item.value, nil
+}
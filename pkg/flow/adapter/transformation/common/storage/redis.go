@@ -0,0 +1,77 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisBackend is a Backend that persists variables in a Redis-compatible server.
+type redisBackend struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+var _ Backend = (*redisBackend)(nil)
+
+// NewRedisBackend returns a Backend that persists variables in a Redis server.
+// Keys are prefixed to avoid collisions between Transformations sharing a server.
+func NewRedisBackend(address, password string, database int, keyPrefix string) Backend {
+	return &redisBackend{
+		client: redis.NewClient(&redis.Options{
+			Addr:     address,
+			Password: password,
+			DB:       database,
+		}),
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Set implements Backend.
+func (r *redisBackend) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding value of variable %q: %w", key, err)
+	}
+	if err := r.client.Set(ctx, r.keyPrefix+key, b, ttl).Err(); err != nil {
+		return fmt.Errorf("writing variable %q to Redis: %w", key, err)
+	}
+	return nil
+}
+
+// Get implements Backend.
+func (r *redisBackend) Get(ctx context.Context, key string) (interface{}, error) {
+	b, err := r.client.Get(ctx, r.keyPrefix+key).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading variable %q from Redis: %w", key, err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, fmt.Errorf("decoding value of variable %q: %w", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedisBackend(t *testing.T) {
+	const prefix = "transformation/ns/name/"
+
+	mr := miniredis.RunT(t)
+	b := NewRedisBackend(mr.Addr(), "", 0, prefix)
+	ctx := context.Background()
+
+	t.Run("values round trip as JSON", func(t *testing.T) {
+		require.NoError(t, b.Set(ctx, "$obj", map[string]interface{}{"a": 1.0, "b": []interface{}{"c"}}, 0))
+
+		v, err := b.Get(ctx, "$obj")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"a": 1.0, "b": []interface{}{"c"}}, v)
+
+		stored, err := mr.Get(prefix + "$obj")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a":1,"b":["c"]}`, stored)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		v, err := b.Get(ctx, "$missing")
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		require.NoError(t, b.Set(ctx, "$ttl", "value", time.Minute))
+		assert.Equal(t, time.Minute, mr.TTL(prefix+"$ttl"))
+
+		mr.FastForward(2 * time.Minute)
+
+		v, err := b.Get(ctx, "$ttl")
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("server unavailable", func(t *testing.T) {
+		mr.SetError("LOADING Redis is loading the dataset in memory")
+		defer mr.SetError("")
+
+		_, err := b.Get(ctx, "$obj")
+		assert.Error(t, err)
+		assert.Error(t, b.Set(ctx, "$obj", "value", 0))
+	})
+}
+
+func TestStorageBackendErrors(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	core, logs := observer.New(zap.ErrorLevel)
+	s := NewWithBackend(NewRedisBackend(mr.Addr(), "", 0, ""), zap.New(core).Sugar())
+	s.SetGlobal("$var", 0)
+
+	require.NoError(t, s.Set("event1", "$var", "value"))
+	assert.Equal(t, "value", s.Get("event2", "$var"))
+	assert.Zero(t, logs.Len())
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+
+	assert.Nil(t, s.Get("event2", "$var"))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "$var", logs.All()[0].ContextMap()["variable"])
+}
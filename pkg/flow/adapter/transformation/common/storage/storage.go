@@ -17,45 +17,98 @@ limitations under the License.
 package storage
 
 import (
+	"context"
 	"sync"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 // Storage is a simple object that provides thread safe
-// methods to read and write into a map.
+// methods to read and write into a map. Variables of the
+// global scope are additionally persisted in the Backend.
 type Storage struct {
 	data map[string]map[string]interface{}
 	mux  sync.RWMutex
+
+	backend Backend
+	global  map[string]time.Duration
+
+	logger *zap.SugaredLogger
 }
 
-// New returns an instance of Storage.
+// New returns an instance of Storage that keeps
+// global variables in memory.
 func New() *Storage {
+	return NewWithBackend(NewMemoryBackend(), zap.NewNop().Sugar())
+}
+
+// NewWithBackend returns an instance of Storage that persists
+// global variables in the given Backend. Errors returned by the
+// Backend when reading variables are reported to the logger.
+func NewWithBackend(backend Backend, logger *zap.SugaredLogger) *Storage {
 	return &Storage{
 		data: make(map[string]map[string]interface{}),
 		mux:  sync.RWMutex{},
+
+		backend: backend,
+		global:  make(map[string]time.Duration),
+
+		logger: logger,
 	}
 }
 
-// Set writes a value interface to a string key.
-func (s *Storage) Set(eventID, key string, value interface{}) {
+// SetGlobal marks the variable key as global, i.e. persisted
+// across events for the duration of the TTL. Zero TTL means
+// that the variable never expires.
+func (s *Storage) SetGlobal(key string, ttl time.Duration) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
+	s.global[key] = ttl
+}
+
+// Set writes a value interface to a string key. Non-empty values
+// of global variables are written to the Backend.
+func (s *Storage) Set(eventID, key string, value interface{}) error {
+	s.mux.Lock()
 	if s.data[eventID] == nil {
 		s.data[eventID] = make(map[string]interface{})
 	}
 	s.data[eventID][key] = value
+	ttl, global := s.global[key]
+	s.mux.Unlock()
+
+	if !global || value == nil {
+		return nil
+	}
+	return s.backend.Set(context.Background(), key, value, ttl)
 }
 
-// Get reads value by a key.
+// Get reads value by a key. Global variables that were not
+// set by the event are read from the Backend. A global variable
+// that can not be read from the Backend is logged and treated
+// as unset.
 func (s *Storage) Get(eventID string, key string) interface{} {
 	s.mux.RLock()
-	defer s.mux.RUnlock()
-	if s.data[eventID] == nil {
+	value := s.data[eventID][key]
+	_, global := s.global[key]
+	s.mux.RUnlock()
+
+	if value != nil || !global {
+		return value
+	}
+
+	value, err := s.backend.Get(context.Background(), key)
+	if err != nil {
+		s.logger.Errorw("Cannot read global variable from the storage backend",
+			zap.String("variable", key), zap.Error(err))
 		return nil
 	}
-	return s.data[eventID][key]
+	return value
 }
 
-// ListEventVariables returns the slice of variables created for EventID.
+// ListEventVariables returns the slice of variables created for EventID
+// together with the global variables.
 func (s *Storage) ListEventVariables(eventID string) []string {
 	s.mux.RLock()
 	defer s.mux.RUnlock()
@@ -63,6 +116,11 @@ func (s *Storage) ListEventVariables(eventID string) []string {
 	for k := range s.data[eventID] {
 		list = append(list, k)
 	}
+	for k := range s.global {
+		if _, exists := s.data[eventID][k]; !exists {
+			list = append(list, k)
+		}
+	}
 	return list
 }
 
@@ -16,6 +16,32 @@ limitations under the License.
 
 package common
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/convert"
+)
+
+// timeLayouts is the list of layouts used to parse timestamps.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
 // ReadValue returns the source object item located at the requested path.
 func ReadValue(source interface{}, path map[string]interface{}) interface{} {
 	var result interface{}
@@ -69,3 +95,55 @@ func ReadValue(source interface{}, path map[string]interface{}) interface{} {
 	}
 	return result
 }
+
+// ModifyValue passes the value located at the requested path to the modifier
+// function and writes the result back into the JSON data. Data is returned
+// unchanged if the path does not exist.
+func ModifyValue(data []byte, path, separator string, modifier func(interface{}) (interface{}, error)) ([]byte, error) {
+	var event interface{}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return data, err
+	}
+
+	value := ReadValue(event, convert.SliceToMap(strings.Split(path, separator), ""))
+	if value == nil {
+		return data, nil
+	}
+
+	newValue, err := modifier(value)
+	if err != nil {
+		return data, err
+	}
+
+	newObject := convert.SliceToMap(strings.Split(path, separator), newValue)
+	return json.Marshal(convert.MergeJSONWithMap(event, newObject))
+}
+
+// ParseTime converts the value into time. Numbers are treated as Unix epoch
+// seconds, strings are parsed using the list of common layouts.
+func ParseTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case float64:
+		sec, frac := splitFloat(v)
+		return time.Unix(sec, frac).UTC(), nil
+	case string:
+		v = strings.TrimSpace(v)
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			sec, frac := splitFloat(f)
+			return time.Unix(sec, frac).UTC(), nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unable to parse %q as timestamp", v)
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %T as timestamp", value)
+}
+
+// splitFloat splits the number of seconds into whole seconds and nanoseconds.
+func splitFloat(f float64) (int64, int64) {
+	sec := int64(f)
+	return sec, int64((f - float64(sec)) * float64(time.Second))
+}
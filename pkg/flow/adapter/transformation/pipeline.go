@@ -17,17 +17,31 @@ limitations under the License.
 package transformation
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/flow/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/convert"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/add"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/cast"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/concat"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/date"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/delete"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/math"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/parse"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/replace"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/shift"
 	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/store"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer/textcase"
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
 )
 
 const (
@@ -37,8 +51,27 @@ const (
 // Pipeline is a set of Transformations that are
 // sequentially applied to JSON data.
 type Pipeline struct {
-	Transformers []transformer.Transformer
-	Storage      *storage.Storage
+	Steps   []Step
+	Storage *storage.Storage
+}
+
+// Step is a single element of the Pipeline. It either wraps a Transformer,
+// optionally guarded by a condition, or represents an if/else block
+// of nested Steps.
+type Step struct {
+	Transformer transformer.Transformer
+	Condition   *condition
+
+	Then []Step
+	Else []Step
+}
+
+// condition is a compiled predicate of the Transformation step.
+type condition struct {
+	filter *cel.ConditionalFilter
+
+	path  map[string]interface{}
+	value string
 }
 
 // register loads available Transformation into a named map.
@@ -50,50 +83,182 @@ func register() map[string]transformer.Transformer {
 	shift.Register(transformations)
 	store.Register(transformations)
 	parse.Register(transformations)
+	cast.Register(transformations)
+	math.Register(transformations)
+	concat.Register(transformations)
+	textcase.Register(transformations)
+	replace.Register(transformations)
+	date.Register(transformations)
 
 	return transformations
 }
 
 // newPipeline loads available Transformations and creates a Pipeline.
 func newPipeline(transformations []v1alpha1.Transform, storage *storage.Storage) (*Pipeline, error) {
-	availableTransformers := register()
-	pipeline := []transformer.Transformer{}
+	steps, err := newSteps(register(), transformations, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{
+		Steps:   steps,
+		Storage: storage,
+	}, nil
+}
+
+// newSteps converts the list of Transformations into Pipeline Steps.
+func newSteps(availableTransformers map[string]transformer.Transformer,
+	transformations []v1alpha1.Transform, storage *storage.Storage) ([]Step, error) {
+	steps := []Step{}
 
 	for _, transformation := range transformations {
+		if transformation.If != nil {
+			cond, err := newCondition(&transformation.If.Condition)
+			if err != nil {
+				return nil, err
+			}
+			thenSteps, err := newSteps(availableTransformers, transformation.If.Then, storage)
+			if err != nil {
+				return nil, err
+			}
+			elseSteps, err := newSteps(availableTransformers, transformation.If.Else, storage)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, Step{
+				Condition: cond,
+				Then:      thenSteps,
+				Else:      elseSteps,
+			})
+			continue
+		}
+
 		operation, exist := availableTransformers[transformation.Operation]
 		if !exist {
 			return nil, fmt.Errorf("transformation %q not found", transformation.Operation)
 		}
+
+		var cond *condition
+		if transformation.When != nil {
+			var err error
+			if cond, err = newCondition(transformation.When); err != nil {
+				return nil, err
+			}
+		}
+
 		for _, kv := range transformation.Paths {
 			separator := defaultEventPathSeparator
 			if kv.Separator != "" {
 				separator = kv.Separator
 			}
+			if transformation.Scope != nil && *transformation.Scope == v1alpha1.VariableScopeGlobal {
+				var ttl time.Duration
+				if transformation.TTL != nil {
+					ttl = time.Duration(*transformation.TTL)
+				}
+				storage.SetGlobal(kv.Key, ttl)
+			}
 			transformer := operation.New(kv.Key, kv.Value, separator)
 			transformer.SetStorage(storage)
-			pipeline = append(pipeline, transformer)
+			steps = append(steps, Step{
+				Transformer: transformer,
+				Condition:   cond,
+			})
 		}
 	}
 
-	return &Pipeline{
-		Transformers: pipeline,
-		Storage:      storage,
-	}, nil
+	return steps, nil
 }
 
-// Apply applies Pipeline transformations.
-func (p *Pipeline) apply(eventID string, data []byte, init bool) ([]byte, error) {
-	var err error
-	var errs []string
-	for _, v := range p.Transformers {
-		if init == v.InitStep() {
-			if data, err = v.Apply(eventID, data); err != nil {
-				errs = append(errs, err.Error())
-			}
+// newCondition compiles Transformation step predicate.
+func newCondition(c *v1alpha1.Condition) (*condition, error) {
+	switch {
+	case c.Expression != "":
+		filter, err := cel.CompileExpression(c.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compile expression %q: %w", c.Expression, err)
+		}
+		return &condition{filter: &filter}, nil
+	case c.Path != nil && c.Path.Key != "":
+		separator := defaultEventPathSeparator
+		if c.Path.Separator != "" {
+			separator = c.Path.Separator
 		}
+		return &condition{
+			path:  convert.SliceToMap(strings.Split(c.Path.Key, separator), ""),
+			value: c.Path.Value,
+		}, nil
+	}
+	return nil, fmt.Errorf("condition must contain either an expression or a path")
+}
+
+// match evaluates the condition. CEL expressions are evaluated against the
+// original event, path predicates against the JSON as transformed by the
+// preceding steps.
+func (c *condition) match(ctx context.Context, event cloudevents.Event, data []byte) bool {
+	if c == nil {
+		return true
+	}
+
+	if c.filter != nil {
+		match, err := c.filter.Eval(ctx, event)
+		return err == nil && match
 	}
+
+	var object interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+
+	value := common.ReadValue(object, c.path)
+	if value == nil {
+		return false
+	}
+	if c.value == "" {
+		return true
+	}
+	return fmt.Sprint(value) == c.value
+}
+
+// Apply applies Pipeline transformations.
+func (p *Pipeline) apply(ctx context.Context, event cloudevents.Event, eventID string, data []byte, init bool) ([]byte, error) {
+	var errs []string
+	data = applySteps(ctx, p.Steps, event, eventID, data, init, false, &errs)
 	if len(errs) != 0 {
 		return data, fmt.Errorf(strings.Join(errs, ","))
 	}
 	return data, nil
 }
+
+// applySteps sequentially applies the Steps which conditions match the event.
+// If/else blocks are only evaluated in the main pass, when they are reached,
+// so that their conditions see the same data as the conditions of the
+// preceding steps. All the steps of the taken branch run at their position,
+// including init steps, which can not run before the branch is decided.
+func applySteps(ctx context.Context, steps []Step, event cloudevents.Event, eventID string, data []byte, init, inBranch bool, errs *[]string) []byte {
+	var err error
+	for _, s := range steps {
+		if s.Transformer == nil {
+			if init {
+				continue
+			}
+			branch := s.Else
+			if s.Condition.match(ctx, event, data) {
+				branch = s.Then
+			}
+			data = applySteps(ctx, branch, event, eventID, data, init, true, errs)
+			continue
+		}
+
+		if !inBranch && init != s.Transformer.InitStep() {
+			continue
+		}
+		if !s.Condition.match(ctx, event, data) {
+			continue
+		}
+		if data, err = s.Transformer.Apply(eventID, data); err != nil {
+			*errs = append(*errs, err.Error())
+		}
+	}
+	return data
+}
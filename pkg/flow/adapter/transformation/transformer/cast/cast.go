@@ -0,0 +1,153 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cast
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Cast)(nil)
+
+// Cast object implements Transformer interface.
+type Cast struct {
+	Path      string
+	Value     string
+	Separator string
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The cast operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "cast"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Cast{}
+}
+
+// SetStorage is a no-op, the cast operation does not use
+// Pipeline variables.
+func (c *Cast) SetStorage(*storage.Storage) {}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (c *Cast) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Cast object.
+func (c *Cast) New(key, value, separator string) transformer.Transformer {
+	return &Cast{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+	}
+}
+
+// Apply is a main method of Transformation that converts the value
+// located at the path into the requested type.
+func (c *Cast) Apply(eventID string, data []byte) ([]byte, error) {
+	return common.ModifyValue(data, c.Path, c.Separator, func(value interface{}) (interface{}, error) {
+		return Convert(value, c.Value)
+	})
+}
+
+// Convert casts the value into one of the supported types:
+// "string", "number", "boolean" or "timestamp".
+func Convert(value interface{}, typ string) (interface{}, error) {
+	switch typ {
+	case "string":
+		return toString(value)
+	case "number":
+		return toNumber(value)
+	case "boolean":
+		return toBoolean(value)
+	case "timestamp":
+		t, err := common.ParseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(time.RFC3339Nano), nil
+	}
+	return nil, fmt.Errorf("cast operation does not support %q type", typ)
+}
+
+func toString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to cast %T to string: %w", value, err)
+	}
+	return string(b), nil
+}
+
+func toNumber(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f, nil
+		}
+		// timestamps are converted into Unix epoch seconds
+		if t, err := common.ParseTime(v); err == nil {
+			return float64(t.UnixNano()) / float64(time.Second), nil
+		}
+		return nil, fmt.Errorf("unable to cast %q to number", v)
+	}
+	return nil, fmt.Errorf("unable to cast %T to number", value)
+}
+
+func toBoolean(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("unable to cast %q to boolean", v)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unable to cast %T to boolean", value)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concat
+
+import (
+	"fmt"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Concat)(nil)
+
+// Concat object implements Transformer interface.
+type Concat struct {
+	Path      string
+	Value     string
+	Separator string
+
+	variables *storage.Storage
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The concat operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "concat"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Concat{}
+}
+
+// SetStorage sets a shared Storage with Pipeline variables.
+func (c *Concat) SetStorage(storage *storage.Storage) {
+	c.variables = storage
+}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (c *Concat) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Concat object.
+func (c *Concat) New(key, value, separator string) transformer.Transformer {
+	return &Concat{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+
+		variables: c.variables,
+	}
+}
+
+// Apply is a main method of Transformation that appends the string
+// or the variable value to the value located at the path.
+func (c *Concat) Apply(eventID string, data []byte) ([]byte, error) {
+	suffix := fmt.Sprint(c.retrieveVariable(eventID, c.Value))
+
+	return common.ModifyValue(data, c.Path, c.Separator, func(value interface{}) (interface{}, error) {
+		return fmt.Sprint(value) + suffix, nil
+	})
+}
+
+func (c *Concat) retrieveVariable(eventID, key string) interface{} {
+	if value := c.variables.Get(eventID, key); value != nil {
+		return value
+	}
+	return key
+}
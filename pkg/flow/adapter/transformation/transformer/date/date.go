@@ -0,0 +1,106 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package date
+
+import (
+	"time"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Date)(nil)
+
+// Date object implements Transformer interface.
+type Date struct {
+	Path      string
+	Value     string
+	Separator string
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The date operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "date"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Date{}
+}
+
+// SetStorage is a no-op, the date operation does not use
+// Pipeline variables.
+func (d *Date) SetStorage(*storage.Storage) {}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (d *Date) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Date object.
+func (d *Date) New(key, value, separator string) transformer.Transformer {
+	return &Date{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+	}
+}
+
+// Apply is a main method of Transformation that reformats the timestamp
+// located at the path using the requested layout.
+func (d *Date) Apply(eventID string, data []byte) ([]byte, error) {
+	return common.ModifyValue(data, d.Path, d.Separator, func(value interface{}) (interface{}, error) {
+		t, err := common.ParseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		switch d.Value {
+		case "unix":
+			return float64(t.Unix()), nil
+		case "unixmilli":
+			return float64(t.UnixMilli()), nil
+		}
+		if layout, ok := layouts[d.Value]; ok {
+			return t.Format(layout), nil
+		}
+		return t.Format(d.Value), nil
+	})
+}
+
+// layouts contains named time layouts that can be used as the operation value.
+// Any other value is treated as a custom Go time layout.
+var layouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package math
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Math)(nil)
+
+// Math object implements Transformer interface.
+type Math struct {
+	Path      string
+	Value     string
+	Separator string
+
+	variables *storage.Storage
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The math operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "math"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Math{}
+}
+
+// SetStorage sets a shared Storage with Pipeline variables.
+func (m *Math) SetStorage(storage *storage.Storage) {
+	m.variables = storage
+}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (m *Math) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Math object.
+func (m *Math) New(key, value, separator string) transformer.Transformer {
+	return &Math{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+
+		variables: m.variables,
+	}
+}
+
+// Apply is a main method of Transformation that performs an arithmetic
+// operation on the numeric value located at the path.
+func (m *Math) Apply(eventID string, data []byte) ([]byte, error) {
+	if len(m.Value) < 2 {
+		return data, fmt.Errorf("math operation %q must consist of an operator and an operand", m.Value)
+	}
+	operator := m.Value[0]
+	operand, err := m.operand(eventID, strings.TrimSpace(m.Value[1:]))
+	if err != nil {
+		return data, err
+	}
+
+	return common.ModifyValue(data, m.Path, m.Separator, func(value interface{}) (interface{}, error) {
+		number, err := toFloat(value)
+		if err != nil {
+			return nil, err
+		}
+		switch operator {
+		case '+':
+			return number + operand, nil
+		case '-':
+			return number - operand, nil
+		case '*':
+			return number * operand, nil
+		case '/':
+			if operand == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return number / operand, nil
+		case '%':
+			if operand == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return math.Mod(number, operand), nil
+		}
+		return nil, fmt.Errorf("math operation does not support %q operator", operator)
+	})
+}
+
+// operand returns the numeric value of the stored variable
+// or of the literal operand.
+func (m *Math) operand(eventID, key string) (float64, error) {
+	if value := m.variables.Get(eventID, key); value != nil {
+		return toFloat(value)
+	}
+	return toFloat(key)
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", v)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("value of type %T is not a number", value)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replace
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Replace)(nil)
+
+// Replace object implements Transformer interface.
+type Replace struct {
+	Path      string
+	Value     string
+	Separator string
+
+	regexp      *regexp.Regexp
+	replacement string
+	err         error
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The replace operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "replace"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Replace{}
+}
+
+// SetStorage is a no-op, the replace operation does not use
+// Pipeline variables.
+func (r *Replace) SetStorage(*storage.Storage) {}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (r *Replace) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Replace object.
+func (r *Replace) New(key, value, separator string) transformer.Transformer {
+	re, replacement, err := parseExpression(value)
+	return &Replace{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+
+		regexp:      re,
+		replacement: replacement,
+		err:         err,
+	}
+}
+
+// Apply is a main method of Transformation that replaces the regular
+// expression matches in the string located at the path.
+func (r *Replace) Apply(eventID string, data []byte) ([]byte, error) {
+	if r.err != nil {
+		return data, r.err
+	}
+
+	return common.ModifyValue(data, r.Path, r.Separator, func(value interface{}) (interface{}, error) {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to replace the substring of %T value", value)
+		}
+		return r.regexp.ReplaceAllString(str, r.replacement), nil
+	})
+}
+
+// parseExpression parses the sed-like "/pattern/replacement/" expression,
+// where the first character is used as the delimiter.
+func parseExpression(value string) (*regexp.Regexp, string, error) {
+	if len(value) < 2 {
+		return nil, "", fmt.Errorf("replace expression %q must be in the \"/pattern/replacement/\" format", value)
+	}
+
+	delimiter := value[:1]
+	parts := strings.SplitN(strings.TrimSuffix(value[1:], delimiter), delimiter, 2)
+	if len(parts) != 2 {
+		return nil, "", fmt.Errorf("replace expression %q must be in the \"/pattern/replacement/\" format", value)
+	}
+
+	re, err := regexp.Compile(parts[0])
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to compile regular expression %q: %w", parts[0], err)
+	}
+	return re, parts[1], nil
+}
@@ -85,7 +85,9 @@ func (s *Store) Apply(eventID string, data []byte) ([]byte, error) {
 
 	value := common.ReadValue(event, path)
 
-	s.variables.Set(eventID, s.Path, value)
+	if err := s.variables.Set(eventID, s.Path, value); err != nil {
+		return data, err
+	}
 
 	return data, nil
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package textcase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/flow/adapter/transformation/transformer"
+)
+
+var _ transformer.Transformer = (*Case)(nil)
+
+// Case object implements Transformer interface.
+type Case struct {
+	Path      string
+	Value     string
+	Separator string
+}
+
+// InitStep is used to figure out if this operation should
+// run before main Transformations. The case operation runs
+// with the main Transformations.
+var InitStep bool = false
+
+// operationName is used to identify this transformation.
+var operationName string = "case"
+
+// Register adds this transformation to the map which will
+// be used to create Transformation pipeline.
+func Register(m map[string]transformer.Transformer) {
+	m[operationName] = &Case{}
+}
+
+// SetStorage is a no-op, the case operation does not use
+// Pipeline variables.
+func (c *Case) SetStorage(*storage.Storage) {}
+
+// InitStep returns "true" if this Transformation should run
+// as init step.
+func (c *Case) InitStep() bool {
+	return InitStep
+}
+
+// New returns a new instance of Case object.
+func (c *Case) New(key, value, separator string) transformer.Transformer {
+	return &Case{
+		Path:      key,
+		Value:     value,
+		Separator: separator,
+	}
+}
+
+// Apply is a main method of Transformation that converts the case
+// of the string located at the path.
+func (c *Case) Apply(eventID string, data []byte) ([]byte, error) {
+	return common.ModifyValue(data, c.Path, c.Separator, func(value interface{}) (interface{}, error) {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to change the case of %T value", value)
+		}
+		switch c.Value {
+		case "upper":
+			return strings.ToUpper(str), nil
+		case "lower":
+			return strings.ToLower(str), nil
+		case "title":
+			return title(str), nil
+		}
+		return nil, fmt.Errorf("case operation does not support %q conversion", c.Value)
+	})
+}
+
+// title capitalizes the first letter of every word.
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		wordStart := unicode.IsSpace(prev) || unicode.IsPunct(prev)
+		prev = r
+		if wordStart {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
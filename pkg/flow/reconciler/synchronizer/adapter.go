@@ -79,5 +79,43 @@ func MakeAppEnv(o *v1alpha1.Synchronizer) []corev1.EnvVar {
 		})
 	}
 
+	if o.Spec.Protocol != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "CLIENT_PROTOCOL",
+			Value: string(*o.Spec.Protocol),
+		})
+	}
+
+	if o.Spec.Response.Mode != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "RESPONSE_MODE",
+			Value: string(*o.Spec.Response.Mode),
+		})
+	}
+
+	if o.Spec.Response.Retention != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "RESPONSE_RETENTION",
+			Value: o.Spec.Response.Retention.String(),
+		})
+	}
+
+	if o.Spec.Storage != nil && o.Spec.Storage.Redis != nil {
+		redis := o.Spec.Storage.Redis
+		env = append(env, corev1.EnvVar{
+			Name:  common.EnvStorageRedisAddress,
+			Value: redis.Address,
+		})
+		if redis.Password != nil {
+			env = common.MaybeAppendValueFromEnvVar(env, common.EnvStorageRedisPassword, *redis.Password)
+		}
+		if redis.Database != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  common.EnvStorageRedisDatabase,
+				Value: strconv.Itoa(*redis.Database),
+			})
+		}
+	}
+
 	return env
 }
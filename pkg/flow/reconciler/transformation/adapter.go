@@ -18,6 +18,7 @@ package transformation
 
 import (
 	"encoding/json"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -72,7 +73,7 @@ func MakeAppEnv(o *v1alpha1.Transformation) []corev1.EnvVar {
 		trnData = string(b)
 	}
 
-	return []corev1.EnvVar{
+	env := []corev1.EnvVar{
 		{
 			Name:  envTransformationCtx,
 			Value: trnContext,
@@ -82,4 +83,23 @@ func MakeAppEnv(o *v1alpha1.Transformation) []corev1.EnvVar {
 			Value: trnData,
 		},
 	}
+
+	if o.Spec.Storage != nil && o.Spec.Storage.Redis != nil {
+		redis := o.Spec.Storage.Redis
+		env = append(env, corev1.EnvVar{
+			Name:  common.EnvStorageRedisAddress,
+			Value: redis.Address,
+		})
+		if redis.Password != nil {
+			env = common.MaybeAppendValueFromEnvVar(env, common.EnvStorageRedisPassword, *redis.Password)
+		}
+		if redis.Database != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  common.EnvStorageRedisDatabase,
+				Value: strconv.Itoa(*redis.Database),
+			})
+		}
+	}
+
+	return env
 }
@@ -51,7 +51,10 @@ const (
 	metricsPrometheusPort uint16 = 9092
 )
 
-const roleNameConfigWatcher = "triggermesh-config-watcher"
+const (
+	roleNameConfigWatcher   = "triggermesh-config-watcher"
+	roleNameConfigMapWriter = "triggermesh-configmap-writer"
+)
 
 const defaultSinkTimeout = 30 * time.Second
 
@@ -279,6 +282,15 @@ func newConfigWatchRoleBinding(rcl v1alpha1.Reconcilable, owner *corev1.ServiceA
 	return newRoleBinding(rbName, roleNameConfigWatcher, rcl, owner)
 }
 
+// newConfigMapWriterRoleBinding returns a RoleBinding object that binds a
+// ServiceAccount (namespace-scoped) to the ConfigMap writer ClusterRole
+// (cluster-scoped).
+func newConfigMapWriterRoleBinding(rcl v1alpha1.Reconcilable, owner *corev1.ServiceAccount) *rbacv1.RoleBinding {
+	rbName := owner.Name + "-configmap-writer" // {kind}-adapter-configmap-writer or {kind}-i-{name}-configmap-writer
+
+	return newRoleBinding(rbName, roleNameConfigMapWriter, rcl, owner)
+}
+
 // newMTAdapterRoleBinding returns a RoleBinding object that binds a ServiceAccount
 // (namespace-scoped) to the (mt-)adapter's ClusterRole (cluster-scoped).
 func newMTAdapterRoleBinding(rcl v1alpha1.Reconcilable, owner *corev1.ServiceAccount) *rbacv1.RoleBinding {
@@ -30,6 +30,18 @@ const (
 	EnvCESource = "CE_SOURCE"
 	EnvCEType   = "CE_TYPE"
 
+	// Batching of events sent by targets
+	EnvBatchMaxEvents  = "BATCH_MAX_EVENTS"
+	EnvBatchMaxBytes   = "BATCH_MAX_BYTES"
+	EnvBatchLinger     = "BATCH_LINGER"
+	EnvBatchCompress   = "BATCH_COMPRESS"
+	EnvBatchMaxRetries = "BATCH_MAX_RETRIES"
+
+	// Redis storage shared by the replicas of stateful components
+	EnvStorageRedisAddress  = "STORAGE_REDIS_ADDRESS"
+	EnvStorageRedisPassword = "STORAGE_REDIS_PASSWORD" //nolint:gosec
+	EnvStorageRedisDatabase = "STORAGE_REDIS_DATABASE"
+
 	// Common AWS attributes
 	EnvARN             = "ARN"
 	EnvAccessKeyID     = "AWS_ACCESS_KEY_ID"
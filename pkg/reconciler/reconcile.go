@@ -319,6 +319,20 @@ func (r *GenericRBACReconciler[T, L]) reconcileRBAC(ctx context.Context,
 		return nil, fmt.Errorf("synchronizing adapter RoleBinding: %w", err)
 	}
 
+	// Bind serviceAccount to shared "triggermesh-configmap-writer" clusterRole.
+	// Some adapters persist their state in configMaps.
+	if v1alpha1.WritesConfigMaps(rcl) {
+		desiredRB := newConfigMapWriterRoleBinding(rcl, currentSA)
+		currentRB, err := r.getOrCreateAdapterRoleBinding(ctx, desiredRB)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = r.syncAdapterRoleBinding(ctx, currentRB, desiredRB); err != nil {
+			return nil, fmt.Errorf("synchronizing adapter RoleBinding: %w", err)
+		}
+	}
+
 	// Bind serviceAccount to "{kind}-adapter" clusterRole.
 	// Multi-tenant adapters require extra permissions to interact with
 	// objects of their kind.
@@ -0,0 +1,261 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"go.uber.org/zap"
+
+	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+	informerv1alpha1 "github.com/triggermesh/triggermesh/pkg/client/generated/injection/informers/routing/v1alpha1/aggregator"
+	routinglisters "github.com/triggermesh/triggermesh/pkg/client/generated/listers/routing/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/env"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
+)
+
+const serverPort int = 8080
+
+// Default timeout of aggregations, used when the Aggregator's spec was not defaulted.
+const defaultTimeout = 1 * time.Minute
+
+// Handler collects Cloud Events sharing a correlation attribute, and sends the
+// aggregated events to a subscriber.
+//
+// Ongoing aggregations are kept in memory, therefore all the events of a given
+// aggregation must be received by the same adapter instance.
+type Handler struct {
+	// receiver receives incoming HTTP requests
+	receiver *kncloudevents.HTTPMessageReceiver
+	// dispatcher sends requests to downstream services
+	dispatcher *dispatch.Dispatcher
+
+	aggregatorLister routinglisters.AggregatorNamespaceLister
+	logger           *zap.SugaredLogger
+
+	// expressions is the map of aggregator refs with precompiled completion expressions
+	expressions *storage.ExpressionStorage[cel.ConditionalFilter]
+	// aggregations is the set of ongoing aggregations
+	aggregations *aggregations
+}
+
+// NewEnvConfig satisfies env.ConfigConstructor.
+func NewEnvConfig() env.ConfigAccessor {
+	return &env.Config{}
+}
+
+// NewAdapter returns a constructor for the source's adapter.
+func NewAdapter(string) pkgadapter.AdapterConstructor {
+	return func(ctx context.Context, _ pkgadapter.EnvConfigAccessor, _ cloudevents.Client) pkgadapter.Adapter {
+		logger := logging.FromContext(ctx)
+
+		sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
+		if err != nil {
+			logger.Panicf("failed to create message sender: %v", err)
+		}
+
+		informer := informerv1alpha1.Get(ctx)
+		ns := injection.GetNamespaceScope(ctx)
+
+		return &Handler{
+			receiver:         kncloudevents.NewHTTPMessageReceiver(serverPort),
+			dispatcher:       dispatch.New(sender, logger),
+			aggregatorLister: informer.Lister().Aggregators(ns),
+			logger:           logger,
+
+			expressions:  storage.NewExpressionStorage[cel.ConditionalFilter](),
+			aggregations: newAggregations(),
+		}
+	}
+}
+
+// Start begins to receive messages for the handler.
+//
+// HTTP POST requests to the root path (/) are accepted.
+//
+// This method will block until ctx is done.
+func (h *Handler) Start(ctx context.Context) error {
+	return h.receiver.StartListen(ctx, h)
+}
+
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	aggregator, err := dispatch.ParseRequestURI(request.URL.Path)
+	if err != nil {
+		h.logger.Errorw("Unable to parse path as aggregator", zap.Error(err), zap.String("path", request.RequestURI))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ctx := request.Context()
+
+	message := cehttp.NewMessageFromHttpRequest(request)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer message.Finish(nil)
+
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		h.logger.Errorw("Failed to extract event from request", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Debugw("Received message", zap.Any("aggregator", aggregator))
+
+	a, err := h.aggregatorLister.Get(aggregator)
+	if err != nil {
+		h.logger.Errorw("Unable to get the Aggregator", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	correlation, err := attributeValue(event, a.Spec.CorrelationKey)
+	if err != nil {
+		h.logger.Errorw("Unable to read the correlation attribute", zap.Error(err), zap.String("id", event.ID()))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	complete, err := h.matchCompletion(ctx, a, event)
+	if err != nil {
+		h.logger.Errorw("Failed to evaluate the completion expression", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	agg := h.aggregations.add(a, correlation, event, complete, h.expire)
+	if agg == nil {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.emit(ctx, request.Header, agg, false); err != nil {
+		h.logger.Errorw("Failed to send the aggregated event", zap.Error(err), zap.String("correlation", correlation))
+		h.aggregations.restore(agg)
+		writer.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+// matchCompletion returns whether the event matches the completion expression
+// of the Aggregator, if any.
+func (h *Handler) matchCompletion(ctx context.Context, a *v1alpha1.Aggregator, event *cloudevents.Event) (bool, error) {
+	if a.Spec.Completion == nil {
+		return false, nil
+	}
+
+	cond, exists := h.expressions.Get(a.UID, a.Generation)
+	if !exists {
+		var err error
+		if cond, err = cel.CompileExpression(*a.Spec.Completion); err != nil {
+			return false, err
+		}
+		h.expressions.Set(a.UID, a.Generation, cond)
+	}
+
+	// events for which the expression can not be evaluated do not complete
+	// the aggregation
+	match, err := cond.Eval(ctx, *event)
+	return err == nil && match, nil
+}
+
+// expire handles aggregations which timeout elapsed before completion.
+func (h *Handler) expire(agg *aggregation) {
+	logger := h.logger.With(zap.String("correlation", agg.correlation), zap.Int("events", len(agg.events)))
+
+	// the Aggregator may have been updated or deleted since the
+	// beginning of the aggregation
+	a, err := h.aggregatorLister.Get(agg.aggregator.Name)
+	if err != nil {
+		logger.Errorw("Discarding partial aggregation of unavailable Aggregator", zap.Error(err))
+		return
+	}
+	agg.aggregator = a
+
+	if a.Spec.OnTimeout != nil && *a.Spec.OnTimeout == v1alpha1.AggregatorTimeoutPolicyDiscard {
+		logger.Infow("Discarding partial aggregation after timeout")
+		return
+	}
+
+	if err := h.emit(context.Background(), nil, agg, true); err != nil {
+		logger.Errorw("Failed to send the partial aggregated event", zap.Error(err))
+	}
+}
+
+// emit sends the aggregated event to the Aggregator's sink.
+func (h *Handler) emit(ctx context.Context, headers http.Header, agg *aggregation, partial bool) error {
+	event, err := agg.toEvent(partial)
+	if err != nil {
+		return err
+	}
+
+	return h.dispatcher.Deliver(ctx, headers, agg.aggregator.Status.SinkURI.String(), event)
+}
+
+// attributeValue returns the value of the given context attribute or
+// extension of the event.
+func attributeValue(event *cloudevents.Event, name string) (string, error) {
+	var value string
+
+	switch name {
+	case "id":
+		value = event.ID()
+	case "type":
+		value = event.Type()
+	case "source":
+		value = event.Source()
+	case "subject":
+		value = event.Subject()
+	case "dataschema":
+		value = event.DataSchema()
+	default:
+		ext, exists := event.Extensions()[name]
+		if !exists {
+			return "", fmt.Errorf("event has no attribute %q", name)
+		}
+		var err error
+		if value, err = types.Format(ext); err != nil {
+			return "", fmt.Errorf("formatting value of attribute %q: %w", name, err)
+		}
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("attribute %q is empty", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	logtesting "knative.dev/pkg/logging/testing"
+
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
+)
+
+const (
+	tCloudEventType   = "ce.test.type"
+	tCloudEventSource = "ce.test.source"
+
+	tCorrelation = "parent-id"
+)
+
+func TestAggregateByCount(t *testing.T) {
+	countKey, indexKey := "splitcount", "splitindex"
+
+	a := newAggregator()
+	a.Spec.CountKey = &countKey
+	a.Spec.IndexKey = &indexKey
+
+	s := newAggregations()
+	onTimeout := func(*aggregation) { t.Error("Unexpected timeout") }
+
+	// events are received in reverse order, the second one twice
+	for _, i := range []int{2, 1, 1, 0} {
+		e := newCloudEvent(t, i, fmt.Sprintf(`{"item":%d}`, i))
+		e.SetExtension(countKey, 3)
+		e.SetExtension(indexKey, i)
+
+		agg := s.add(a, tCorrelation, e, false, onTimeout)
+		if i != 0 {
+			assert.Nil(t, agg, "Aggregation completed before receiving all events")
+			continue
+		}
+		require.NotNil(t, agg, "Aggregation did not complete")
+
+		out, err := agg.toEvent(false)
+		require.NoError(t, err)
+
+		assert.Regexp(t, "^"+tCorrelation+`-\d+$`, out.ID())
+		assert.Equal(t, "aggregated.type", out.Type())
+		assert.Equal(t, "aggregated.source", out.Source())
+		assert.JSONEq(t, `[{"item":0},{"item":1},{"item":2}]`, string(out.Data()))
+		assert.EqualValues(t, 3, out.Extensions()[extensionAggregateCount])
+		assert.Equal(t, false, out.Extensions()[extensionAggregatePartial])
+	}
+
+	assert.Empty(t, s.m, "Complete aggregation was not removed")
+}
+
+func TestAggregateByCompletion(t *testing.T) {
+	completion := `$last.(bool) == true`
+
+	a := newAggregator()
+	a.Spec.Completion = &completion
+
+	h := &Handler{
+		logger:       logtesting.TestLogger(t),
+		expressions:  storage.NewExpressionStorage[cel.ConditionalFilter](),
+		aggregations: newAggregations(),
+	}
+	onTimeout := func(*aggregation) { t.Error("Unexpected timeout") }
+
+	for i, data := range []string{`{"last":false}`, `plain text`, `{"last":true}`} {
+		e := newCloudEvent(t, i, data)
+
+		complete, err := h.matchCompletion(context.Background(), a, e)
+		require.NoError(t, err)
+
+		agg := h.aggregations.add(a, tCorrelation, e, complete, onTimeout)
+		if i != 2 {
+			assert.Nil(t, agg, "Aggregation completed before matching the completion expression")
+			continue
+		}
+		require.NotNil(t, agg, "Aggregation did not complete")
+
+		out, err := agg.toEvent(false)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"last":false},"plain text",{"last":true}]`, string(out.Data()))
+	}
+}
+
+func TestAggregationTimeout(t *testing.T) {
+	timeout := tmapis.Duration(10 * time.Millisecond)
+
+	a := newAggregator()
+	a.Spec.Timeout = &timeout
+	a.Spec.Count = new(int32)
+	*a.Spec.Count = 3
+
+	s := newAggregations()
+
+	expired := make(chan *aggregation, 1)
+	onTimeout := func(agg *aggregation) { expired <- agg }
+
+	assert.Nil(t, s.add(a, tCorrelation, newCloudEvent(t, 0, `{}`), false, onTimeout))
+	assert.Nil(t, s.add(a, tCorrelation, newCloudEvent(t, 1, `{}`), false, onTimeout))
+
+	select {
+	case agg := <-expired:
+		out, err := agg.toEvent(true)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, out.Extensions()[extensionAggregateCount])
+		assert.Equal(t, true, out.Extensions()[extensionAggregatePartial])
+	case <-time.After(time.Second):
+		t.Fatal("Aggregation did not time out")
+	}
+
+	assert.Empty(t, s.m, "Expired aggregation was not removed")
+}
+
+func TestRecurringCorrelation(t *testing.T) {
+	a := newAggregator()
+	a.Spec.Count = new(int32)
+	*a.Spec.Count = 1
+
+	s := newAggregations()
+	onTimeout := func(*aggregation) { t.Error("Unexpected timeout") }
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		agg := s.add(a, tCorrelation, newCloudEvent(t, i, `{}`), false, onTimeout)
+		require.NotNil(t, agg, "Aggregation did not complete")
+
+		out, err := agg.toEvent(false)
+		require.NoError(t, err)
+		ids = append(ids, out.ID())
+
+		// the ID is stable across emissions of the same aggregation
+		again, err := agg.toEvent(false)
+		require.NoError(t, err)
+		assert.Equal(t, out.ID(), again.ID())
+	}
+
+	assert.NotEqual(t, ids[0], ids[1], "Aggregations of a recurring correlation value share the same ID")
+}
+
+func TestAttributeValue(t *testing.T) {
+	e := newCloudEvent(t, 0, `{}`)
+	e.SetSubject("subject")
+	e.SetExtension("splitid", tCorrelation)
+
+	v, err := attributeValue(e, "subject")
+	assert.NoError(t, err)
+	assert.Equal(t, "subject", v)
+
+	v, err = attributeValue(e, "splitid")
+	assert.NoError(t, err)
+	assert.Equal(t, tCorrelation, v)
+
+	_, err = attributeValue(e, "missing")
+	assert.Error(t, err)
+}
+
+func newAggregator() *v1alpha1.Aggregator {
+	return &v1alpha1.Aggregator{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "test-namespace",
+			Name:       "aggregator",
+			UID:        uuid.NewUUID(),
+			Generation: 1,
+		},
+		Spec: v1alpha1.AggregatorSpec{
+			CorrelationKey: "splitid",
+			CEContext: v1alpha1.CloudEventContext{
+				Type:   "aggregated.type",
+				Source: "aggregated.source",
+			},
+		},
+	}
+}
+
+func newCloudEvent(t *testing.T, i int, data string) *cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%s-%d", tCorrelation, i))
+	event.SetType(tCloudEventType)
+	event.SetSource(tCloudEventSource)
+	err := event.SetData(cloudevents.ApplicationJSON, []byte(data))
+	require.NoError(t, err)
+	return &event
+}
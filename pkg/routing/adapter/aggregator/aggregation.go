@@ -0,0 +1,244 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+)
+
+// Extensions set on the aggregated events.
+const (
+	extensionAggregateCount   = "aggregatecount"
+	extensionAggregatePartial = "aggregatepartial"
+)
+
+// aggregation is a set of events sharing the same correlation value.
+type aggregation struct {
+	key         string
+	correlation string
+	aggregator  *v1alpha1.Aggregator
+
+	events   []*cloudevents.Event
+	expected int
+	timer    *time.Timer
+	// time at which the first event of the aggregation was received
+	started time.Time
+}
+
+// insert adds an event to the aggregation. Redelivered events replace the
+// previously received events with the same ID.
+func (a *aggregation) insert(e *cloudevents.Event) {
+	for i := range a.events {
+		if a.events[i].ID() == e.ID() {
+			a.events[i] = e
+			return
+		}
+	}
+	a.events = append(a.events, e)
+}
+
+// isComplete returns whether the expected number of events was received.
+func (a *aggregation) isComplete() bool {
+	return a.expected > 0 && len(a.events) >= a.expected
+}
+
+// toEvent combines the events of the aggregation into a single event which
+// data is the JSON array of the data of the aggregated events.
+func (a *aggregation) toEvent(partial bool) (*cloudevents.Event, error) {
+	events := make([]*cloudevents.Event, len(a.events))
+	copy(events, a.events)
+
+	if indexKey := a.aggregator.Spec.IndexKey; indexKey != nil {
+		sort.SliceStable(events, func(i, j int) bool {
+			return eventIndex(events[i], *indexKey) < eventIndex(events[j], *indexKey)
+		})
+	}
+
+	items := make([]json.RawMessage, len(events))
+	for i, e := range events {
+		data := e.Data()
+		switch {
+		case len(data) == 0:
+			items[i] = json.RawMessage("null")
+		case json.Valid(data):
+			items[i] = json.RawMessage(data)
+		default:
+			str, err := json.Marshal(string(data))
+			if err != nil {
+				return nil, fmt.Errorf("encoding data of event %q: %w", e.ID(), err)
+			}
+			items[i] = str
+		}
+	}
+
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("encoding aggregated data: %w", err)
+	}
+
+	ceCtx := a.aggregator.Spec.CEContext
+
+	out := cloudevents.NewEvent()
+	out.SetID(a.id())
+	out.SetType(ceCtx.Type)
+	out.SetSource(ceCtx.Source)
+	for key, value := range ceCtx.Extensions {
+		out.SetExtension(key, value)
+	}
+	out.SetExtension(extensionAggregateCount, len(events))
+	out.SetExtension(extensionAggregatePartial, partial)
+
+	if err := out.SetData(cloudevents.ApplicationJSON, payload); err != nil {
+		return nil, fmt.Errorf("setting aggregated data: %w", err)
+	}
+	out.DataBase64 = false
+
+	return &out, nil
+}
+
+// id returns the ID of the aggregated event. Aggregations of a correlation
+// value which recurs after the previous aggregation was emitted have distinct
+// IDs, while the same aggregation emitted again after a failed delivery keeps
+// its ID.
+func (a *aggregation) id() string {
+	return a.correlation + "-" + strconv.FormatInt(a.started.UnixNano(), 10)
+}
+
+// aggregations keeps track of the ongoing aggregations.
+type aggregations struct {
+	sync.Mutex
+	m map[string]*aggregation
+}
+
+// newAggregations returns an empty set of aggregations.
+func newAggregations() *aggregations {
+	return &aggregations{
+		m: make(map[string]*aggregation),
+	}
+}
+
+// add adds an event to the aggregation identified by the given correlation
+// value, and returns this aggregation if it is complete. A complete
+// aggregation is removed from the set of ongoing aggregations.
+//
+// The onTimeout callback is invoked with the aggregation when its timeout
+// elapses before completion.
+func (s *aggregations) add(agg *v1alpha1.Aggregator, correlation string, e *cloudevents.Event,
+	complete bool, onTimeout func(*aggregation)) *aggregation {
+
+	s.Lock()
+	defer s.Unlock()
+
+	key := string(agg.UID) + "/" + correlation
+
+	a, exists := s.m[key]
+	if !exists {
+		a = &aggregation{
+			key:         key,
+			correlation: correlation,
+			aggregator:  agg,
+			started:     time.Now(),
+		}
+		if agg.Spec.Count != nil {
+			a.expected = int(*agg.Spec.Count)
+		}
+		a.timer = time.AfterFunc(aggregationTimeout(agg), func() {
+			if s.remove(a) {
+				onTimeout(a)
+			}
+		})
+		s.m[key] = a
+	}
+
+	a.insert(e)
+
+	if countKey := agg.Spec.CountKey; countKey != nil {
+		if count, err := types.ToInteger(e.Extensions()[*countKey]); err == nil && count > 0 {
+			a.expected = int(count)
+		}
+	}
+
+	if !complete && !a.isComplete() {
+		return nil
+	}
+
+	a.timer.Stop()
+	delete(s.m, key)
+
+	return a
+}
+
+// remove removes the given aggregation from the set of ongoing aggregations.
+// It returns false if the aggregation was already removed.
+func (s *aggregations) remove(a *aggregation) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.m[a.key] != a {
+		return false
+	}
+	delete(s.m, a.key)
+
+	return true
+}
+
+// restore puts back a complete aggregation which could not be delivered into
+// the set of ongoing aggregations, so that it can be emitted again upon
+// redelivery of its events or upon timeout.
+func (s *aggregations) restore(a *aggregation) {
+	s.Lock()
+	defer s.Unlock()
+
+	if current, exists := s.m[a.key]; exists {
+		for _, e := range a.events {
+			current.insert(e)
+		}
+		return
+	}
+
+	s.m[a.key] = a
+	a.timer.Reset(aggregationTimeout(a.aggregator))
+}
+
+// aggregationTimeout returns the timeout of the aggregations of the given Aggregator.
+func aggregationTimeout(agg *v1alpha1.Aggregator) time.Duration {
+	if agg.Spec.Timeout == nil {
+		return defaultTimeout
+	}
+	return time.Duration(*agg.Spec.Timeout)
+}
+
+// eventIndex returns the position of an event in the aggregation, as read
+// from the given extension. Events without index are sorted last.
+func eventIndex(e *cloudevents.Event, indexKey string) int32 {
+	idx, err := types.ToInteger(e.Extensions()[indexKey])
+	if err != nil {
+		return math.MaxInt32
+	}
+	return idx
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dispatch contains the logic shared by the routing components
+// for sending events to their destinations.
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/utils"
+)
+
+// Dispatcher sends events to the destinations of a routing component.
+type Dispatcher struct {
+	// sender sends requests to downstream services
+	sender *kncloudevents.HTTPMessageSender
+	logger *zap.SugaredLogger
+}
+
+// New returns a Dispatcher which sends requests using the given sender.
+func New(sender *kncloudevents.HTTPMessageSender, logger *zap.SugaredLogger) *Dispatcher {
+	return &Dispatcher{
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// Send sends the event to the target and relays the response of the target
+// to the writer.
+func (d *Dispatcher) Send(ctx context.Context, writer http.ResponseWriter, headers http.Header, target string, event *cloudevents.Event) {
+	// send the event to trigger's subscriber
+	response, err := d.SendEvent(ctx, headers, target, event)
+	if err != nil {
+		d.logger.Errorw("Failed to send event", zap.Error(err))
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	d.logger.Debug("Successfully dispatched message", zap.Any("target", target))
+
+	// If there is an event in the response write it to the response
+	_, err = d.WriteResponse(ctx, writer, response, target)
+	if err != nil {
+		d.logger.Errorw("Failed to write response", zap.Error(err))
+	}
+}
+
+// Deliver sends the event to the target and discards the response. An error
+// is returned if the target does not accept the event.
+func (d *Dispatcher) Deliver(ctx context.Context, headers http.Header, target string, event *cloudevents.Event) error {
+	resp, err := d.SendEvent(ctx, headers, target, event)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("destination responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendEvent sends the event to the target, along with the pass-through
+// headers of the original request.
+func (d *Dispatcher) SendEvent(ctx context.Context, headers http.Header, target string, event *cloudevents.Event) (*http.Response, error) {
+	// Send the event to the subscriber
+	req, err := d.sender.NewCloudEventRequestWithTarget(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the request: %w", err)
+	}
+
+	message := binding.ToMessage(event)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer message.Finish(nil)
+
+	additionalHeaders := utils.PassThroughHeaders(headers)
+	err = kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, req, additionalHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write request: %w", err)
+	}
+
+	resp, err := d.sender.Send(req)
+	if err != nil {
+		err = fmt.Errorf("failed to dispatch message: %w", err)
+	}
+
+	return resp, err
+}
+
+// WriteResponse relays the response of the target to the writer. The return
+// values are the status code of the response and the error which occurred
+// while writing it, if any.
+func (d *Dispatcher) WriteResponse(ctx context.Context, writer http.ResponseWriter, resp *http.Response, target string) (int, error) {
+	response := cehttp.NewMessageFromHttpResponse(resp)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer response.Finish(nil)
+
+	if response.ReadEncoding() == binding.EncodingUnknown {
+		// Response doesn't have a ce-specversion header nor a content-type matching a cloudevent event format
+		// Just read a byte out of the reader to see if it's non-empty, we don't care what it is,
+		// just that it is not empty. This means there was a response and it's not valid, so treat
+		// as delivery failure.
+		body := make([]byte, 1)
+		n, _ := response.BodyReader.Read(body)
+		response.BodyReader.Close()
+		if n != 0 {
+			// Note that we could just use StatusInternalServerError, but to distinguish
+			// between the failure cases, we use a different code here.
+			writer.WriteHeader(http.StatusBadGateway)
+			return http.StatusBadGateway, errors.New("received a non-empty response not recognized as CloudEvent. The response MUST be or empty or a valid CloudEvent")
+		}
+		d.logger.Debug("Response doesn't contain a CloudEvent, replying with an empty response", zap.Any("target", target))
+		writer.WriteHeader(resp.StatusCode)
+		return resp.StatusCode, nil
+	}
+
+	event, err := binding.ToEvent(ctx, response)
+	if err != nil {
+		// Like in the above case, we could just use StatusInternalServerError, but to distinguish
+		// between the failure cases, we use a different code here.
+		writer.WriteHeader(http.StatusBadGateway)
+		// Malformed event, reply with err
+		return http.StatusBadGateway, err
+	}
+
+	eventResponse := binding.ToMessage(event)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer eventResponse.Finish(nil)
+
+	if err := cehttp.WriteResponseWriter(ctx, eventResponse, resp.StatusCode, writer); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to write response event: %w", err)
+	}
+
+	d.logger.Debug("Replied with a CloudEvent response", zap.Any("target", target))
+
+	return resp.StatusCode, nil
+}
+
+// ParseRequestURI returns the name of the routing object addressed by the
+// request path, in the "/<namespace>/<name>" format.
+func ParseRequestURI(path string) (string, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("incorrect number of parts in the path, expected 2, actual %d, '%s'", len(parts), path)
+	}
+	return parts[2], nil
+}
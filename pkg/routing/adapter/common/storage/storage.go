@@ -0,0 +1,68 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage contains a cache of objects compiled from the
+// specs of the routing components, such as CEL expressions.
+package storage
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type generations[T any] map[int64]T
+type uids[T any] map[types.UID]generations[T]
+
+// ExpressionStorage keeps compiled expressions indexed by the UID
+// and the generation of the object they were compiled from.
+type ExpressionStorage[T any] struct {
+	*sync.RWMutex
+	uids uids[T]
+}
+
+// NewExpressionStorage returns an empty ExpressionStorage.
+func NewExpressionStorage[T any]() *ExpressionStorage[T] {
+	return &ExpressionStorage[T]{
+		RWMutex: &sync.RWMutex{},
+		uids:    make(uids[T]),
+	}
+}
+
+// Get returns the expression compiled for the given object generation.
+func (f *ExpressionStorage[T]) Get(uid types.UID, generation int64) (T, bool) {
+	f.RLock()
+	defer f.RUnlock()
+
+	gens, exist := f.uids[uid]
+	if !exist {
+		var empty T
+		return empty, false
+	}
+
+	expression, exist := gens[generation]
+	return expression, exist
+}
+
+// Set method overrides previous generations of compiled expressions
+func (f *ExpressionStorage[T]) Set(uid types.UID, generation int64, expression T) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.uids[uid] = generations[T]{
+		generation: expression,
+	}
+}
@@ -47,6 +47,9 @@ import (
 	v1alpha1 "github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
 	fakeinjectionclient "github.com/triggermesh/triggermesh/pkg/client/generated/injection/client/fake"
 	fakeinformer "github.com/triggermesh/triggermesh/pkg/client/generated/injection/informers/routing/v1alpha1/filter/fake"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
 )
 
 const (
@@ -271,12 +274,14 @@ func newHandler(t *testing.T, ctx context.Context, port int) *Handler {
 	sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
 	assert.NoError(t, err)
 
+	logger := logtesting.TestLogger(t)
+
 	return &Handler{
 		receiver:     kncloudevents.NewHTTPMessageReceiver(port),
-		sender:       sender,
+		dispatcher:   dispatch.New(sender, logger),
 		filterLister: fakeinformer.Get(ctx).Lister().Filters(tNS),
-		logger:       logtesting.TestLogger(t),
-		expressions:  newExpressionStorage(),
+		logger:       logger,
+		expressions:  storage.NewExpressionStorage[cel.ConditionalFilter](),
 	}
 }
 
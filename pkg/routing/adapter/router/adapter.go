@@ -0,0 +1,265 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"go.uber.org/zap"
+
+	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+	informerv1alpha1 "github.com/triggermesh/triggermesh/pkg/client/generated/injection/informers/routing/v1alpha1/router"
+	routinglisters "github.com/triggermesh/triggermesh/pkg/client/generated/listers/routing/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/env"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/storage"
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter/cel"
+)
+
+const serverPort int = 8080
+
+const (
+	// Number of attempts at delivering an event sent to multiple
+	// destinations to each of them.
+	fanOutAttempts = 3
+	// Delay before the first retry of failed deliveries, doubled after
+	// each attempt.
+	fanOutRetryBackoff = 100 * time.Millisecond
+)
+
+// Handler parses Cloud Events, evaluates the routing rules, and sends them to the matching destinations.
+type Handler struct {
+	// receiver receives incoming HTTP requests
+	receiver *kncloudevents.HTTPMessageReceiver
+	// dispatcher sends requests to downstream services
+	dispatcher *dispatch.Dispatcher
+
+	routerLister routinglisters.RouterNamespaceLister
+	logger       *zap.SugaredLogger
+
+	// expressions is the map of router refs with precompiled CEL expressions of their routes
+	expressions *storage.ExpressionStorage[[]cel.ConditionalFilter]
+}
+
+// NewEnvConfig satisfies env.ConfigConstructor.
+func NewEnvConfig() env.ConfigAccessor {
+	return &env.Config{}
+}
+
+// NewAdapter returns a constructor for the source's adapter.
+func NewAdapter(string) pkgadapter.AdapterConstructor {
+	return func(ctx context.Context, _ pkgadapter.EnvConfigAccessor, _ cloudevents.Client) pkgadapter.Adapter {
+		logger := logging.FromContext(ctx)
+
+		sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
+		if err != nil {
+			logger.Panicf("failed to create message sender: %v", err)
+		}
+
+		informer := informerv1alpha1.Get(ctx)
+		ns := injection.GetNamespaceScope(ctx)
+
+		return &Handler{
+			receiver:     kncloudevents.NewHTTPMessageReceiver(serverPort),
+			dispatcher:   dispatch.New(sender, logger),
+			routerLister: informer.Lister().Routers(ns),
+			logger:       logger,
+
+			expressions: storage.NewExpressionStorage[[]cel.ConditionalFilter](),
+		}
+	}
+}
+
+// Start begins to receive messages for the handler.
+//
+// HTTP POST requests to the root path (/) are accepted.
+//
+// This method will block until ctx is done.
+func (h *Handler) Start(ctx context.Context) error {
+	return h.receiver.StartListen(ctx, h)
+}
+
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	router, err := dispatch.ParseRequestURI(request.URL.Path)
+	if err != nil {
+		h.logger.Errorw("Unable to parse path as router", zap.Error(err), zap.String("path", request.RequestURI))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ctx := request.Context()
+
+	message := cehttp.NewMessageFromHttpRequest(request)
+	// cannot be err, but makes linter complain about missing err check
+	//nolint
+	defer message.Finish(nil)
+
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		h.logger.Errorw("Failed to extract event from request", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Debug("Received message", zap.Any("router", router))
+
+	r, err := h.routerLister.Get(router)
+	if err != nil {
+		h.logger.Errorw("Unable to get the Router", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	conds, exists := h.expressions.Get(r.UID, r.Generation)
+	if !exists {
+		if conds, err = compileRoutes(r.Spec.Routes); err != nil {
+			h.logger.Errorw("Failed to compile route expression", zap.Error(err), zap.Any("router", router))
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.expressions.Set(r.UID, r.Generation, conds)
+	}
+
+	targets, err := matchRoutes(ctx, r, conds, *event)
+	if err != nil {
+		h.logger.Errorw("Failed to route the event", zap.Error(err), zap.Any("router", router))
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch len(targets) {
+	case 0:
+		h.logger.Infow("Event did not match any route and was dropped", zap.String("router", router),
+			zap.String("id", event.ID()), zap.String("type", event.Type()), zap.String("source", event.Source()))
+	case 1:
+		h.dispatcher.Send(ctx, writer, request.Header, targets[0], event)
+	default:
+		h.fanOut(ctx, writer, request.Header, targets, event)
+	}
+}
+
+// compileRoutes compiles CEL expressions of the routes.
+func compileRoutes(routes []v1alpha1.Route) ([]cel.ConditionalFilter, error) {
+	conds := make([]cel.ConditionalFilter, 0, len(routes))
+	for i, route := range routes {
+		cond, err := cel.CompileExpression(route.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: %w", i, err)
+		}
+		conds = append(conds, cond)
+	}
+	return conds, nil
+}
+
+// matchRoutes returns the destinations of the routes matching the event
+// according to the Router mode, or the default sink if no route matched.
+func matchRoutes(ctx context.Context, r *v1alpha1.Router, conds []cel.ConditionalFilter,
+	event cloudevents.Event) ([]string, error) {
+
+	var targets []string
+	for i := range conds {
+		// routes which expression can not be evaluated do not match
+		if match, err := conds[i].Eval(ctx, event); err != nil || !match {
+			continue
+		}
+
+		if i >= len(r.Status.RouteURIs) || r.Status.RouteURIs[i] == nil {
+			return nil, fmt.Errorf("destination of route %d is not resolved", i)
+		}
+		targets = append(targets, r.Status.RouteURIs[i].String())
+
+		if r.Spec.Mode == nil || *r.Spec.Mode == v1alpha1.RouterModeFirst {
+			break
+		}
+	}
+
+	if len(targets) == 0 && r.Status.SinkURI != nil {
+		targets = append(targets, r.Status.SinkURI.String())
+	}
+
+	return targets, nil
+}
+
+// fanOut sends the event to multiple destinations. Responses of the
+// destinations are discarded, and failed deliveries are retried for those
+// destinations only. The request fails only if no destination accepted the
+// event, since a redelivery by the sender would otherwise duplicate the event
+// at the destinations which accepted it.
+func (h *Handler) fanOut(ctx context.Context, writer http.ResponseWriter, headers http.Header, targets []string, event *cloudevents.Event) {
+	pending := targets
+	backoff := fanOutRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		var failed []string
+		for _, target := range pending {
+			if err := h.dispatcher.Deliver(ctx, headers, target, event); err != nil {
+				h.logger.Errorw("Failed to send event", zap.Error(err), zap.String("target", target),
+					zap.Int("attempt", attempt))
+				failed = append(failed, target)
+				continue
+			}
+			h.logger.Debug("Successfully dispatched message", zap.Any("target", target))
+		}
+
+		if pending = failed; len(pending) == 0 || attempt == fanOutAttempts || !sleep(ctx, backoff) {
+			break
+		}
+		backoff *= 2
+	}
+
+	switch {
+	case len(pending) == len(targets):
+		writer.WriteHeader(http.StatusBadGateway)
+	case len(pending) != 0:
+		h.logger.Errorw("Event could not be delivered to some destinations", zap.Strings("targets", pending),
+			zap.String("id", event.ID()), zap.String("type", event.Type()), zap.String("source", event.Source()))
+		writer.WriteHeader(http.StatusOK)
+	default:
+		writer.WriteHeader(http.StatusOK)
+	}
+}
+
+// sleep waits for the given duration, and returns false if the context is
+// done before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
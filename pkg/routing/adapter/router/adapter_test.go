@@ -0,0 +1,223 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	common "github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
+)
+
+const (
+	tCloudEventID     = "ce-abcd-0123"
+	tCloudEventType   = "ce.test.type"
+	tCloudEventSource = "ce.test.source"
+
+	tRouteA   = "http://route-a"
+	tRouteB   = "http://route-b"
+	tFallback = "http://fallback"
+)
+
+func TestMatchRoutes(t *testing.T) {
+	first, all := v1alpha1.RouterModeFirst, v1alpha1.RouterModeAll
+
+	testCases := map[string]struct {
+		mode     *v1alpha1.RouterMode
+		fallback bool
+		payload  string
+		expect   []string
+	}{
+		"First match": {
+			mode:    &first,
+			payload: `{"name":"bob","age":42}`,
+			expect:  []string{tRouteA},
+		},
+		"All matches": {
+			mode:    &all,
+			payload: `{"name":"bob","age":42}`,
+			expect:  []string{tRouteA, tRouteB},
+		},
+		"Default mode": {
+			payload: `{"name":"alice","age":42}`,
+			expect:  []string{tRouteB},
+		},
+		"No match, default sink": {
+			mode:     &all,
+			fallback: true,
+			payload:  `{"name":"alice","age":20}`,
+			expect:   []string{tFallback},
+		},
+		"No match, no default sink": {
+			mode:    &all,
+			payload: `{"name":"alice","age":20}`,
+			expect:  nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			r := newRouter(t, tc.mode, tc.fallback)
+
+			conds, err := compileRoutes(r.Spec.Routes)
+			require.NoError(t, err)
+
+			targets, err := matchRoutes(context.Background(), r, conds, newCloudEvent(t, tc.payload))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, targets)
+		})
+	}
+}
+
+func TestMatchUnresolvedRoute(t *testing.T) {
+	r := newRouter(t, nil, false)
+	r.Status.RouteURIs = nil
+
+	conds, err := compileRoutes(r.Spec.Routes)
+	require.NoError(t, err)
+
+	_, err = matchRoutes(context.Background(), r, conds, newCloudEvent(t, `{"name":"bob","age":42}`))
+	assert.Error(t, err)
+}
+
+func TestCompileMalformedRoute(t *testing.T) {
+	_, err := compileRoutes([]v1alpha1.Route{{Expression: `hi!`}})
+	assert.Error(t, err)
+}
+
+func TestFanOut(t *testing.T) {
+	testCases := map[string]struct {
+		failures       []int
+		expectAttempts []int32
+		expectStatus   int
+	}{
+		"All destinations accept the event": {
+			failures:       []int{0, 0},
+			expectAttempts: []int32{1, 1},
+			expectStatus:   http.StatusOK,
+		},
+		"Only failed deliveries are retried": {
+			failures:       []int{0, 1},
+			expectAttempts: []int32{1, 2},
+			expectStatus:   http.StatusOK,
+		},
+		"Some destinations never accept the event": {
+			failures:       []int{0, fanOutAttempts},
+			expectAttempts: []int32{1, fanOutAttempts},
+			expectStatus:   http.StatusOK,
+		},
+		"No destination accepts the event": {
+			failures:       []int{fanOutAttempts, fanOutAttempts},
+			expectAttempts: []int32{fanOutAttempts, fanOutAttempts},
+			expectStatus:   http.StatusBadGateway,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			attempts := make([]int32, len(tc.failures))
+			targets := make([]string, len(tc.failures))
+
+			for i := range tc.failures {
+				i := i
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if int(atomic.AddInt32(&attempts[i], 1)) <= tc.failures[i] {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					w.WriteHeader(http.StatusAccepted)
+				}))
+				t.Cleanup(srv.Close)
+				targets[i] = srv.URL
+			}
+
+			sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
+			require.NoError(t, err)
+
+			logger := logtesting.TestLogger(t)
+			h := &Handler{
+				dispatcher: dispatch.New(sender, logger),
+				logger:     logger,
+			}
+
+			event := newCloudEvent(t, `{"name":"bob","age":42}`)
+			rec := httptest.NewRecorder()
+			h.fanOut(context.Background(), rec, http.Header{}, targets, &event)
+
+			assert.Equal(t, tc.expectStatus, rec.Code)
+			assert.Equal(t, tc.expectAttempts, attempts)
+		})
+	}
+}
+
+func newRouter(t *testing.T, mode *v1alpha1.RouterMode, fallback bool) *v1alpha1.Router {
+	r := &v1alpha1.Router{
+		Spec: v1alpha1.RouterSpec{
+			Mode: mode,
+			Routes: []v1alpha1.Route{
+				{Expression: `$name.(string) == "bob"`},
+				{Expression: `$age.(int64) > 30`},
+			},
+		},
+		Status: v1alpha1.RouterStatus{
+			RouteURIs: []*apis.URL{parseURL(t, tRouteA), parseURL(t, tRouteB)},
+		},
+	}
+
+	if fallback {
+		r.Status.Status = common.Status{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: parseURL(t, tFallback),
+			},
+		}
+	}
+
+	return r
+}
+
+func newCloudEvent(t *testing.T, data string) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(tCloudEventID)
+	event.SetType(tCloudEventType)
+	event.SetSource(tCloudEventSource)
+	err := event.SetData(cloudevents.ApplicationJSON, []byte(data))
+	require.NoError(t, err)
+	return event
+}
+
+func parseURL(t *testing.T, s string) *apis.URL {
+	u, err := apis.ParseURL(s)
+	require.NoError(t, err)
+	return u
+}
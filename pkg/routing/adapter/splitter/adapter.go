@@ -18,9 +18,10 @@ package splitter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -31,23 +32,37 @@ import (
 
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
-	"knative.dev/eventing/pkg/utils"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
 	informerv1alpha1 "github.com/triggermesh/triggermesh/pkg/client/generated/injection/informers/routing/v1alpha1/splitter"
 	routinglisters "github.com/triggermesh/triggermesh/pkg/client/generated/listers/routing/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
 	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/env"
 )
 
 const serverPort int = 8080
 
+// Extensions set on the resulting events when IndexExtensions is enabled.
+const (
+	extensionSplitID    = "splitid"
+	extensionSplitIndex = "splitindex"
+	extensionSplitCount = "splitcount"
+)
+
+// Default retry parameters of the "retry" failure policy.
+const (
+	defaultRetries      = 3
+	defaultBackoffDelay = 500 * time.Millisecond
+)
+
 // Handler parses Cloud Events, determines if they pass a filter, and sends them to a subscriber.
 type Handler struct {
 	// receiver receives incoming HTTP requests
 	receiver *kncloudevents.HTTPMessageReceiver
-	// sender sends requests to downstream services
-	sender *kncloudevents.HTTPMessageSender
+	// dispatcher sends requests to downstream services
+	dispatcher *dispatch.Dispatcher
 
 	splitterLister routinglisters.SplitterNamespaceLister
 	logger         *zap.SugaredLogger
@@ -73,7 +88,7 @@ func NewAdapter(string) pkgadapter.AdapterConstructor {
 
 		return &Handler{
 			receiver:       kncloudevents.NewHTTPMessageReceiver(serverPort),
-			sender:         sender,
+			dispatcher:     dispatch.New(sender, logger),
 			splitterLister: informer.Lister().Splitters(ns),
 			logger:         logger,
 		}
@@ -95,7 +110,7 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	splitter, err := parseRequestURI(request.URL.Path)
+	splitter, err := dispatch.ParseRequestURI(request.URL.Path)
 	if err != nil {
 		h.logger.Errorw("Unable to parse path as splitter", zap.Error(err), zap.String("path", request.RequestURI))
 		writer.WriteHeader(http.StatusBadRequest)
@@ -125,21 +140,146 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	for i, e := range h.split(s.Spec.Path, event) {
-		e.SetID(fmt.Sprintf("%s-%d", event.ID(), i))
+	children := h.split(s.Spec.Path, event)
+	setContext(s, event, children)
+
+	failed := h.deliver(ctx, request.Header, s.Status.SinkURI.String(), children, nil)
+
+	policy := v1alpha1.SplitterFailurePolicyIgnore
+	if d := s.Spec.Delivery; d != nil && d.OnFailure != nil {
+		policy = *d.OnFailure
+	}
+
+	if len(failed) != 0 && policy == v1alpha1.SplitterFailurePolicyRetry {
+		failed = h.retry(ctx, request.Header, s, children, failed)
+	}
+
+	if len(failed) == 0 || policy == v1alpha1.SplitterFailurePolicyIgnore {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.logger.Errorw("Failed to deliver split events", zap.Any("splitter", splitter),
+		zap.Int("failed", len(failed)), zap.Int("total", len(children)))
+	writeDeliveryFailures(writer, failed)
+}
+
+// setContext sets the context attributes of the events resulting from the
+// split of the parent event, according to the Splitter's spec.
+func setContext(s *v1alpha1.Splitter, parent *cloudevents.Event, children []*cloudevents.Event) {
+	preserve := s.Spec.PreserveContext != nil && *s.Spec.PreserveContext
+	index := s.Spec.IndexExtensions != nil && *s.Spec.IndexExtensions
+
+	for i, e := range children {
+		e.SetID(fmt.Sprintf("%s-%d", parent.ID(), i))
 		e.SetType(s.Spec.CEContext.Type)
 		e.SetSource(s.Spec.CEContext.Source)
+
+		if preserve {
+			if subject := parent.Subject(); subject != "" {
+				e.SetSubject(subject)
+			}
+			if ct := parent.DataContentType(); ct != "" {
+				e.SetDataContentType(ct)
+			}
+			for key, value := range parent.Extensions() {
+				e.SetExtension(key, value)
+			}
+		}
+
 		for key, value := range s.Spec.CEContext.Extensions {
 			e.SetExtension(key, value)
 		}
-		// we may want to keep responses and send them back to the source
-		_, err := h.sendEvent(ctx, request.Header, s.Status.SinkURI.String(), e)
-		if err != nil {
-			h.logger.Errorw("Failed to send the event", zap.Error(err))
+
+		if index {
+			e.SetExtension(extensionSplitID, parent.ID())
+			e.SetExtension(extensionSplitIndex, i)
+			e.SetExtension(extensionSplitCount, len(children))
+		}
+	}
+}
+
+// deliveryFailure describes an event that could not be delivered.
+type deliveryFailure struct {
+	Index int    `json:"index"`
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// deliver sends the events at the given indexes, or all events if indexes is
+// nil, to the target and returns the failed deliveries.
+func (h *Handler) deliver(ctx context.Context, headers http.Header, target string,
+	events []*cloudevents.Event, indexes []int) []deliveryFailure {
+
+	if indexes == nil {
+		indexes = make([]int, len(events))
+		for i := range events {
+			indexes[i] = i
+		}
+	}
+
+	var failed []deliveryFailure
+	for _, i := range indexes {
+		if err := h.dispatcher.Deliver(ctx, headers, target, events[i]); err != nil {
+			h.logger.Errorw("Failed to send the event", zap.Error(err), zap.String("id", events[i].ID()))
+			failed = append(failed, deliveryFailure{
+				Index: i,
+				ID:    events[i].ID(),
+				Error: err.Error(),
+			})
 		}
 	}
 
-	writer.WriteHeader(http.StatusOK)
+	return failed
+}
+
+// retry re-sends the failed events with an exponential backoff until they
+// are all delivered or the retries are exhausted, and returns the events that
+// could not be delivered.
+func (h *Handler) retry(ctx context.Context, headers http.Header, s *v1alpha1.Splitter,
+	events []*cloudevents.Event, failed []deliveryFailure) []deliveryFailure {
+
+	retries := defaultRetries
+	delay := defaultBackoffDelay
+	if d := s.Spec.Delivery; d != nil {
+		if d.Retries != nil {
+			retries = int(*d.Retries)
+		}
+		if d.BackoffDelay != nil {
+			delay = time.Duration(*d.BackoffDelay)
+		}
+	}
+
+	for attempt := 0; attempt < retries && len(failed) != 0; attempt++ {
+		select {
+		case <-ctx.Done():
+			return failed
+		case <-time.After(delay):
+		}
+		delay *= 2
+
+		indexes := make([]int, len(failed))
+		for i, f := range failed {
+			indexes[i] = f.Index
+		}
+		failed = h.deliver(ctx, headers, s.Status.SinkURI.String(), events, indexes)
+	}
+
+	return failed
+}
+
+// writeDeliveryFailures responds to the original request with the list of
+// events that could not be delivered.
+func writeDeliveryFailures(writer http.ResponseWriter, failed []deliveryFailure) {
+	writer.Header().Set("Content-Type", cloudevents.ApplicationJSON)
+	writer.WriteHeader(http.StatusBadGateway)
+
+	//nolint:errcheck
+	json.NewEncoder(writer).Encode(struct {
+		Failed []deliveryFailure `json:"failed"`
+	}{
+		Failed: failed,
+	})
 }
 
 func (h *Handler) split(path string, e *event.Event) []*event.Event {
@@ -160,37 +300,3 @@ func (h *Handler) split(path string, e *event.Event) []*event.Event {
 	}
 	return result
 }
-
-func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target string, event *cloudevents.Event) (*http.Response, error) {
-	// Send the event to the subscriber
-	req, err := h.sender.NewCloudEventRequestWithTarget(ctx, target)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create the request: %w", err)
-	}
-
-	message := binding.ToMessage(event)
-	// cannot be err, but makes linter complain about missing err check
-	//nolint
-	defer message.Finish(nil)
-
-	additionalHeaders := utils.PassThroughHeaders(headers)
-	err = kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, req, additionalHeaders)
-	if err != nil {
-		return nil, fmt.Errorf("failed to write request: %w", err)
-	}
-
-	resp, err := h.sender.Send(req)
-	if err != nil {
-		err = fmt.Errorf("failed to dispatch message: %w", err)
-	}
-
-	return resp, err
-}
-
-func parseRequestURI(path string) (string, error) {
-	parts := strings.Split(path, "/")
-	if len(parts) != 3 {
-		return "", fmt.Errorf("incorrect number of parts in the path, expected 2, actual %d, '%s'", len(parts), path)
-	}
-	return parts[2], nil
-}
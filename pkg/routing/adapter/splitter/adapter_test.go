@@ -24,7 +24,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,10 +44,12 @@ import (
 	"knative.dev/pkg/injection"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	tmapis "github.com/triggermesh/triggermesh/pkg/apis"
 	common "github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
 	v1alpha1 "github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
 	fakeinjectionclient "github.com/triggermesh/triggermesh/pkg/client/generated/injection/client/fake"
 	fakeinformer "github.com/triggermesh/triggermesh/pkg/client/generated/injection/informers/routing/v1alpha1/splitter/fake"
+	"github.com/triggermesh/triggermesh/pkg/routing/adapter/common/dispatch"
 )
 
 const (
@@ -156,6 +160,94 @@ func TestAdapter(t *testing.T) {
 	}
 }
 
+func TestSetContext(t *testing.T) {
+	parent := newCloudEvent(t, `{"items":[1,2]}`)
+	parent.SetSubject("parent-subject")
+	parent.SetExtension("parentext", "parent-value")
+	parent.SetExtension("override", "parent-value")
+
+	preserve, index := true, true
+
+	s := newSplitter(t, tSplitter.key, tSplitter.path, "sink")
+	s.Spec.CEContext = v1alpha1.CloudEventContext{
+		Type:   "split.type",
+		Source: "split.source",
+		Extensions: map[string]string{
+			"override": "splitter-value",
+		},
+	}
+	s.Spec.PreserveContext = &preserve
+	s.Spec.IndexExtensions = &index
+
+	h := &Handler{logger: logtesting.TestLogger(t)}
+	children := h.split(s.Spec.Path, &parent)
+	require.Len(t, children, 2)
+
+	setContext(s, &parent, children)
+
+	for i, e := range children {
+		assert.Equal(t, fmt.Sprintf("%s-%d", tCloudEventID, i), e.ID())
+		assert.Equal(t, "split.type", e.Type())
+		assert.Equal(t, "split.source", e.Source())
+		assert.Equal(t, "parent-subject", e.Subject())
+
+		ext := e.Extensions()
+		assert.Equal(t, "parent-value", ext["parentext"])
+		assert.Equal(t, "splitter-value", ext["override"])
+		assert.Equal(t, tCloudEventID, ext[extensionSplitID])
+		assert.EqualValues(t, i, ext[extensionSplitIndex])
+		assert.EqualValues(t, 2, ext[extensionSplitCount])
+	}
+}
+
+func TestDeliveryFailures(t *testing.T) {
+	// the sink rejects the first delivery attempt of the event with ID "id-1"
+	var attempts sync.Map
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("ce-id")
+		if n, _ := attempts.LoadOrStore(id, 0); id == "id-1" && n.(int) == 0 {
+			attempts.Store(id, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(sink.Close)
+
+	sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
+	require.NoError(t, err)
+
+	logger := logtesting.TestLogger(t)
+
+	h := &Handler{
+		dispatcher: dispatch.New(sender, logger),
+		logger:     logger,
+	}
+
+	var events []*cloudevents.Event
+	for i := 0; i < 3; i++ {
+		e := newCloudEvent(t, `{}`)
+		e.SetID(fmt.Sprintf("id-%d", i))
+		events = append(events, &e)
+	}
+
+	s := newSplitter(t, tSplitter.key, tSplitter.path, sink.Listener.Addr().String())
+	retries := int32(2)
+	delay := tmapis.Duration(time.Millisecond)
+	s.Spec.Delivery = &v1alpha1.SplitterDelivery{
+		Retries:      &retries,
+		BackoffDelay: &delay,
+	}
+
+	failed := h.deliver(context.Background(), http.Header{}, s.Status.SinkURI.String(), events, nil)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Index)
+	assert.Equal(t, "id-1", failed[0].ID)
+
+	failed = h.retry(context.Background(), http.Header{}, s, events, failed)
+	assert.Empty(t, failed)
+}
+
 func newCloudEvent(t *testing.T, data string) cloudevents.Event {
 	event := cloudevents.NewEvent()
 	event.SetID(tCloudEventID)
@@ -214,11 +306,13 @@ func newHandler(t *testing.T, ctx context.Context, port int) *Handler {
 	sender, err := kncloudevents.NewHTTPMessageSenderWithTarget("")
 	assert.NoError(t, err)
 
+	logger := logtesting.TestLogger(t)
+
 	return &Handler{
 		receiver:       kncloudevents.NewHTTPMessageReceiver(port),
-		sender:         sender,
+		dispatcher:     dispatch.New(sender, logger),
 		splitterLister: fakeinformer.Get(ctx).Lister().Splitters(tNS),
-		logger:         logtesting.TestLogger(t),
+		logger:         logger,
 	}
 }
 
@@ -27,10 +27,17 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// contextVariable is the name of the CEL variable that contains
+// CloudEvent context attributes and extensions.
+const contextVariable = "ce"
+
 var errVarType = errors.New("variable definition doesn't match expected format: \"$json_path.(type)\"")
 
 // CompileExpression accepts the expression string from the Filter spec,
-// parses variables and their types, compiles expression into CEL Program
+// parses variables and their types, compiles expression into CEL Program.
+// Besides the "$json_path.(type)" variables of the event payload, expression
+// can access CloudEvent context attributes and extensions as "ce" map keys,
+// e.g. ce.type or ce.myextension.
 func CompileExpression(expression string) (ConditionalFilter, error) {
 	expr, vars, err := parseExpressionString(expression)
 	if err != nil {
@@ -101,10 +108,12 @@ func parseExpressionString(expression string) (string, []Variable, error) {
 // newCEL creates CEL env, sets its variables, compiles expression string
 // and validates expression result type
 func newCEL(expr string, vars []Variable) (cel.Program, error) {
-	declVars := []*exprpb.Decl{}
+	declVars := []*exprpb.Decl{
+		// CloudEvent context attributes and extensions, e.g. ce.type or ce.traceparent
+		decls.NewVar(contextVariable, decls.NewMapType(decls.String, decls.Dyn)),
+	}
 	for _, variable := range vars {
-		primitiveType := exprpb.Type_PrimitiveType(exprpb.Type_PrimitiveType_value[strings.ToUpper(variable.Type)])
-		declVars = append(declVars, decls.NewVar(variable.Name, decls.NewPrimitiveType(primitiveType)))
+		declVars = append(declVars, decls.NewVar(variable.Name, declType(variable.Type)))
 	}
 
 	env, err := cel.NewEnv(
@@ -125,3 +134,15 @@ func newCEL(expr string, vars []Variable) (cel.Program, error) {
 
 	return env.Program(ast)
 }
+
+// declType returns CEL type declaration of the variable type.
+func declType(typ string) *exprpb.Type {
+	switch typ {
+	case "list":
+		return decls.NewListType(decls.Dyn)
+	case "map":
+		return decls.NewMapType(decls.String, decls.Dyn)
+	}
+	primitiveType := exprpb.Type_PrimitiveType(exprpb.Type_PrimitiveType_value[strings.ToUpper(typ)])
+	return decls.NewPrimitiveType(primitiveType)
+}
@@ -86,6 +86,22 @@ func TestCompileExpression(t *testing.T) {
 		"Valid expression 5": {
 			expression: `true`,
 		},
+		"Context attributes": {
+			expression: `ce.type == "foo" && ce.source.startsWith("bar")`,
+		},
+		"Extension presence": {
+			expression: `has(ce.traceparent)`,
+		},
+		"List macro": {
+			expression: `$items.(list).exists(i, i.price > 10.0)`,
+		},
+		"Map access": {
+			expression: `$user.(map).name.matches("^J.*")`,
+		},
+		"Non-bool context attribute": {
+			expression: `ce.type`,
+			wantError:  true,
+		},
 	}
 
 	for name, tc := range cases {
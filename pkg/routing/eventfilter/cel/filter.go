@@ -18,8 +18,10 @@ package cel
 
 import (
 	"context"
+	"fmt"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
 	"github.com/google/cel-go/cel"
 	"github.com/tidwall/gjson"
 
@@ -42,8 +44,21 @@ type Variable struct {
 }
 
 // Filter parses Event payload values defined as the expression variables, asserts their types,
-// and executes CEL Program. If expression result is true, Event passes the filter.
+// and executes CEL Program. If expression result is true, or if the expression can not be
+// evaluated against the Event, Event passes the filter.
 func (c *ConditionalFilter) Filter(ctx context.Context, event cloudevents.Event) eventfilter.FilterResult {
+	pass, err := c.Eval(ctx, event)
+	if err != nil || pass {
+		return eventfilter.PassFilter
+	}
+
+	return eventfilter.FailFilter
+}
+
+// Eval parses Event payload values defined as the expression variables and executes CEL
+// Program. Unlike Filter, it returns the errors that occurred while evaluating the expression,
+// e.g. when the expression refers to a missing CloudEvent extension.
+func (c *ConditionalFilter) Eval(ctx context.Context, event cloudevents.Event) (bool, error) {
 	vars := make(map[string]interface{})
 
 	for _, v := range c.Variables {
@@ -58,19 +73,84 @@ func (c *ConditionalFilter) Filter(ctx context.Context, event cloudevents.Event)
 			vars[v.Name] = gjson.GetBytes(event.Data(), v.Path).Float()
 		case "string":
 			vars[v.Name] = gjson.GetBytes(event.Data(), v.Path).String()
+		case "list":
+			vars[v.Name] = toList(gjson.GetBytes(event.Data(), v.Path))
+		case "map":
+			vars[v.Name] = toMap(gjson.GetBytes(event.Data(), v.Path))
 		}
 	}
+	vars[contextVariable] = contextAttributes(event)
 
-	pass, err := eval(*c.Expression, vars)
-	if err != nil || pass {
-		return eventfilter.PassFilter
-	}
-
-	return eventfilter.FailFilter
+	return eval(*c.Expression, vars)
 }
 
 // eval evaluates precompiled Expression with passed variables
 func eval(program cel.Program, vars map[string]interface{}) (bool, error) {
 	out, _, err := program.Eval(vars)
-	return out.Value().(bool), err
+	if err != nil {
+		return false, err
+	}
+	pass, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned non-boolean value %v", out.Value())
+	}
+	return pass, nil
+}
+
+// contextAttributes returns the map of CloudEvent context attributes and extensions.
+// Optional attributes are only set if they are not empty.
+func contextAttributes(event cloudevents.Event) map[string]interface{} {
+	attrs := make(map[string]interface{}, len(event.Extensions())+8)
+	for k, v := range event.Extensions() {
+		attrs[k] = extensionValue(v)
+	}
+
+	attrs["specversion"] = event.SpecVersion()
+	attrs["id"] = event.ID()
+	attrs["type"] = event.Type()
+	attrs["source"] = event.Source()
+	if subject := event.Subject(); subject != "" {
+		attrs["subject"] = subject
+	}
+	if dataContentType := event.DataContentType(); dataContentType != "" {
+		attrs["datacontenttype"] = dataContentType
+	}
+	if dataSchema := event.DataSchema(); dataSchema != "" {
+		attrs["dataschema"] = dataSchema
+	}
+	if t := event.Time(); !t.IsZero() {
+		attrs["time"] = t
+	}
+	return attrs
+}
+
+// extensionValue converts CloudEvent extension value into
+// one of the types supported by CEL.
+func extensionValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case string, bool:
+		return value
+	case int32:
+		return int64(value)
+	}
+	if str, err := types.Format(v); err == nil {
+		return str
+	}
+	return fmt.Sprint(v)
+}
+
+// toList returns JSON array as a list, or an empty list for other JSON types.
+func toList(r gjson.Result) []interface{} {
+	if list, ok := r.Value().([]interface{}); ok {
+		return list
+	}
+	return []interface{}{}
+}
+
+// toMap returns JSON object as a map, or an empty map for other JSON types.
+func toMap(r gjson.Result) map[string]interface{} {
+	if m, ok := r.Value().(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
 }
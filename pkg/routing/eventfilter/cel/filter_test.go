@@ -0,0 +1,96 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/triggermesh/triggermesh/pkg/routing/eventfilter"
+)
+
+func TestFilter(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetType("com.example.order")
+	event.SetSource("shop")
+	event.SetExtension("tenant", "acme")
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON,
+		[]byte(`{"items":[{"price":5},{"price":15}],"user":{"name":"John"}}`)))
+
+	cases := map[string]struct {
+		expression string
+		expected   eventfilter.FilterResult
+		expectErr  bool
+	}{
+		"Type match": {
+			expression: `ce.type == "com.example.order"`,
+			expected:   eventfilter.PassFilter,
+		},
+		"Extension match": {
+			expression: `ce.tenant == "acme" && ce.source == "shop"`,
+			expected:   eventfilter.PassFilter,
+		},
+		"Missing extension": {
+			expression: `ce.traceparent == "foo"`,
+			// evaluation errors do not stop events at the filter
+			expected:  eventfilter.PassFilter,
+			expectErr: true,
+		},
+		"Optional extension": {
+			expression: `has(ce.traceparent) && ce.traceparent == "foo"`,
+			expected:   eventfilter.FailFilter,
+		},
+		"List exists": {
+			expression: `$items.(list).exists(i, i.price > 10.0)`,
+			expected:   eventfilter.PassFilter,
+		},
+		"List all": {
+			expression: `$items.(list).all(i, i.price > 10.0)`,
+			expected:   eventfilter.FailFilter,
+		},
+		"Map matches": {
+			expression: `$user.(map).name.matches("^J.*")`,
+			expected:   eventfilter.PassFilter,
+		},
+		"Payload variable": {
+			expression: `$user.name.(string) == "Jane"`,
+			expected:   eventfilter.FailFilter,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := CompileExpression(tc.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, filter.Filter(context.Background(), event))
+
+			pass, err := filter.Eval(context.Background(), event)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.False(t, pass)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected == eventfilter.PassFilter, pass)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+
+	commonv1alpha1 "github.com/triggermesh/triggermesh/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/apis/routing/v1alpha1"
+	reconcilerv1alpha1 "github.com/triggermesh/triggermesh/pkg/client/generated/injection/reconciler/routing/v1alpha1/router"
+	listersv1alpha1 "github.com/triggermesh/triggermesh/pkg/client/generated/listers/routing/v1alpha1"
+	common "github.com/triggermesh/triggermesh/pkg/reconciler"
+)
+
+// Reconciler implements addressableservicereconciler.Interface for
+// AddressableService resources.
+type Reconciler struct {
+	base       common.GenericServiceReconciler[*v1alpha1.Router, listersv1alpha1.RouterNamespaceLister]
+	adapterCfg *adapterConfig
+}
+
+// Check that our Reconciler implements Interface
+var _ reconcilerv1alpha1.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, o *v1alpha1.Router) reconciler.Event {
+	// inject component instance into context for usage in reconciliation logic
+	ctx = commonv1alpha1.WithReconcilable(ctx, o)
+
+	if err := r.resolveRoutes(ctx, o); err != nil {
+		return err
+	}
+
+	return r.base.ReconcileAdapter(ctx, r)
+}
+
+// resolveRoutes resolves the URIs of the routes' destinations and propagates
+// them to the status of the Router.
+func (r *Reconciler) resolveRoutes(ctx context.Context, o *v1alpha1.Router) error {
+	uris := make([]*apis.URL, 0, len(o.Spec.Routes))
+
+	for i := range o.Spec.Routes {
+		dest := *o.Spec.Routes[i].Sink.DeepCopy()
+		if dest.Ref != nil && dest.Ref.Namespace == "" {
+			dest.Ref.Namespace = o.Namespace
+		}
+
+		uri, err := r.base.SinkResolver.URIFromDestinationV1(ctx, dest, o)
+		if err != nil {
+			o.GetStatusManager().MarkNoSink()
+			return controller.NewPermanentError(reconciler.NewEvent(corev1.EventTypeWarning,
+				common.ReasonBadSinkURI, "Could not resolve URI of route %d: %s", i, err))
+		}
+		uris = append(uris, uri)
+	}
+
+	o.Status.RouteURIs = uris
+
+	return nil
+}
@@ -48,6 +48,11 @@ const (
 	logfieldMsgIDs = "msgIDs"
 )
 
+// This event source spends most of its time waiting for the network, so we
+// can run more than one of each receiver|processor|deleter for each
+// available thread.
+const instancesPerProc = 3
+
 // envConfig is a set parameters sourced from the environment for the source's
 // adapter.
 type envConfig struct {
@@ -61,7 +66,7 @@ type envConfig struct {
 	// Name of a message processor which takes care of converting SQS
 	// messages to CloudEvents.
 	//
-	// Supported values: [ default s3 eventbridge ]
+	// Supported values: [ default s3 eventbridge sns cloudevents ]
 	MessageProcessor string `envconfig:"SQS_MESSAGE_PROCESSOR" default:"default"`
 
 	// https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-visibility-timeout.html
@@ -69,6 +74,10 @@ type envConfig struct {
 	// https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-visibility-timeout.html
 	VisibilityTimeout *time.Duration `envconfig:"SQS_VISIBILITY_TIMEOUT"`
 
+	// Number of messages processed concurrently. Defaults to a value
+	// proportional to the number of available CPUs.
+	Concurrency int `envconfig:"SQS_CONCURRENCY"`
+
 	// Allows overriding common CloudEvents attributes.
 	CEOverrideSource string `envconfig:"CE_SOURCE"`
 	CEOverrideType   string `envconfig:"CE_TYPE"`
@@ -96,7 +105,12 @@ type adapter struct {
 
 	visibilityTimeoutSeconds *int64
 
-	processQueue chan *sqs.Message
+	// number of message receivers and deleters
+	receivers int
+	// number of message processors
+	processors int
+
+	processQueue chan messageGroup
 	deleteQueue  chan *sqs.Message
 
 	deletePeriod time.Duration
@@ -134,6 +148,14 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 			ceSource:         env.CEOverrideSource,
 			ceSourceFallback: arn.String(),
 		}
+	case "sns":
+		msgPrcsr = &snsMessageProcessor{
+			ceSourceFallback: arn.String(),
+		}
+	case "cloudevents":
+		msgPrcsr = &cloudEventsMessageProcessor{
+			ceSourceFallback: arn.String(),
+		}
 	case "default":
 		msgPrcsr = &defaultMessageProcessor{
 			ceSource: arn.String(),
@@ -152,6 +174,18 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		}
 	}
 
+	receivers := runtime.GOMAXPROCS(-1) * instancesPerProc
+
+	processors := env.Concurrency
+	if processors <= 0 {
+		processors = receivers
+	}
+	// each receiver feeds processors with batches of messages, enough
+	// receivers must run to keep all processors busy
+	if minReceivers := (processors + maxReceiveMsgBatchSize - 1) / maxReceiveMsgBatchSize; receivers < minReceivers {
+		receivers = minReceivers
+	}
+
 	sess := session.Must(session.NewSession(aws.NewConfig().
 		WithRegion(arn.Region).
 		WithEndpointResolver(common.EndpointResolver(arn.Partition)),
@@ -164,8 +198,8 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 
 	// allocate generous buffer sizes to limit blocking on surges of new
 	// messages coming from receivers
-	const batchSizePerProc = 9
-	queueBufferSizeProcess := maxReceiveMsgBatchSize * runtime.GOMAXPROCS(-1) * batchSizePerProc
+	const batchSizePerReceiver = 3
+	queueBufferSizeProcess := maxReceiveMsgBatchSize * receivers * batchSizePerReceiver
 	queueBufferSizeDelete := queueBufferSizeProcess
 
 	sr := mustNewStatsReporter(mt)
@@ -187,7 +221,10 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 
 		visibilityTimeoutSeconds: visibilityTimeoutSeconds,
 
-		processQueue: make(chan *sqs.Message, queueBufferSizeProcess),
+		receivers:  receivers,
+		processors: processors,
+
+		processQueue: make(chan messageGroup, queueBufferSizeProcess),
 		deleteQueue:  make(chan *sqs.Message, queueBufferSizeDelete),
 
 		deletePeriod: maxDeleteMsgPeriod,
@@ -214,12 +251,7 @@ func (a *adapter) Start(ctx context.Context) error {
 
 	var wg sync.WaitGroup
 
-	// This event source spends most of its time waiting for the network,
-	// so we can run more than one of each receiver|processor|deleter for
-	// each available thread.
-	const instancesPerProc = 3
-
-	for i := 0; i < runtime.GOMAXPROCS(-1)*instancesPerProc; i++ {
+	for i := 0; i < a.receivers; i++ {
 		// TODO(antoineco): spawn and terminate receivers dynamically
 		// based on the current amount of messages being processed to
 		// optimize costs generated by ReceiveMessage API requests.
@@ -233,13 +265,15 @@ func (a *adapter) Start(ctx context.Context) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			a.runMessagesProcessor(msgCtx)
+			a.runMessagesDeleter(msgCtx, queueURL)
 		}()
+	}
 
+	for i := 0; i < a.processors; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			a.runMessagesDeleter(msgCtx, queueURL)
+			a.runMessagesProcessor(msgCtx, queueURL)
 		}()
 	}
 
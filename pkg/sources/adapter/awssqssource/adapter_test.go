@@ -27,6 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -107,7 +109,10 @@ func TestAdapter(t *testing.T) {
 
 				visibilityTimeoutSeconds: aws.Int64(tVisibilityTimeout),
 
-				processQueue: make(chan *sqs.Message, tc.queueBufSize),
+				receivers:  3,
+				processors: 3,
+
+				processQueue: make(chan messageGroup, tc.queueBufSize),
 				deleteQueue:  make(chan *sqs.Message, tc.queueBufSize),
 
 				deletePeriod: 5 * time.Millisecond,
@@ -183,6 +188,132 @@ func TestAdapter(t *testing.T) {
 	}
 }
 
+func TestProcessMessageGroup(t *testing.T) {
+	const failingEventType = "unit.sendFail"
+
+	ceCli := adaptertest.NewTestClient()
+	sqsCli := &standardMockSQSClient{}
+
+	mt := &pkgadapter.MetricTag{}
+
+	a := adapter{
+		logger: loggingtesting.TestLogger(t),
+
+		mt: mt,
+		sr: mustNewStatsReporter(mt),
+
+		sqsClient: sqsCli,
+		ceClient:  ceCli,
+
+		msgPrcsr: eventTypeFromBodyMessageProcessor{},
+
+		processQueue: make(chan messageGroup, 1),
+		deleteQueue:  make(chan *sqs.Message, 3),
+	}
+
+	msgs := makeMockMessages(3)
+	for i, typ := range []string{"unit.type", failingEventType, "unit.type"} {
+		msgs[i].Body = aws.String(typ)
+		msgs[i].Attributes = map[string]*string{
+			sqs.MessageSystemAttributeNameMessageGroupId:          aws.String("group-1"),
+			sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("3"),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.runMessagesProcessor(ctx, tQueueURL)
+	}()
+
+	a.processQueue <- msgs
+
+	var deleted *sqs.Message
+	select {
+	case deleted = <-a.deleteQueue:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for message to be enqueued for deletion")
+	}
+
+	require.Eventually(t, func() bool {
+		return len(sqsCli.visibilityChanges()) > 0
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, msgs[0], deleted, "Only the first message should be deleted")
+	assert.Len(t, ceCli.Sent(), 2, "Messages following a failed message shouldn't be sent")
+
+	changes := sqsCli.visibilityChanges()
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Entries, 2, "The failed message and the ones following it should be delayed")
+	assert.Equal(t, msgs[1].MessageId, changes[0].Entries[0].Id)
+	assert.Equal(t, msgs[2].MessageId, changes[0].Entries[1].Id)
+	assert.EqualValues(t, 4, *changes[0].Entries[0].VisibilityTimeout)
+}
+
+func TestGroupMessages(t *testing.T) {
+	msgs := makeMockMessages(5)
+
+	setGroup := func(msg *sqs.Message, group string) {
+		msg.Attributes = map[string]*string{
+			sqs.MessageSystemAttributeNameMessageGroupId: aws.String(group),
+		}
+	}
+	setGroup(msgs[0], "a")
+	setGroup(msgs[2], "b")
+	setGroup(msgs[3], "a")
+
+	expect := []messageGroup{
+		{msgs[0], msgs[3]},
+		{msgs[1]},
+		{msgs[2]},
+		{msgs[4]},
+	}
+
+	assert.Equal(t, expect, groupMessages(msgs))
+}
+
+func TestRedeliveryDelay(t *testing.T) {
+	testCases := map[string]struct {
+		receiveCount *string
+		expect       time.Duration
+	}{
+		"no receive count": {
+			expect: minRedeliveryDelay,
+		},
+		"first receive": {
+			receiveCount: aws.String("1"),
+			expect:       minRedeliveryDelay,
+		},
+		"fourth receive": {
+			receiveCount: aws.String("4"),
+			expect:       8 * minRedeliveryDelay,
+		},
+		"capped": {
+			receiveCount: aws.String("1000"),
+			expect:       maxRedeliveryDelay,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			msg := &sqs.Message{
+				Attributes: map[string]*string{
+					sqs.MessageSystemAttributeNameApproximateReceiveCount: tc.receiveCount,
+				},
+			}
+
+			assert.Equal(t, tc.expect, redeliveryDelay(msg))
+		})
+	}
+}
+
 func TestProcessRawJSON(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -227,6 +358,195 @@ func TestProcessRawJSON(t *testing.T) {
 	}
 }
 
+func TestProcessSNS(t *testing.T) {
+	const queueARN = "arn:aws:sqs:us-fake-0:123456789012:MyQueue"
+
+	msgPrcsr := &snsMessageProcessor{ceSourceFallback: queueARN}
+
+	t.Run("notification envelope", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body: aws.String(`{"Type":"Notification","MessageId":"sns-msg-id",` +
+				`"TopicArn":"arn:aws:sns:us-fake-0:123456789012:MyTopic","Subject":"greeting",` +
+				`"Message":"{\"hello\":\"world\"}","Timestamp":"2023-03-01T10:00:00.123Z",` +
+				`"MessageAttributes":{"Store.Name":{"Type":"String","Value":"example"},` +
+				`"Logo":{"Type":"Binary","Value":"iVBORw0KGgo="}}}`),
+		}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "com.amazon.sns.notification", e.Type())
+		assert.Equal(t, "arn:aws:sns:us-fake-0:123456789012:MyTopic", e.Source())
+		assert.Equal(t, "sns-msg-id", e.ID())
+		assert.Equal(t, "greeting", e.Subject())
+		assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 123e6, time.UTC), e.Time())
+		assert.Equal(t, map[string]interface{}{"snsmsgstorename": "example"}, e.Extensions())
+		assert.Equal(t, cloudevents.ApplicationJSON, e.DataContentType())
+		assert.JSONEq(t, `{"hello":"world"}`, string(e.Data()))
+	})
+
+	t.Run("raw message delivery", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body:      aws.String("hello world"),
+			Attributes: map[string]*string{
+				sqs.MessageSystemAttributeNameSentTimestamp: aws.String("1677664800000"),
+			},
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"Store.Name": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("example"),
+				},
+			},
+		}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "com.amazon.sns.notification", e.Type())
+		assert.Equal(t, queueARN, e.Source())
+		assert.Equal(t, tMsgIDPrefix+"001", e.ID())
+		assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), e.Time().UTC())
+		assert.Equal(t, map[string]interface{}{"sqsmsgstorename": "example"}, e.Extensions())
+		assert.Equal(t, cloudevents.TextPlain, e.DataContentType())
+		assert.Equal(t, "hello world", string(e.Data()))
+	})
+}
+
+func TestProcessEventBridge(t *testing.T) {
+	const queueARN = "arn:aws:sqs:us-fake-0:123456789012:MyQueue"
+
+	msg := &sqs.Message{
+		MessageId: aws.String(tMsgIDPrefix + "001"),
+		Body: aws.String(`{"version":"0","id":"eb-event-id","detail-type":"EC2 Instance State-change Notification",` +
+			`"source":"aws.ec2","time":"2023-03-01T10:00:00Z",` +
+			`"resources":["arn:aws:ec2:us-fake-0:123456789012:instance/i-1234567890abcdef0"],"detail":{}}`),
+	}
+
+	t.Run("source override", func(t *testing.T) {
+		msgPrcsr := &eventbridgeMessageProcessor{ceSource: "custom.source", ceSourceFallback: queueARN}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "com.amazon.events.event", e.Type())
+		assert.Equal(t, "custom.source", e.Source())
+		assert.Equal(t, "eb-event-id", e.ID())
+		assert.Equal(t, "arn:aws:ec2:us-fake-0:123456789012:instance/i-1234567890abcdef0", e.Subject())
+		assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), e.Time())
+	})
+
+	t.Run("no source override", func(t *testing.T) {
+		msgPrcsr := &eventbridgeMessageProcessor{ceSourceFallback: queueARN}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		assert.Equal(t, queueARN, events[0].Source())
+	})
+}
+
+func TestProcessCloudEvents(t *testing.T) {
+	const queueARN = "arn:aws:sqs:us-fake-0:123456789012:MyQueue"
+
+	msgPrcsr := &cloudEventsMessageProcessor{ceSourceFallback: queueARN}
+
+	strAttr := func(v string) *sqs.MessageAttributeValue {
+		return &sqs.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(v),
+		}
+	}
+
+	t.Run("binary", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body:      aws.String(`{"hello":"world"}`),
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"ce-specversion": strAttr("1.0"),
+				"ce-id":          strAttr("original-id"),
+				"ce-type":        strAttr("com.example.type"),
+				"ce-source":      strAttr("com.example.source"),
+				"ce-subject":     strAttr("some-subject"),
+				"ce-time":        strAttr("2023-03-01T10:00:00Z"),
+				"ce-myext":       strAttr("value"),
+				"content-type":   strAttr(cloudevents.ApplicationJSON),
+			},
+		}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "original-id", e.ID())
+		assert.Equal(t, "com.example.type", e.Type())
+		assert.Equal(t, "com.example.source", e.Source())
+		assert.Equal(t, "some-subject", e.Subject())
+		assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), e.Time())
+		assert.Equal(t, map[string]interface{}{"myext": "value"}, e.Extensions())
+		assert.Equal(t, cloudevents.ApplicationJSON, e.DataContentType())
+		assert.Equal(t, `{"hello":"world"}`, string(e.Data()))
+	})
+
+	t.Run("invalid binary", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body:      aws.String(`{"hello":"world"}`),
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"ce-specversion": strAttr("1.0"),
+				"ce-id":          strAttr("original-id"),
+			},
+		}
+
+		_, err := msgPrcsr.Process(msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("structured", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body: aws.String(`{"specversion":"1.0","id":"original-id","type":"com.example.type",` +
+				`"source":"com.example.source","datacontenttype":"application/json","data":{"hello":"world"}}`),
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"content-type": strAttr(cloudevents.ApplicationCloudEventsJSON),
+			},
+		}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "original-id", e.ID())
+		assert.Equal(t, "com.example.type", e.Type())
+		assert.Equal(t, "com.example.source", e.Source())
+		assert.Equal(t, `{"hello":"world"}`, string(e.Data()))
+	})
+
+	t.Run("not a CloudEvent", func(t *testing.T) {
+		msg := &sqs.Message{
+			MessageId: aws.String(tMsgIDPrefix + "001"),
+			Body:      aws.String("hello"),
+		}
+
+		events, err := msgPrcsr.Process(msg)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		assert.Equal(t, "com.amazon.sqs.message", events[0].Type())
+		assert.Equal(t, queueARN, events[0].Source())
+	})
+}
+
 // stringifyEventData returns the given data as a JSON-encoded string. This
 // helps asserting the value of a SQS messages's Body contained in a
 // CloudEvent, which can be either a JSON object encoded as a
@@ -268,6 +588,9 @@ type standardMockSQSClient struct {
 	totalDeleted int
 
 	rcvMsgRecorder receiveMessageRequestRecorder
+
+	// recorded ChangeMessageVisibilityBatch requests
+	visChanges []*sqs.ChangeMessageVisibilityBatchInput
 }
 
 func (*standardMockSQSClient) GetQueueUrl(*sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) { //nolint:golint,stylecheck
@@ -330,6 +653,39 @@ func (c *standardMockSQSClient) DeleteMessageBatchWithContext(_ context.Context,
 	return &sqs.DeleteMessageBatchOutput{}, nil
 }
 
+func (c *standardMockSQSClient) ChangeMessageVisibilityBatchWithContext(_ context.Context,
+	in *sqs.ChangeMessageVisibilityBatchInput, _ ...request.Option) (*sqs.ChangeMessageVisibilityBatchOutput, error) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.visChanges = append(c.visChanges, in)
+
+	return &sqs.ChangeMessageVisibilityBatchOutput{}, nil
+}
+
+// visibilityChanges returns the recorded ChangeMessageVisibilityBatch requests.
+func (c *standardMockSQSClient) visibilityChanges() []*sqs.ChangeMessageVisibilityBatchInput {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.visChanges
+}
+
+// eventTypeFromBodyMessageProcessor is a MessageProcessor which uses the body
+// of SQS messages as the type of CloudEvents.
+type eventTypeFromBodyMessageProcessor struct{}
+
+// Process implements MessageProcessor.
+func (eventTypeFromBodyMessageProcessor) Process(msg *sqs.Message) ([]*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(*msg.MessageId)
+	event.SetSource("test")
+	event.SetType(*msg.Body)
+
+	return []*cloudevents.Event{&event}, nil
+}
+
 // makeMockMessages returns a set of mocked Messages.
 func makeMockMessages(n int) []*sqs.Message {
 	const receiptHandle = "dHJpZ2dlcm1lc2g="
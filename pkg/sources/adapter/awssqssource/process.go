@@ -20,57 +20,76 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eventbridge"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/sources/v1alpha1"
 )
 
-// A message processor processes SQS messages (sends as CloudEvent)
-// sequentially, as soon as they are written to processQueue.
-func (a *adapter) runMessagesProcessor(ctx context.Context) {
+// A message processor processes groups of SQS messages (sends as CloudEvent)
+// as soon as they are written to processQueue. The messages of a group are
+// processed sequentially, in order.
+func (a *adapter) runMessagesProcessor(ctx context.Context, queueURL string) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 
-		case msg := <-a.processQueue:
-			a.sr.reportMessageDequeuedProcessCount()
-
-			a.logger.Debugw("Processing message", zap.String(logfieldMsgID, *msg.MessageId))
-
-			events, err := a.msgPrcsr.Process(msg)
-			if err != nil {
-				a.logger.Errorw("Failed to process SQS message", zap.Error(err),
-					zap.String(logfieldMsgID, *msg.MessageId))
-				continue
+		case msgs := <-a.processQueue:
+			for range msgs {
+				a.sr.reportMessageDequeuedProcessCount()
 			}
 
-			sendError := false
-			for _, event := range events {
-				if err := sendSQSEvent(ctx, a.ceClient, event); err != nil {
-					a.logger.Errorw("Failed to send event to the sink", zap.Error(err))
-					sendError = true
+			for i, msg := range msgs {
+				if !a.processMessage(ctx, msg) {
+					// Delay the redelivery of the failed message
+					// together with the messages which follow it in
+					// the group, to preserve their ordering.
+					a.delayRedelivery(ctx, queueURL, msgs[i:])
 					break
 				}
 			}
+		}
+	}
+}
 
-			if sendError {
-				continue
-			}
+// processMessage sends the given SQS message as CloudEvent(s) to the sink,
+// and enqueues it for deletion upon success. It returns false if the message
+// couldn't be sent, in which case the message should be redelivered.
+func (a *adapter) processMessage(ctx context.Context, msg *sqs.Message) bool {
+	a.logger.Debugw("Processing message", zap.String(logfieldMsgID, *msg.MessageId))
+
+	events, err := a.msgPrcsr.Process(msg)
+	if err != nil {
+		a.logger.Errorw("Failed to process SQS message", zap.Error(err),
+			zap.String(logfieldMsgID, *msg.MessageId))
+		return true
+	}
 
-			a.deleteQueue <- msg
-			a.sr.reportMessageEnqueuedDeleteCount()
+	for _, event := range events {
+		if err := sendSQSEvent(ctx, a.ceClient, event); err != nil {
+			a.logger.Errorw("Failed to send event to the sink", zap.Error(err),
+				zap.String(logfieldMsgID, *msg.MessageId))
+			return false
 		}
 	}
+
+	a.deleteQueue <- msg
+	a.sr.reportMessageEnqueuedDeleteCount()
+
+	return true
 }
 
 // sendSQSEvent sends a single SQS message as a CloudEvent to the event sink.
@@ -89,6 +108,9 @@ type MessageProcessor interface {
 var (
 	_ MessageProcessor = (*defaultMessageProcessor)(nil)
 	_ MessageProcessor = (*s3MessageProcessor)(nil)
+	_ MessageProcessor = (*eventbridgeMessageProcessor)(nil)
+	_ MessageProcessor = (*snsMessageProcessor)(nil)
+	_ MessageProcessor = (*cloudEventsMessageProcessor)(nil)
 )
 
 // defaultMessageProcessor is the default message processor.
@@ -290,7 +312,7 @@ func isTestEventPayload(data map[string]interface{}) bool {
 // eventbridgeMessageProcessor processes messages originating from EventBridge.
 type eventbridgeMessageProcessor struct {
 	// this value is set as the "source" CE context attribute on messages
-	// that originate from EventBridge
+	// that originate from EventBridge, if not empty
 	ceSource string
 	// this value is set as the "source" CE context attribute when the
 	// EventBridge processor handles messages which are not originating
@@ -323,7 +345,12 @@ func (p *eventbridgeMessageProcessor) Process(msg *sqs.Message) ([]*cloudevents.
 
 	switch {
 	case isEventBridgeEvent(bodyData):
-		event, err := makeEventBridgeEvent(bodyData, p.ceSource)
+		ceSource := p.ceSource
+		if ceSource == "" {
+			ceSource = p.ceSourceFallback
+		}
+
+		event, err := makeEventBridgeEvent(bodyData, ceSource)
 		if err != nil {
 			return nil, fmt.Errorf("creating CloudEvent from EventBridge event: %w", err)
 		}
@@ -374,6 +401,12 @@ func makeEventBridgeEvent(data map[string]interface{}, srcAttr string) (*cloudev
 			event.SetTime(ts)
 		}
 	}
+	// the first resource, if any, is the primary entity the event relates to
+	if resources, ok := data["resources"].([]interface{}); ok && len(resources) > 0 {
+		if res, ok := resources[0].(string); ok {
+			event.SetSubject(res)
+		}
+	}
 
 	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
 		return nil, fmt.Errorf("setting CloudEvent data: %w", err)
@@ -381,3 +414,251 @@ func makeEventBridgeEvent(data map[string]interface{}, srcAttr string) (*cloudev
 
 	return &event, nil
 }
+
+// snsMessageProcessor processes messages originating from SNS topics.
+type snsMessageProcessor struct {
+	// this value is set as the "source" CE context attribute on messages
+	// which were delivered by SNS without their notification envelope (raw
+	// message delivery), since the ARN of the topic isn't known in that case
+	ceSourceFallback string
+}
+
+// Process implements MessageProcessor.
+//
+// This processor unwraps the SNS message contained in the notification
+// envelope of the given message's body. Messages which aren't wrapped in a
+// notification envelope are assumed to have been delivered by a subscription
+// with raw message delivery enabled, in which case the body is the SNS message
+// itself and SNS message attributes are SQS message attributes.
+//
+// Expected envelope structure: https://docs.aws.amazon.com/sns/latest/dg/sns-message-and-json-formats.html#http-notification-json
+// Raw message delivery: https://docs.aws.amazon.com/sns/latest/dg/sns-large-payload-raw-message-delivery.html
+func (p *snsMessageProcessor) Process(msg *sqs.Message) ([]*cloudevents.Event, error) {
+	var notif snsNotification
+
+	if err := json.Unmarshal([]byte(*msg.Body), &notif); err != nil || !notif.isValid() {
+		event, err := makeRawSNSEvent(msg, p.ceSourceFallback)
+		if err != nil {
+			return nil, fmt.Errorf("creating CloudEvent from raw SNS message: %w", err)
+		}
+
+		return []*cloudevents.Event{event}, nil
+	}
+
+	event, err := makeSNSEvent(&notif)
+	if err != nil {
+		return nil, fmt.Errorf("creating CloudEvent from SNS notification: %w", err)
+	}
+
+	return []*cloudevents.Event{event}, nil
+}
+
+// snsNotification is the envelope of messages delivered by SNS.
+type snsNotification struct {
+	Type              string
+	MessageID         string `json:"MessageId"`
+	TopicARN          string `json:"TopicArn"`
+	Subject           string
+	Message           string
+	Timestamp         string
+	MessageAttributes map[string]snsMessageAttribute
+}
+
+// snsMessageAttribute is an attribute of a SNS message.
+type snsMessageAttribute struct {
+	Type  string
+	Value string
+}
+
+// isValid returns whether the notification contains the attributes which
+// identify a SNS notification envelope.
+func (n *snsNotification) isValid() bool {
+	return n.Type == "Notification" && n.MessageID != "" && n.TopicARN != ""
+}
+
+// Prefix of the CloudEvents extension attributes translated from SNS message
+// attributes.
+const ceExtensionSNSMessagePrefix = "snsmsg"
+
+// makeSNSEvent returns a CloudEvent for the given SNS notification.
+func makeSNSEvent(notif *snsNotification) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetType(v1alpha1.AWSEventType(sns.ServiceName, v1alpha1.AWSSNSGenericEventType))
+	event.SetSource(notif.TopicARN)
+	event.SetID(notif.MessageID)
+	event.SetSubject(notif.Subject)
+
+	if ts, err := time.Parse(time.RFC3339, notif.Timestamp); err == nil {
+		event.SetTime(ts)
+	}
+
+	for name, attr := range notif.MessageAttributes {
+		if !strings.HasPrefix(attr.Type, sqsMgsAttrDataTypeBinary) {
+			event.SetExtension(ceExtensionSNSMessagePrefix+stripNonAlphanumCharsAndMapToLower(name), attr.Value)
+		}
+	}
+
+	if err := setMessageData(&event, []byte(notif.Message)); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// makeRawSNSEvent returns a CloudEvent for a SNS message which was delivered
+// without its notification envelope.
+func makeRawSNSEvent(msg *sqs.Message, srcAttr string) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetType(v1alpha1.AWSEventType(sns.ServiceName, v1alpha1.AWSSNSGenericEventType))
+	event.SetSource(srcAttr)
+	event.SetID(*msg.MessageId)
+
+	if ts, ok := sentTimestamp(msg); ok {
+		event.SetTime(ts)
+	}
+
+	for name, val := range ceExtensionAttrsForMessage(msg) {
+		event.SetExtension(name, val)
+	}
+
+	if err := setMessageData(&event, []byte(*msg.Body)); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// setMessageData sets the given message payload as the data of the event,
+// as JSON if the payload is valid JSON, or as plain text otherwise.
+func setMessageData(event *cloudevents.Event, payload []byte) error {
+	var err error
+	if json.Valid(payload) {
+		err = event.SetData(cloudevents.ApplicationJSON, json.RawMessage(payload))
+	} else {
+		err = event.SetData(cloudevents.TextPlain, string(payload))
+	}
+
+	if err != nil {
+		return fmt.Errorf("setting CloudEvent data: %w", err)
+	}
+	return nil
+}
+
+// sentTimestamp returns the time at which the given message was sent to the
+// queue, if this attribute was received.
+func sentTimestamp(msg *sqs.Message) (time.Time, bool) {
+	ms, err := strconv.ParseInt(aws.StringValue(msg.Attributes[sqs.MessageSystemAttributeNameSentTimestamp]), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// cloudEventsMessageProcessor processes messages which represent CloudEvents.
+type cloudEventsMessageProcessor struct {
+	// this value is set as the "source" CE context attribute when the
+	// CloudEvents processor handles messages which are not CloudEvents
+	ceSourceFallback string
+}
+
+// Process implements MessageProcessor.
+//
+// This processor reads the context attributes of the event from the
+// 'ce-' prefixed message attributes of the given message, and its data from
+// the message's body, similarly to the binary content mode of the
+// CloudEvents HTTP binding. The content type of the data is read from the
+// 'content-type' message attribute. When this content type is
+// 'application/cloudevents+json', the message's body is decoded as a
+// structured event instead.
+//
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+func (p *cloudEventsMessageProcessor) Process(msg *sqs.Message) ([]*cloudevents.Event, error) {
+	contentType := messageAttributeValue(msg, ceAttrContentType)
+
+	switch {
+	case strings.HasPrefix(contentType, cloudevents.ApplicationCloudEventsJSON):
+		event := cloudevents.NewEvent()
+		if err := json.Unmarshal([]byte(*msg.Body), &event); err != nil {
+			return nil, fmt.Errorf("decoding structured CloudEvent: %w", err)
+		}
+		return []*cloudevents.Event{&event}, nil
+
+	case messageAttributeValue(msg, ceAttrs.PrefixedSpecVersionName()) != "":
+		event, err := makeBinaryCloudEvent(msg, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("decoding binary CloudEvent: %w", err)
+		}
+		return []*cloudevents.Event{event}, nil
+	}
+
+	// instead of discarding messages which aren't CloudEvents, fall back
+	// to the default processor's behaviour
+	event, err := makeSQSEvent(msg, p.ceSourceFallback)
+	if err != nil {
+		return nil, fmt.Errorf("creating CloudEvent from SQS message: %w", err)
+	}
+
+	return []*cloudevents.Event{event}, nil
+}
+
+const (
+	// prefix of message attributes carrying CloudEvent context attributes
+	ceAttrPrefix = "ce-"
+	// message attribute carrying the content type of the message's body
+	ceAttrContentType = "content-type"
+)
+
+// ceAttrs contains the CloudEvent context attributes mapped to 'ce-' prefixed
+// message attributes.
+var ceAttrs = spec.WithPrefix(ceAttrPrefix)
+
+// makeBinaryCloudEvent returns the CloudEvent represented by the message
+// attributes and body of the given message.
+func makeBinaryCloudEvent(msg *sqs.Message, contentType string) (*cloudevents.Event, error) {
+	specVersion := messageAttributeValue(msg, ceAttrs.PrefixedSpecVersionName())
+
+	version := ceAttrs.Version(specVersion)
+	if version == nil {
+		return nil, fmt.Errorf("unsupported CloudEvents spec version %q", specVersion)
+	}
+
+	event := cloudevents.NewEvent(version.String())
+
+	for name, attrVal := range msg.MessageAttributes {
+		name = strings.ToLower(name)
+		if !strings.HasPrefix(name, ceAttrPrefix) || name == ceAttrs.PrefixedSpecVersionName() {
+			continue
+		}
+		if attrVal.StringValue == nil {
+			continue
+		}
+		if err := version.SetAttribute(event.Context, name, *attrVal.StringValue); err != nil {
+			return nil, fmt.Errorf("setting attribute from message attribute %q: %w", name, err)
+		}
+	}
+
+	if contentType != "" {
+		event.SetDataContentType(contentType)
+	}
+	if msg.Body != nil {
+		event.DataEncoded = []byte(*msg.Body)
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// messageAttributeValue returns the string value of the message attribute
+// with the given name, matched case-insensitively, or an empty string if the
+// message doesn't have such attribute.
+func messageAttributeValue(msg *sqs.Message, name string) string {
+	for n, attrVal := range msg.MessageAttributes {
+		if strings.EqualFold(n, name) {
+			return aws.StringValue(attrVal.StringValue)
+		}
+	}
+	return ""
+}
@@ -71,9 +71,11 @@ func (a *adapter) runMessagesReceiver(ctx context.Context, queueURL string) {
 					zap.Array(logfieldMsgID, messageList(messages)))
 			}
 
-			for _, msg := range messages {
-				a.processQueue <- msg
-				a.sr.reportMessageEnqueuedProcessCount()
+			for _, grp := range groupMessages(messages) {
+				a.processQueue <- grp
+				for range grp {
+					a.sr.reportMessageEnqueuedProcessCount()
+				}
 			}
 
 			t.Reset(nextRequestDelay)
@@ -81,6 +83,41 @@ func (a *adapter) runMessagesReceiver(ctx context.Context, queueURL string) {
 	}
 }
 
+// messageGroup is a list of messages which must be processed sequentially,
+// in order.
+type messageGroup []*sqs.Message
+
+// groupMessages splits the given batch of received messages into groups that
+// can be processed concurrently. Messages which belong to the same message
+// group of a FIFO queue are grouped together, in the order they were
+// received, while all other messages are returned in their own group.
+//
+// Because a FIFO queue doesn't return messages of a message group while other
+// messages of that group are in flight, the messages of a given message group
+// are never spread across multiple batches being processed concurrently.
+func groupMessages(msgs []*sqs.Message) []messageGroup {
+	groups := make([]messageGroup, 0, len(msgs))
+	groupIdx := make(map[ /*MessageGroupId*/ string]int)
+
+	for _, msg := range msgs {
+		groupID := aws.StringValue(msg.Attributes[sqs.MessageSystemAttributeNameMessageGroupId])
+		if groupID == "" {
+			groups = append(groups, messageGroup{msg})
+			continue
+		}
+
+		if i, ok := groupIdx[groupID]; ok {
+			groups[i] = append(groups[i], msg)
+			continue
+		}
+
+		groupIdx[groupID] = len(groups)
+		groups = append(groups, messageGroup{msg})
+	}
+
+	return groups
+}
+
 type messageList []*sqs.Message
 
 var _ zapcore.ArrayMarshaler = (messageList)(nil)
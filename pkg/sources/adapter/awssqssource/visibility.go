@@ -0,0 +1,118 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awssqssource
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+const (
+	// Bounds of the delay before a message which failed to be sent to the
+	// sink is redelivered. The delay doubles each time the message is
+	// received.
+	minRedeliveryDelay = 1 * time.Second
+	maxRedeliveryDelay = 15 * time.Minute
+
+	// Calls to ChangeMessageVisibilityBatch are cancelled when they exceed
+	// this duration.
+	changeVisibilityRequestTimeout = 10 * time.Second
+)
+
+// delayRedelivery changes the visibility timeout of the given messages so
+// that SQS redelivers them after a delay which grows exponentially with the
+// number of times the first message was received, instead of waiting for the
+// whole visibility timeout to expire.
+func (a *adapter) delayRedelivery(ctx context.Context, queueURL string, msgs messageGroup) {
+	// the source is shutting down, messages are redelivered once their
+	// visibility timeout expires
+	if ctx.Err() != nil {
+		return
+	}
+
+	delay := redeliveryDelay(msgs[0])
+
+	a.logger.Debugw("Delaying redelivery of messages", zap.Array(logfieldMsgIDs, messageList(msgs)),
+		zap.Duration("delay", delay))
+
+	if err := changeMessagesVisibility(ctx, a.sqsClient, queueURL, msgs, durationInSeconds(delay)); err != nil {
+		// NOTE: If the visibility change fails, SQS redelivers those
+		// messages after the original visibility timeout has expired.
+		a.logger.Errorw("Failed to change the visibility of messages", zap.Error(err),
+			zap.Array(logfieldMsgIDs, messageList(msgs)))
+	}
+}
+
+// redeliveryDelay returns the delay before the given message is redelivered,
+// based on the number of times it was received.
+func redeliveryDelay(msg *sqs.Message) time.Duration {
+	receiveCount, err := strconv.Atoi(aws.StringValue(
+		msg.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]))
+	if err != nil || receiveCount < 1 {
+		receiveCount = 1
+	}
+
+	delay := minRedeliveryDelay
+	for i := 1; i < receiveCount && delay < maxRedeliveryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRedeliveryDelay {
+		delay = maxRedeliveryDelay
+	}
+
+	return delay
+}
+
+// changeMessagesVisibility sets the visibility timeout of the given messages.
+func changeMessagesVisibility(ctx context.Context, cli sqsiface.SQSAPI, queueURL string,
+	msgs []*sqs.Message, visibilityTimeoutSeconds int64) error {
+
+	entries := make([]*sqs.ChangeMessageVisibilityBatchRequestEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		entries = append(entries, &sqs.ChangeMessageVisibilityBatchRequestEntry{
+			Id:                msg.MessageId,
+			ReceiptHandle:     msg.ReceiptHandle,
+			VisibilityTimeout: &visibilityTimeoutSeconds,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, changeVisibilityRequestTimeout)
+	defer cancel()
+
+	in := &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: &queueURL,
+		Entries:  entries,
+	}
+
+	out, err := cli.ChangeMessageVisibilityBatchWithContext(ctx, in)
+	if err != nil {
+		return err
+	}
+	if len(out.Failed) > 0 {
+		return errors.New(prettifyBatchResultErrors(out.Failed))
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ import (
 
 	"github.com/triggermesh/triggermesh/pkg/adapter/fs"
 	"github.com/triggermesh/triggermesh/pkg/apis/sources"
-	"github.com/triggermesh/triggermesh/pkg/sources/adapter/cloudeventssource/ratelimiter"
+	"github.com/triggermesh/triggermesh/pkg/sources/adapter/common/ratelimiter"
 )
 
 // NewAdapter satisfies pkgadapter.AdapterConstructor.
@@ -22,6 +22,8 @@ import (
 	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"regexp"
 	"time"
 
 	"go.uber.org/zap"
@@ -55,8 +57,30 @@ type kafkasourceAdapter struct {
 	logger   *zap.SugaredLogger
 	mt       *pkgadapter.MetricTag
 
+	client      sarama.Client
 	kafkaClient sarama.ConsumerGroup
-	topic       string
+	groupID     string
+
+	topics               []string
+	topicPattern         *regexp.Regexp
+	topicRefreshInterval time.Duration
+
+	// initialTimestamp is the time from which records are consumed in
+	// partitions without committed offset, if set.
+	initialTimestamp *time.Time
+	// admin lists committed offsets when an initial timestamp is set.
+	admin sarama.ClusterAdmin
+
+	maxInFlight int
+
+	retries      int
+	backoffDelay time.Duration
+	// dlqProducer produces records to the dead-letter topic, if set.
+	dlqProducer sarama.SyncProducer
+	dlqTopic    string
+
+	eventType   string
+	eventSource string
 }
 
 // NewAdapter satisfies pkgadapter.AdapterConstructor.
@@ -132,21 +156,90 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		config.Net.SASL.GSSAPI = kerberosConfig
 	}
 
+	initialTimestamp, err := setInitialOffset(config, env.InitialOffset)
+	if err != nil {
+		logger.Panicw("Invalid initial offset", zap.Error(err))
+	}
+
+	var topicPattern *regexp.Regexp
+	if env.TopicPattern != "" {
+		if topicPattern, err = regexp.Compile(env.TopicPattern); err != nil {
+			logger.Panicw("Invalid topic pattern", zap.Error(err))
+		}
+	}
+
+	var topics []string
+	for _, t := range append([]string{env.Topic}, env.Topics...) {
+		if t != "" {
+			topics = append(topics, t)
+		}
+	}
+	if len(topics) == 0 && topicPattern == nil {
+		logger.Panic("At least one topic or a topic pattern must be provided")
+	}
+
+	maxInFlight := env.MaxInFlight
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	// required by the dead-letter topic producer
+	config.Producer.Return.Successes = true
+
 	err = config.Validate()
 	if err != nil {
 		logger.Panicw("Config not valid", zap.Error(err))
 	}
 
-	kc, err := sarama.NewConsumerGroup(
-		env.BootstrapServers,
-		env.GroupID, config)
+	client, err := sarama.NewClient(env.BootstrapServers, config)
+	if err != nil {
+		logger.Panicw("Error creating Kafka client", zap.Error(err))
+	}
+
+	kc, err := sarama.NewConsumerGroupFromClient(env.GroupID, client)
 	if err != nil {
 		logger.Panicw("Error creating Kafka Consumer Group", zap.Error(err))
 	}
 
+	var dlqProducer sarama.SyncProducer
+	if env.DeadLetterTopic != "" {
+		if dlqProducer, err = sarama.NewSyncProducerFromClient(client); err != nil {
+			logger.Panicw("Error creating Kafka producer for the dead-letter topic", zap.Error(err))
+		}
+	}
+
+	var admin sarama.ClusterAdmin
+	if initialTimestamp != nil {
+		if admin, err = sarama.NewClusterAdminFromClient(client); err != nil {
+			logger.Panicw("Error creating Kafka cluster admin", zap.Error(err))
+		}
+	}
+
+	eventType := defaultEventType
+	if env.EventType != "" {
+		eventType = env.EventType
+	}
+
 	return &kafkasourceAdapter{
+		client:      client,
 		kafkaClient: kc,
-		topic:       env.Topic,
+		groupID:     env.GroupID,
+
+		topics:               topics,
+		topicPattern:         topicPattern,
+		topicRefreshInterval: env.TopicRefreshInterval,
+
+		initialTimestamp: initialTimestamp,
+		admin:            admin,
+		maxInFlight:      maxInFlight,
+
+		retries:      env.DeliveryRetries,
+		backoffDelay: env.DeliveryBackoffDelay,
+		dlqProducer:  dlqProducer,
+		dlqTopic:     env.DeadLetterTopic,
+
+		eventType:   eventType,
+		eventSource: env.EventSource,
 
 		ceClient: ceClient,
 		logger:   logger,
@@ -157,10 +250,6 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 func (a *kafkasourceAdapter) Start(ctx context.Context) error {
 	a.logger.Info("Starting Kafka Source Adapter")
 
-	consumerGroup := consumerGroupHandler{
-		adapter: a,
-	}
-
 	errorList := NewStaleList(errorAccumulationTolerance)
 
 	// while the context is not done, run the loop.
@@ -168,7 +257,7 @@ func (a *kafkasourceAdapter) Start(ctx context.Context) error {
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := a.kafkaClient.Consume(ctx, []string{a.topic}, consumerGroup); err != nil {
+		if err := a.consume(ctx); err != nil {
 			a.logger.Error("Error setting up the consumer client", zap.Error(err))
 
 			// Safety net mechanism, we try to re-consume and avoid exiting the adapter.
@@ -193,6 +282,47 @@ func (a *kafkasourceAdapter) Start(ctx context.Context) error {
 	return nil
 }
 
+// consume runs a consumer group session on the subscribed topics. When a
+// topic pattern is set, the session is ended whenever the set of matching
+// topics changes, so that the caller can subscribe again. The session is
+// also ended when a record can neither be delivered nor dead-lettered, so
+// that it gets consumed again from the last committed offset.
+func (a *kafkasourceAdapter) consume(ctx context.Context) error {
+	topics, err := a.subscribedTopics()
+	if err != nil {
+		return err
+	}
+
+	if len(topics) == 0 {
+		if a.topicPattern == nil {
+			return errors.New("no topic to consume from, at least one topic or a topic pattern must be provided")
+		}
+
+		a.logger.Infow("No topic matches the topic pattern, waiting for topics to be created",
+			zap.String("pattern", a.topicPattern.String()))
+
+		select {
+		case <-time.After(a.topicRefreshInterval):
+		case <-ctx.Done():
+		}
+		return nil
+	}
+
+	consumeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if a.topicPattern != nil {
+		go a.watchTopics(consumeCtx, cancel, topics)
+	}
+
+	handler := consumerGroupHandler{
+		adapter: a,
+		restart: cancel,
+	}
+
+	return a.kafkaClient.Consume(consumeCtx, topics, handler)
+}
+
 func addCAConfig(tlsConfig *tls.Config, caCert string) {
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM([]byte(caCert))
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/sources/adapter/common"
+)
+
+// Headers added to records produced to the dead-letter topic.
+const (
+	dlqHeaderError     = "triggermesh-delivery-error"
+	dlqHeaderTopic     = "triggermesh-original-topic"
+	dlqHeaderPartition = "triggermesh-original-partition"
+	dlqHeaderOffset    = "triggermesh-original-offset"
+)
+
+// maxDeliveryBackoff is the upper bound of the delay between retries.
+const maxDeliveryBackoff = 5 * time.Minute
+
+// deliver sends the event represented by the given record to the sink,
+// retrying with an exponential backoff in case of failure. Records which
+// can't be delivered are produced to the dead-letter topic if one is set, or
+// dropped otherwise.
+//
+// It returns false if the record could neither be delivered nor
+// dead-lettered, in which case it must be consumed again.
+func (a *kafkasourceAdapter) deliver(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	logger := a.logger.With(
+		zap.String("topic", msg.Topic),
+		zap.Int32("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+	)
+
+	event, err := a.toEvent(msg)
+	if err != nil {
+		// retrying wouldn't change the outcome of the conversion
+		logger.Errorw("Failed to create event from record", zap.Error(err))
+		return a.deadLetter(ctx, logger, msg, err)
+	}
+
+	maxBackoff := maxDeliveryBackoff
+	if a.backoffDelay > maxBackoff {
+		maxBackoff = a.backoffDelay
+	}
+	backoff := common.NewBackoff(a.backoffDelay, maxBackoff)
+
+	for attempt := 0; ; attempt++ {
+		result := a.ceClient.Send(ctx, *event)
+		if cloudevents.IsACK(result) {
+			return true
+		}
+		err = result
+
+		if ctx.Err() != nil {
+			return false
+		}
+		if attempt >= a.retries {
+			break
+		}
+
+		delay := backoff.Duration()
+		logger.Warnw("Failed to deliver event, retrying", zap.Error(err), zap.Duration("delay", delay))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	logger.Errorw("Failed to deliver event", zap.Error(err), zap.Int("retries", a.retries))
+	return a.deadLetter(ctx, logger, msg, err)
+}
+
+// deadLetter produces the given record to the dead-letter topic, along with
+// headers describing the delivery error and the origin of the record. The
+// record is dropped if no dead-letter topic is set.
+//
+// It returns false if the record couldn't be produced.
+func (a *kafkasourceAdapter) deadLetter(ctx context.Context, logger *zap.SugaredLogger,
+	msg *sarama.ConsumerMessage, deliveryErr error) bool {
+
+	if a.dlqProducer == nil {
+		logger.Warn("Dropping record which failed to be delivered")
+		return true
+	}
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if _, _, err := a.dlqProducer.SendMessage(deadLetterMessage(a.dlqTopic, msg, deliveryErr)); err != nil {
+		logger.Errorw("Failed to produce record to the dead-letter topic",
+			zap.String("deadLetterTopic", a.dlqTopic), zap.Error(err))
+		return false
+	}
+
+	logger.Infow("Produced record to the dead-letter topic", zap.String("deadLetterTopic", a.dlqTopic))
+	return true
+}
+
+// deadLetterMessage returns a copy of the given record destined to the given
+// dead-letter topic.
+func deadLetterMessage(topic string, msg *sarama.ConsumerMessage, deliveryErr error) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+4)
+	for _, h := range msg.Headers {
+		if h != nil {
+			headers = append(headers, *h)
+		}
+	}
+
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(dlqHeaderError), Value: []byte(deliveryErr.Error())},
+		sarama.RecordHeader{Key: []byte(dlqHeaderTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(dlqHeaderPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(dlqHeaderOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+
+	pm := &sarama.ProducerMessage{
+		Topic:   topic,
+		Headers: headers,
+	}
+	if msg.Key != nil {
+		pm.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		pm.Value = sarama.ByteEncoder(msg.Value)
+	}
+	if !msg.Timestamp.IsZero() {
+		pm.Timestamp = msg.Timestamp
+	}
+
+	return pm
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+	loggingtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestDeliver(t *testing.T) {
+	const (
+		// event type which the test client fails to send
+		failingEventType = "unit.wantErr"
+
+		dlqTopic = "dead-letters"
+		retries  = 2
+	)
+
+	msg := &sarama.ConsumerMessage{
+		Topic:     "orders",
+		Partition: 1,
+		Offset:    7,
+		Key:       []byte("customer-1"),
+		Value:     []byte(`{"order":1}`),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("trace-id"), Value: []byte("abc")},
+		},
+	}
+
+	testCases := map[string]struct {
+		eventType   string
+		dlq         func(*mocks.SyncProducer)
+		expectOK    bool
+		expectSends int
+	}{
+		"delivered": {
+			eventType:   defaultEventType,
+			expectOK:    true,
+			expectSends: 1,
+		},
+		"dropped without dead-letter topic": {
+			eventType:   failingEventType,
+			expectOK:    true,
+			expectSends: retries + 1,
+		},
+		"dead-lettered": {
+			eventType: failingEventType,
+			dlq: func(p *mocks.SyncProducer) {
+				p.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(pm *sarama.ProducerMessage) error {
+					assert.Equal(t, dlqTopic, pm.Topic)
+					assert.Equal(t, sarama.ByteEncoder(msg.Key), pm.Key)
+					assert.Equal(t, sarama.ByteEncoder(msg.Value), pm.Value)
+					assert.Equal(t, []sarama.RecordHeader{
+						{Key: []byte("trace-id"), Value: []byte("abc")},
+						{Key: []byte(dlqHeaderError), Value: []byte("totally not an http result")},
+						{Key: []byte(dlqHeaderTopic), Value: []byte("orders")},
+						{Key: []byte(dlqHeaderPartition), Value: []byte("1")},
+						{Key: []byte(dlqHeaderOffset), Value: []byte("7")},
+					}, pm.Headers)
+					return nil
+				})
+			},
+			expectOK:    true,
+			expectSends: retries + 1,
+		},
+		"dead-letter topic unavailable": {
+			eventType: failingEventType,
+			dlq: func(p *mocks.SyncProducer) {
+				p.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+			},
+			expectOK:    false,
+			expectSends: retries + 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			ceClient := adaptertest.NewTestClient()
+
+			a := &kafkasourceAdapter{
+				ceClient:     ceClient,
+				logger:       loggingtesting.TestLogger(t),
+				eventType:    tc.eventType,
+				retries:      retries,
+				backoffDelay: time.Millisecond,
+			}
+
+			if tc.dlq != nil {
+				p := mocks.NewSyncProducer(t, nil)
+				defer func() { assert.NoError(t, p.Close()) }()
+				tc.dlq(p)
+
+				a.dlqProducer = p
+				a.dlqTopic = dlqTopic
+			}
+
+			ok := a.deliver(context.Background(), msg)
+
+			assert.Equal(t, tc.expectOK, ok)
+			assert.Len(t, ceClient.Sent(), tc.expectSends)
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package kafkasource
 
 import (
+	"time"
+
 	"knative.dev/eventing/pkg/adapter/v2"
 )
 
@@ -34,9 +36,34 @@ type envAccessor struct {
 	BootstrapServers []string `envconfig:"BOOTSTRAP_SERVERS" required:"true"`
 	Username         string   `envconfig:"USERNAME" required:"false"`
 	Password         string   `envconfig:"PASSWORD" required:"false"`
-	Topic            string   `envconfig:"TOPIC" required:"true"`
+	Topic            string   `envconfig:"TOPIC" required:"false"`
 	GroupID          string   `envconfig:"GROUP_ID" required:"false"`
 
+	// Additional topics, listed or matched by a regular expression.
+	Topics       []string `envconfig:"TOPICS" required:"false"`
+	TopicPattern string   `envconfig:"TOPIC_PATTERN" required:"false"`
+
+	// Position from which records are consumed in partitions without
+	// committed offset (earliest|latest|<RFC 3339 timestamp>).
+	InitialOffset string `envconfig:"INITIAL_OFFSET" default:"latest"`
+
+	// Maximum number of records of a single partition delivered concurrently.
+	MaxInFlight int `envconfig:"MAX_IN_FLIGHT" default:"1"`
+
+	// Delivery policy of records which fail to be delivered to the sink.
+	DeliveryRetries      int           `envconfig:"DELIVERY_RETRIES" default:"3"`
+	DeliveryBackoffDelay time.Duration `envconfig:"DELIVERY_BACKOFF_DELAY" default:"1s"`
+	DeadLetterTopic      string        `envconfig:"DEAD_LETTER_TOPIC" required:"false"`
+
+	// This variable is experimental and not graduated to the CRD.
+	// Interval at which topics matching the pattern are discovered.
+	TopicRefreshInterval time.Duration `envconfig:"TOPIC_REFRESH_INTERVAL" default:"1m"`
+
+	// CloudEvent attributes overrides for records which do not use the
+	// CloudEvents Kafka binding.
+	EventType   string `envconfig:"EVENT_TYPE" required:"false"`
+	EventSource string `envconfig:"EVENT_SOURCE" required:"false"`
+
 	SecurityMechanisms  string `envconfig:"SECURITY_MECHANISMS" required:"false"`
 	KerberosConfigPath  string `envconfig:"KERBEROS_CONFIG_PATH" required:"false" `
 	KerberosServiceName string `envconfig:"KERBEROS_SERVICE_NAME" required:"false" `
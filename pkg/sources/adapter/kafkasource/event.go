@@ -0,0 +1,194 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shopify/sarama"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+)
+
+const (
+	// prefix of record headers carrying CloudEvent attributes in binary content mode.
+	ceHeaderPrefix = "ce_"
+	// header carrying the content type of the record value.
+	contentTypeHeader = "content-type"
+
+	// prefix of the extensions carrying the headers of records.
+	headerExtensionPrefix = "kafkaheader"
+)
+
+// Extensions carrying the metadata of records.
+const (
+	extTopic     = "kafkatopic"
+	extPartition = "kafkapartition"
+	extOffset    = "kafkaoffset"
+	extTimestamp = "kafkatimestamp"
+)
+
+// ceHeaders contains the CloudEvent attributes mapped to 'ce_' prefixed headers.
+var ceHeaders = spec.WithPrefix(ceHeaderPrefix)
+
+// toEvent returns the CloudEvent represented by the given record.
+//
+// Records which use the CloudEvents Kafka binding, in either binary or
+// structured content mode, are decoded as the original event. Other records
+// are wrapped in a new event which carries the metadata of the record as
+// extensions.
+func (a *kafkasourceAdapter) toEvent(msg *sarama.ConsumerMessage) (*cloudevents.Event, error) {
+	contentType := headerValue(msg, contentTypeHeader)
+
+	switch {
+	case strings.HasPrefix(contentType, cloudevents.ApplicationCloudEventsJSON):
+		return decodeStructured(msg)
+	case headerValue(msg, ceHeaders.PrefixedSpecVersionName()) != "":
+		return decodeBinary(msg, contentType)
+	}
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetType(a.eventType)
+	event.SetID(recordID(msg))
+
+	source := a.eventSource
+	if source == "" {
+		source = msg.Topic
+	}
+	event.SetSource(source)
+
+	if len(msg.Key) > 0 && utf8.Valid(msg.Key) {
+		event.SetSubject(string(msg.Key))
+	}
+	if !msg.Timestamp.IsZero() {
+		event.SetTime(msg.Timestamp)
+		event.SetExtension(extTimestamp, msg.Timestamp)
+	}
+
+	event.SetExtension(extTopic, msg.Topic)
+	event.SetExtension(extPartition, msg.Partition)
+	// offsets are 64-bit integers, which are not a valid CloudEvents type
+	event.SetExtension(extOffset, strconv.FormatInt(msg.Offset, 10))
+
+	for _, h := range msg.Headers {
+		if h == nil || !utf8.Valid(h.Value) {
+			continue
+		}
+		name := sanitizeExtensionName(string(h.Key))
+		if name == "" {
+			continue
+		}
+		event.SetExtension(headerExtensionPrefix+name, string(h.Value))
+	}
+
+	if contentType == "" {
+		contentType = cloudevents.ApplicationJSON
+		if !json.Valid(msg.Value) {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	if err := event.SetData(contentType, msg.Value); err != nil {
+		return nil, fmt.Errorf("setting event data: %w", err)
+	}
+
+	return &event, nil
+}
+
+// decodeStructured decodes a record in the structured content mode of the
+// CloudEvents Kafka binding.
+func decodeStructured(msg *sarama.ConsumerMessage) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		return nil, fmt.Errorf("decoding structured CloudEvent: %w", err)
+	}
+	return &event, nil
+}
+
+// decodeBinary decodes a record in the binary content mode of the
+// CloudEvents Kafka binding.
+func decodeBinary(msg *sarama.ConsumerMessage, contentType string) (*cloudevents.Event, error) {
+	specVersion := headerValue(msg, ceHeaders.PrefixedSpecVersionName())
+
+	version := ceHeaders.Version(specVersion)
+	if version == nil {
+		return nil, fmt.Errorf("unsupported CloudEvents spec version %q", specVersion)
+	}
+
+	event := cloudevents.NewEvent(version.String())
+
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+		name := strings.ToLower(string(h.Key))
+		if !strings.HasPrefix(name, ceHeaderPrefix) || name == ceHeaders.PrefixedSpecVersionName() {
+			continue
+		}
+		if err := version.SetAttribute(event.Context, name, string(h.Value)); err != nil {
+			return nil, fmt.Errorf("setting attribute from header %q: %w", h.Key, err)
+		}
+	}
+
+	if contentType != "" {
+		event.SetDataContentType(contentType)
+	}
+	event.DataEncoded = msg.Value
+
+	if err := event.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid binary CloudEvent: %w", err)
+	}
+
+	return &event, nil
+}
+
+// recordID returns a deterministic identifier for the given record, which is
+// unique within a Kafka cluster.
+func recordID(msg *sarama.ConsumerMessage) string {
+	return msg.Topic + "-" + strconv.FormatInt(int64(msg.Partition), 10) + "-" + strconv.FormatInt(msg.Offset, 10)
+}
+
+// headerValue returns the value of the record header with the given name, or
+// an empty string if the record doesn't have such header.
+func headerValue(msg *sarama.ConsumerMessage, name string) string {
+	for _, h := range msg.Headers {
+		if h != nil && strings.EqualFold(string(h.Key), name) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// sanitizeExtensionName returns a valid CloudEvents extension name from the
+// given string, by lowercasing it and stripping invalid characters.
+func sanitizeExtensionName(name string) string {
+	name = strings.ToLower(name)
+
+	var b strings.Builder
+	for i := range name {
+		if (name[i] >= 'a' && name[i] <= 'z') || (name[i] >= '0' && name[i] <= '9') {
+			b.WriteByte(name[i])
+		}
+	}
+
+	return b.String()
+}
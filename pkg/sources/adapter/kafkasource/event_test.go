@@ -0,0 +1,150 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+func TestToEvent(t *testing.T) {
+	tstamp := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	a := &kafkasourceAdapter{
+		eventType: defaultEventType,
+	}
+
+	t.Run("plain record", func(t *testing.T) {
+		msg := &sarama.ConsumerMessage{
+			Topic:     "orders",
+			Partition: 3,
+			Offset:    42,
+			Timestamp: tstamp,
+			Key:       []byte("customer-1"),
+			Value:     []byte(`{"order":1}`),
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte("Trace-Id"), Value: []byte("abc")},
+			},
+		}
+
+		e, err := a.toEvent(msg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "orders-3-42", e.ID())
+		assert.Equal(t, defaultEventType, e.Type())
+		assert.Equal(t, "orders", e.Source())
+		assert.Equal(t, "customer-1", e.Subject())
+		assert.Equal(t, tstamp, e.Time())
+		assert.Equal(t, cloudevents.ApplicationJSON, e.DataContentType())
+		assert.Equal(t, `{"order":1}`, string(e.Data()))
+
+		assert.Equal(t, map[string]interface{}{
+			extTopic:             "orders",
+			extPartition:         int32(3),
+			extOffset:            "42",
+			extTimestamp:         types.Timestamp{Time: tstamp},
+			"kafkaheadertraceid": "abc",
+		}, e.Extensions())
+	})
+
+	t.Run("plain record with overrides", func(t *testing.T) {
+		a := &kafkasourceAdapter{
+			eventType:   "custom.type",
+			eventSource: "custom.source",
+		}
+
+		msg := &sarama.ConsumerMessage{
+			Topic: "orders",
+			Value: []byte("not json"),
+		}
+
+		e, err := a.toEvent(msg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "orders-0-0", e.ID())
+		assert.Equal(t, "custom.type", e.Type())
+		assert.Equal(t, "custom.source", e.Source())
+		assert.Empty(t, e.Subject())
+		assert.Equal(t, "application/octet-stream", e.DataContentType())
+	})
+
+	t.Run("binary CloudEvent", func(t *testing.T) {
+		msg := &sarama.ConsumerMessage{
+			Topic: "orders",
+			Value: []byte(`{"order":1}`),
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+				{Key: []byte("ce_id"), Value: []byte("original-id")},
+				{Key: []byte("ce_type"), Value: []byte("original.type")},
+				{Key: []byte("ce_source"), Value: []byte("original.source")},
+				{Key: []byte("ce_time"), Value: []byte("2023-03-01T10:00:00Z")},
+				{Key: []byte("ce_myext"), Value: []byte("value")},
+				{Key: []byte("content-type"), Value: []byte("application/json")},
+			},
+		}
+
+		e, err := a.toEvent(msg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "original-id", e.ID())
+		assert.Equal(t, "original.type", e.Type())
+		assert.Equal(t, "original.source", e.Source())
+		assert.Equal(t, tstamp, e.Time())
+		assert.Equal(t, cloudevents.ApplicationJSON, e.DataContentType())
+		assert.Equal(t, `{"order":1}`, string(e.Data()))
+		assert.Equal(t, map[string]interface{}{"myext": "value"}, e.Extensions())
+	})
+
+	t.Run("invalid binary CloudEvent", func(t *testing.T) {
+		msg := &sarama.ConsumerMessage{
+			Topic: "orders",
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte("ce_specversion"), Value: []byte("1.0")},
+				{Key: []byte("ce_id"), Value: []byte("original-id")},
+			},
+		}
+
+		_, err := a.toEvent(msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("structured CloudEvent", func(t *testing.T) {
+		msg := &sarama.ConsumerMessage{
+			Topic: "orders",
+			Value: []byte(`{"specversion":"1.0","id":"original-id","type":"original.type","source":"original.source",` +
+				`"datacontenttype":"application/json","data":{"order":1}}`),
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte("content-type"), Value: []byte("application/cloudevents+json; charset=utf-8")},
+			},
+		}
+
+		e, err := a.toEvent(msg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "original-id", e.ID())
+		assert.Equal(t, "original.type", e.Type())
+		assert.Equal(t, "original.source", e.Source())
+		assert.Equal(t, `{"order":1}`, string(e.Data()))
+	})
+}
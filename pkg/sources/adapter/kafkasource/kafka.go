@@ -18,52 +18,50 @@ package kafkasource
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/Shopify/sarama"
-	cloudevents "github.com/cloudevents/sdk-go/v2"
-	"go.uber.org/zap"
 )
 
 const (
-	eventType = "io.triggermesh.kafka.event"
+	defaultEventType = "io.triggermesh.kafka.event"
 )
 
 type consumerGroupHandler struct {
 	adapter *kafkasourceAdapter
-}
-
-func (a *kafkasourceAdapter) emitEvent(ctx context.Context, msg sarama.ConsumerMessage) error {
-	event := cloudevents.NewEvent(cloudevents.VersionV1)
-	event.SetType(eventType)
-	event.SetSubject("kafka/event")
-	event.SetSource(msg.Topic)
-	event.SetID(string(msg.Key))
-
-	if err := event.SetData(cloudevents.ApplicationJSON, msg.Value); err != nil {
-		return fmt.Errorf("failed to set event data: %w", err)
-	}
-
-	if result := a.ceClient.Send(context.Background(), event); !cloudevents.IsACK(result) {
-		return result
-	}
-	return nil
+	// restart ends the consumer group session.
+	restart context.CancelFunc
 }
 
 // ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
+//
+// Up to maxInFlight messages of the claimed partition are delivered
+// concurrently, while messages are always marked in offset order. When a
+// message can neither be delivered nor dead-lettered, the session is
+// restarted so that this message is consumed again.
 func (c consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	w := newInFlightWindow(session, c.adapter.maxInFlight)
+	defer w.wait()
+
 	for {
 		select {
 		case msg, ok := <-claim.Messages():
 			if !ok {
 				return nil
 			}
-			if err := c.adapter.emitEvent(session.Context(), *msg); err != nil {
-				c.adapter.logger.Errorw("Failed to emit event: %v", zap.Error(err))
-				// do not mark message
-				continue
+
+			rec := w.add(session.Context(), msg)
+			if rec == nil {
+				c.adapter.logger.Infow("Context closed, exiting consumer")
+				return nil
 			}
-			session.MarkMessage(msg, "")
+
+			go func() {
+				ok := c.adapter.deliver(session.Context(), msg)
+				w.complete(rec, ok)
+				if !ok {
+					c.restart()
+				}
+			}()
 
 		case <-session.Context().Done():
 			c.adapter.logger.Infow("Context closed, exiting consumer")
@@ -72,7 +70,10 @@ func (c consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	}
 }
 
-func (c consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+func (c consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	if c.adapter.initialTimestamp != nil {
+		return c.adapter.seekToTimestamp(session)
+	}
 	return nil
 }
 
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// Accepted values of the initial offset, besides timestamps.
+const (
+	initialOffsetEarliest = "earliest"
+	initialOffsetLatest   = "latest"
+)
+
+// setInitialOffset sets the initial offset of the consumer in the given
+// configuration. When the initial offset is a timestamp, that timestamp is
+// returned, and records are consumed from the latest offset of partitions in
+// which no record was produced after that time.
+func setInitialOffset(cfg *sarama.Config, initialOffset string) (*time.Time, error) {
+	switch initialOffset {
+	case initialOffsetEarliest:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+		return nil, nil
+	case "", initialOffsetLatest:
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, initialOffset)
+	if err != nil {
+		return nil, fmt.Errorf("initial offset %q is neither %q, %q nor a RFC 3339 timestamp",
+			initialOffset, initialOffsetEarliest, initialOffsetLatest)
+	}
+
+	cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	return &t, nil
+}
+
+// subscribedTopics returns the sorted list of topics to consume from, which
+// includes the topics matching the topic pattern, if any.
+func (a *kafkasourceAdapter) subscribedTopics() ([]string, error) {
+	topicSet := make(map[string]struct{}, len(a.topics))
+	for _, t := range a.topics {
+		topicSet[t] = struct{}{}
+	}
+
+	if a.topicPattern != nil {
+		if err := a.client.RefreshMetadata(); err != nil {
+			return nil, fmt.Errorf("refreshing cluster metadata: %w", err)
+		}
+
+		all, err := a.client.Topics()
+		if err != nil {
+			return nil, fmt.Errorf("listing topics: %w", err)
+		}
+
+		for _, t := range all {
+			// skip internal topics such as "__consumer_offsets"
+			if strings.HasPrefix(t, "__") {
+				continue
+			}
+			if a.topicPattern.MatchString(t) {
+				topicSet[t] = struct{}{}
+			}
+		}
+	}
+
+	topics := make([]string, 0, len(topicSet))
+	for t := range topicSet {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+
+	return topics, nil
+}
+
+// watchTopics periodically lists the subscribed topics, and cancels the
+// consumer session when they differ from the given ones.
+func (a *kafkasourceAdapter) watchTopics(ctx context.Context, cancel context.CancelFunc, current []string) {
+	t := time.NewTicker(a.topicRefreshInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-t.C:
+			topics, err := a.subscribedTopics()
+			if err != nil {
+				a.logger.Warnw("Could not list topics matching the topic pattern", zap.Error(err))
+				continue
+			}
+
+			if !equalTopics(topics, current) {
+				a.logger.Infow("Subscribed topics changed, restarting consumer session",
+					zap.Strings("topics", topics))
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// equalTopics returns whether two sorted lists of topics are equal.
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// seekToTimestamp moves the offset of claimed partitions which have no
+// committed offset to the first record produced after the initial timestamp.
+func (a *kafkasourceAdapter) seekToTimestamp(session sarama.ConsumerGroupSession) error {
+	committed, err := a.admin.ListConsumerGroupOffsets(a.groupID, session.Claims())
+	if err != nil {
+		return fmt.Errorf("listing committed offsets: %w", err)
+	}
+
+	ts := a.initialTimestamp.UnixMilli()
+
+	for topic, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			if b := committed.GetBlock(topic, partition); b != nil && b.Offset >= 0 {
+				continue
+			}
+
+			offset, err := a.client.GetOffset(topic, partition, ts)
+			if err != nil {
+				return fmt.Errorf("getting offset of partition %d of topic %q at %s: %w",
+					partition, topic, a.initialTimestamp, err)
+			}
+			// no record was produced after the timestamp
+			if offset < 0 {
+				continue
+			}
+
+			session.MarkOffset(topic, partition, offset, "")
+		}
+	}
+
+	return nil
+}
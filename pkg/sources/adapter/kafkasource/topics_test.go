@@ -0,0 +1,105 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggingtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestSeekToTimestamp(t *testing.T) {
+	const (
+		group = "my-group"
+		topic = "orders"
+	)
+
+	initialTimestamp := time.Date(2023, 3, 10, 15, 0, 0, 0, time.UTC)
+	ts := initialTimestamp.UnixMilli()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetController(broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()).
+			SetLeader(topic, 1, broker.BrokerID()).
+			SetLeader(topic, 2, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+		// only partition 0 has a committed offset
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset(group, topic, 0, 5, "", sarama.ErrNoError).
+			SetOffset(group, topic, 1, -1, "", sarama.ErrNoError).
+			SetOffset(group, topic, 2, -1, "", sarama.ErrNoError),
+		// no record was produced to partition 2 after the timestamp
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(topic, 1, ts, 42).
+			SetOffset(topic, 2, ts, -1),
+	})
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V1_0_0_0
+
+	client, err := sarama.NewClient([]string{broker.Addr()}, cfg)
+	require.NoError(t, err)
+	defer client.Close()
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	require.NoError(t, err)
+
+	a := &kafkasourceAdapter{
+		client:           client,
+		admin:            admin,
+		groupID:          group,
+		initialTimestamp: &initialTimestamp,
+		logger:           loggingtesting.TestLogger(t),
+	}
+
+	session := &claimsSession{
+		claims: map[string][]int32{topic: {0, 1, 2}},
+		marked: make(map[int32]int64),
+	}
+
+	require.NoError(t, a.seekToTimestamp(session))
+	assert.Equal(t, map[int32]int64{1: 42}, session.marked,
+		"Only partitions without committed offset and with records after the timestamp are moved")
+}
+
+// claimsSession is a sarama.ConsumerGroupSession with static claims, which
+// records marked offsets.
+type claimsSession struct {
+	sarama.ConsumerGroupSession
+
+	claims map[string][]int32
+	marked map[int32]int64
+}
+
+func (s *claimsSession) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func (s *claimsSession) MarkOffset(_ string, partition int32, offset int64, _ string) {
+	s.marked[partition] = offset
+}
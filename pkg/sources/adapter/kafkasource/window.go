@@ -0,0 +1,112 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+// inFlightWindow bounds the number of messages of a partition being
+// processed concurrently, and marks processed messages in offset order, so
+// that the committed offset never skips a message which is still in flight.
+type inFlightWindow struct {
+	session sarama.ConsumerGroupSession
+
+	// semaphore of in-flight messages.
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	m sync.Mutex
+	// in-flight and processed but not yet marked messages, in offset order.
+	pending []*inFlightRecord
+	// set once a message failed to be processed, after which no message is
+	// marked anymore.
+	halted bool
+}
+
+// inFlightRecord is a message tracked by an inFlightWindow.
+type inFlightRecord struct {
+	msg  *sarama.ConsumerMessage
+	done bool
+	ok   bool
+}
+
+// newInFlightWindow returns an inFlightWindow which allows up to size
+// messages in flight.
+func newInFlightWindow(session sarama.ConsumerGroupSession, size int) *inFlightWindow {
+	return &inFlightWindow{
+		session: session,
+		slots:   make(chan struct{}, size),
+	}
+}
+
+// add blocks until the window has room for the given message, and returns
+// the record tracking that message. It returns nil if the context is done
+// before a slot is freed.
+func (w *inFlightWindow) add(ctx context.Context, msg *sarama.ConsumerMessage) *inFlightRecord {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil
+	}
+
+	rec := &inFlightRecord{msg: msg}
+
+	w.m.Lock()
+	w.pending = append(w.pending, rec)
+	w.m.Unlock()
+
+	w.wg.Add(1)
+
+	return rec
+}
+
+// complete records the outcome of the processing of the given record, and
+// marks all the leading processed messages of the window. Neither a message
+// which failed to be processed nor any message following it is marked, so
+// that the committed offset never moves past a message which wasn't
+// delivered.
+func (w *inFlightWindow) complete(rec *inFlightRecord, ok bool) {
+	w.m.Lock()
+
+	rec.done = true
+	rec.ok = ok
+
+	var i int
+	for ; i < len(w.pending) && w.pending[i].done; i++ {
+		if !w.pending[i].ok {
+			w.halted = true
+		}
+		if !w.halted {
+			w.session.MarkMessage(w.pending[i].msg, "")
+		}
+	}
+	w.pending = w.pending[i:]
+
+	w.m.Unlock()
+
+	<-w.slots
+	w.wg.Done()
+}
+
+// wait blocks until all messages in flight are processed.
+func (w *inFlightWindow) wait() {
+	w.wg.Wait()
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkasource
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightWindow(t *testing.T) {
+	s := &fakeSession{}
+	w := newInFlightWindow(s, 3)
+
+	ctx := context.Background()
+
+	recs := make([]*inFlightRecord, 3)
+	for i := range recs {
+		recs[i] = w.add(ctx, &sarama.ConsumerMessage{Offset: int64(i)})
+		require.NotNil(t, recs[i])
+	}
+
+	// the window is full
+	ctxTimeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Nil(t, w.add(ctxTimeout, &sarama.ConsumerMessage{Offset: 3}))
+
+	// later messages are not marked before earlier ones
+	w.complete(recs[2], true)
+	assert.Empty(t, s.markedOffsets())
+
+	// neither failed messages nor the ones following them are marked
+	w.complete(recs[1], false)
+	assert.Empty(t, s.markedOffsets())
+
+	w.complete(recs[0], true)
+	assert.Equal(t, []int64{0}, s.markedOffsets())
+
+	// slots are freed, but the window remains halted
+	rec := w.add(ctx, &sarama.ConsumerMessage{Offset: 3})
+	require.NotNil(t, rec)
+	w.complete(rec, true)
+	assert.Equal(t, []int64{0}, s.markedOffsets())
+
+	w.wait()
+}
+
+func TestSetInitialOffset(t *testing.T) {
+	testCases := map[string]struct {
+		initialOffset string
+		expectOffset  int64
+		expectTime    *time.Time
+		expectErr     bool
+	}{
+		"default": {
+			expectOffset: sarama.OffsetNewest,
+		},
+		"earliest": {
+			initialOffset: "earliest",
+			expectOffset:  sarama.OffsetOldest,
+		},
+		"latest": {
+			initialOffset: "latest",
+			expectOffset:  sarama.OffsetNewest,
+		},
+		"timestamp": {
+			initialOffset: "2023-03-01T10:00:00Z",
+			expectOffset:  sarama.OffsetNewest,
+			expectTime:    timePtr(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)),
+		},
+		"invalid": {
+			initialOffset: "yesterday",
+			expectErr:     true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			cfg := sarama.NewConfig()
+
+			ts, err := setInitialOffset(cfg, tc.initialOffset)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectOffset, cfg.Consumer.Offsets.Initial)
+			assert.Equal(t, tc.expectTime, ts)
+		})
+	}
+}
+
+// fakeSession is a sarama.ConsumerGroupSession which records marked messages.
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+
+	m      sync.Mutex
+	marked []int64
+}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *fakeSession) markedOffsets() []int64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.marked
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package mongodbsource
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -30,23 +32,43 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+	k8sclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/sources"
-	"github.com/triggermesh/triggermesh/pkg/apis/sources/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/common/mongodb"
+	"github.com/triggermesh/triggermesh/pkg/sources/adapter/common"
 	"github.com/triggermesh/triggermesh/pkg/sources/adapter/common/health"
 )
 
 const (
-	resyncPeriod = 15 * time.Second
+	// Minimum interval between two writes of the resume token to the
+	// checkpoint store. Changes which happened during that interval are
+	// read again after a restart, with identical event IDs.
+	checkpointInterval = 5 * time.Second
+	// Timeout of the last write of the resume token at shutdown.
+	checkpointSaveTimeout = 10 * time.Second
 )
 
+// Error code returned by MongoDB when a resume token is no longer in the oplog.
+const errCodeChangeStreamHistoryLost = 286
+
 type envConfig struct {
 	pkgadapter.EnvConfig
 
 	MongoDBURI string `envconfig:"MONGODB_URI" required:"true"`
 	Database   string `envconfig:"MONGODB_DATABASE" required:"true"`
 	Collection string `envconfig:"MONGODB_COLLECTION" required:"true"`
+
+	// Aggregation pipeline in Extended JSON format.
+	Pipeline     string `envconfig:"MONGODB_PIPELINE"`
+	FullDocument string `envconfig:"MONGODB_FULL_DOCUMENT"`
+
+	// Checkpoint store, either a ConfigMap, a collection or a file.
+	CheckpointConfigMap  string `envconfig:"MONGODB_CHECKPOINT_CONFIGMAP"`
+	CheckpointDatabase   string `envconfig:"MONGODB_CHECKPOINT_DATABASE"`
+	CheckpointCollection string `envconfig:"MONGODB_CHECKPOINT_COLLECTION"`
+	CheckpointFile       string `envconfig:"MONGODB_CHECKPOINT_FILE"`
 }
 
 type adapter struct {
@@ -56,8 +78,16 @@ type adapter struct {
 	mongoClient *mongo.Client
 	ceClient    cloudevents.Client
 
-	database   string
-	collection string
+	database     string
+	collection   string
+	pipeline     mongo.Pipeline
+	fullDocument options.FullDocument
+
+	checkpoints checkpointStore
+	// latest resume token, and whether it was persisted
+	token      bson.Raw
+	tokenSaved bool
+	lastSave   time.Time
 }
 
 // NewEnvConfig satisfies pkgadapter.EnvConfigConstructor.
@@ -86,6 +116,38 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		logger.Fatalw("Error pinging MongoDB", zap.Error(err))
 	}
 
+	pipeline := mongo.Pipeline{}
+	if env.Pipeline != "" {
+		if pipeline, err = mongodb.ParsePipeline([]byte(env.Pipeline)); err != nil {
+			logger.Fatalw("Invalid aggregation pipeline", zap.Error(err))
+		}
+	}
+
+	var checkpoints checkpointStore
+	switch {
+	case env.CheckpointConfigMap != "":
+		checkpoints = &configMapStore{
+			cmClient: k8sclient.Get(ctx).CoreV1().ConfigMaps(env.Namespace),
+			name:     env.CheckpointConfigMap,
+			key:      env.Name,
+		}
+
+	case env.CheckpointCollection != "":
+		db := env.Database
+		if env.CheckpointDatabase != "" {
+			db = env.CheckpointDatabase
+		}
+		checkpoints = &collectionStore{
+			coll: client.Database(db).Collection(env.CheckpointCollection),
+			id:   env.Namespace + "/" + env.Name,
+		}
+
+	case env.CheckpointFile != "":
+		checkpoints = &fileStore{
+			path: env.CheckpointFile,
+		}
+	}
+
 	return &adapter{
 		logger: logger,
 		mt:     mt,
@@ -93,8 +155,12 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		mongoClient: client,
 		ceClient:    ceClient,
 
-		database:   env.Database,
-		collection: env.Collection,
+		database:     env.Database,
+		collection:   env.Collection,
+		pipeline:     pipeline,
+		fullDocument: options.FullDocument(env.FullDocument),
+
+		checkpoints: checkpoints,
 	}
 }
 
@@ -104,72 +170,144 @@ func (a *adapter) Start(ctx context.Context) error {
 	health.MarkReady()
 	a.logger.Info("Starting collection of MongoDB change events")
 	ctx = pkgadapter.ContextWithMetricTag(ctx, a.mt)
-	coll := a.mongoClient.Database(a.database).Collection(a.collection)
-	cs, err := coll.Watch(ctx, mongo.Pipeline{})
+
+	cs, err := a.watch(ctx)
 	if err != nil {
 		return fmt.Errorf("watching MongoDB collection: %w", err)
 	}
-	defer cs.Close(ctx)
-
-	t := time.NewTicker(resyncPeriod)
-	defer t.Stop()
+	defer a.saveCheckpoint(true)
 
-	// Call processChanges initially
-	if err := a.processChanges(ctx, cs); err != nil {
-		a.logger.Errorw("Error processing changes", zap.Error(err))
-	}
+	backoff := common.NewBackoff()
 
 	for {
-		select {
-		case <-ctx.Done():
+		token := a.token
+
+		err := a.processChanges(ctx, cs)
+		cs.Close(context.Background())
+
+		if ctx.Err() != nil {
 			return nil
+		}
+
+		// Changes which could not be sent are read again from the last
+		// resume token of a successfully sent event.
+		if !bytes.Equal(token, a.token) {
+			backoff.Reset()
+		}
+		delay := backoff.Duration()
+		a.logger.Errorw("Error processing changes, resuming change stream after backoff",
+			zap.Error(err), zap.Duration("backoff", delay))
+
+		for cs = nil; cs == nil; {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
 
-		case <-t.C:
-			// Call processChanges when the ticker ticks
-			if err := a.processChanges(ctx, cs); err != nil {
-				a.logger.Errorw("Error processing changes", zap.Error(err))
+			if cs, err = a.watch(ctx); err != nil {
+				delay = backoff.Duration()
+				a.logger.Errorw("Error watching MongoDB collection, retrying after backoff",
+					zap.Error(err), zap.Duration("backoff", delay))
 			}
 		}
 	}
 }
 
+// watch opens a change stream on the collection, which resumes after the
+// resume token of the last sent event, or the persisted one if there is one.
+func (a *adapter) watch(ctx context.Context) (*mongo.ChangeStream, error) {
+	coll := a.mongoClient.Database(a.database).Collection(a.collection)
+
+	opts := options.ChangeStream()
+	if a.fullDocument != "" {
+		opts.SetFullDocument(a.fullDocument)
+	}
+
+	switch {
+	case a.token != nil:
+		opts.SetStartAfter(a.token)
+
+	case a.checkpoints != nil:
+		token, err := a.checkpoints.load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading resume token: %w", err)
+		}
+		if token != nil {
+			a.logger.Infow("Resuming change stream from checkpoint", zap.Stringer("token", token))
+			opts.SetStartAfter(token)
+		}
+	}
+
+	cs, err := coll.Watch(ctx, a.pipeline, opts)
+
+	var srvErr mongo.ServerError
+	if errors.As(err, &srvErr) && srvErr.HasErrorCode(errCodeChangeStreamHistoryLost) {
+		a.logger.Warnw("Resume token is no longer in the oplog, some changes were lost. "+
+			"Watching changes from the current time", zap.Error(err))
+		opts.StartAfter = nil
+		cs, err = coll.Watch(ctx, a.pipeline, opts)
+	}
+
+	return cs, err
+}
+
+// processChanges sends the events read from the change stream until an event
+// can not be sent, in which case the resume token is not advanced past that
+// event.
 func (a *adapter) processChanges(ctx context.Context, cs *mongo.ChangeStream) error {
 	for cs.Next(ctx) {
-		var changeEvent bson.M
-		if err := cs.Decode(&changeEvent); err != nil {
-			a.logger.Errorw("Error decoding change event", zap.Error(err))
-			continue
+		event, err := a.makeEvent(cs.Current, cs.ResumeToken())
+		if err != nil {
+			// a change which can not be converted to an event never
+			// will, retrying it would block the change stream
+			a.logger.Errorw("Error processing change event, skipping it", zap.Error(err))
+		} else if err := a.sendEvent(ctx, event); err != nil {
+			return err
 		}
 
-		if err := a.processChangeEvent(ctx, changeEvent); err != nil {
-			a.logger.Errorw("Error processing change event", zap.Error(err))
-		}
+		a.token = cs.ResumeToken()
+		a.tokenSaved = false
+		a.saveCheckpoint(false)
 	}
 
 	if err := cs.Err(); err != nil {
-		a.logger.Errorw("Error reading change events", zap.Error(err))
 		return fmt.Errorf("reading change events: %w", err)
 	}
 
 	return nil
 }
 
-func (a *adapter) processChangeEvent(ctx context.Context, changeEvent bson.M) error {
-	event := cloudevents.NewEvent(cloudevents.VersionV1)
-	event.SetType(v1alpha1.MongoDBSourceEventType)
-	event.SetSource(a.mt.Namespace + "/" + a.mt.Name)
-	event.SetTime(time.Now())
+func (a *adapter) sendEvent(ctx context.Context, event *cloudevents.Event) error {
+	if result := a.ceClient.Send(ctx, *event); !cloudevents.IsACK(result) {
+		return fmt.Errorf("sending event: %w", result)
+	}
+
+	return nil
+}
 
-	if err := event.SetData(cloudevents.ApplicationJSON, changeEvent); err != nil {
-		return fmt.Errorf("setting event data: %w", err)
+// saveCheckpoint persists the latest resume token, unless it was persisted
+// already or, when force is false, the previous token was persisted less than
+// a checkpoint interval ago.
+func (a *adapter) saveCheckpoint(force bool) {
+	if a.checkpoints == nil || a.token == nil || a.tokenSaved {
+		return
+	}
+	if !force && time.Since(a.lastSave) < checkpointInterval {
+		return
 	}
 
-	if result := a.ceClient.Send(ctx, event); !cloudevents.IsACK(result) {
-		a.logger.Errorw("Failed to send event", zap.String("target", a.mt.Namespace+"/"+a.mt.Name), zap.Error(result))
-		return fmt.Errorf("sending event: %w", result)
+	// the token must be persisted even though the adapter is stopping
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointSaveTimeout)
+	defer cancel()
+
+	if err := a.checkpoints.save(ctx, a.token); err != nil {
+		a.logger.Errorw("Error saving resume token", zap.Error(err))
+		return
 	}
 
-	return nil
+	a.tokenSaved = true
+	a.lastSave = time.Now()
 }
 
 func (a *adapter) Stop() {
@@ -0,0 +1,224 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// checkpointStore persists the resume token of a change stream.
+type checkpointStore interface {
+	// load returns the persisted resume token, or nil if no token was
+	// persisted yet.
+	load(ctx context.Context) (bson.Raw, error)
+	// save persists the given resume token.
+	save(ctx context.Context, token bson.Raw) error
+}
+
+// configMapStore persists resume tokens in a ConfigMap, under a key which is
+// the name of the source.
+type configMapStore struct {
+	cmClient coreclientv1.ConfigMapInterface
+	name     string
+	key      string
+}
+
+var _ checkpointStore = (*configMapStore)(nil)
+
+// load implements checkpointStore.
+func (s *configMapStore) load(ctx context.Context) (bson.Raw, error) {
+	cm, err := s.cmClient.Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("getting ConfigMap %q: %w", s.name, err)
+	}
+
+	token, ok := cm.Data[s.key]
+	if !ok {
+		return nil, nil
+	}
+
+	return decodeToken([]byte(token))
+}
+
+// save implements checkpointStore.
+func (s *configMapStore) save(ctx context.Context, token bson.Raw) error {
+	data, err := encodeToken(token)
+	if err != nil {
+		return err
+	}
+
+	cm, err := s.cmClient.Get(ctx, s.name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: s.name,
+			},
+			Data: map[string]string{
+				s.key: string(data),
+			},
+		}
+		if _, err := s.cmClient.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating ConfigMap %q: %w", s.name, err)
+		}
+		return nil
+
+	case err != nil:
+		return fmt.Errorf("getting ConfigMap %q: %w", s.name, err)
+	}
+
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = make(map[string]string, 1)
+	}
+	cm.Data[s.key] = string(data)
+
+	if _, err := s.cmClient.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating ConfigMap %q: %w", s.name, err)
+	}
+	return nil
+}
+
+// collectionStore persists resume tokens in a MongoDB collection, in a
+// document which ID identifies the source.
+type collectionStore struct {
+	coll *mongo.Collection
+	id   string
+}
+
+var _ checkpointStore = (*collectionStore)(nil)
+
+// checkpointDocument is the document persisted by a collectionStore.
+type checkpointDocument struct {
+	ID          string    `bson:"_id"`
+	ResumeToken bson.Raw  `bson:"resumeToken"`
+	UpdatedAt   time.Time `bson:"updatedAt"`
+}
+
+// load implements checkpointStore.
+func (s *collectionStore) load(ctx context.Context) (bson.Raw, error) {
+	var doc checkpointDocument
+
+	err := s.coll.FindOne(ctx, bson.M{"_id": s.id}).Decode(&doc)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("finding checkpoint document: %w", err)
+	}
+
+	return doc.ResumeToken, nil
+}
+
+// save implements checkpointStore.
+func (s *collectionStore) save(ctx context.Context, token bson.Raw) error {
+	doc := &checkpointDocument{
+		ID:          s.id,
+		ResumeToken: token,
+		UpdatedAt:   time.Now(),
+	}
+
+	_, err := s.coll.ReplaceOne(ctx, bson.M{"_id": s.id}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("writing checkpoint document: %w", err)
+	}
+	return nil
+}
+
+// fileStore persists resume tokens in a file.
+type fileStore struct {
+	path string
+}
+
+var _ checkpointStore = (*fileStore)(nil)
+
+// load implements checkpointStore.
+func (s *fileStore) load(context.Context) (bson.Raw, error) {
+	data, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading checkpoint file: %w", err)
+	}
+
+	return decodeToken(data)
+}
+
+// save implements checkpointStore.
+func (s *fileStore) save(_ context.Context, token bson.Raw) error {
+	data, err := encodeToken(token)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that a crash never leaves a
+	// partially written checkpoint behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing temporary checkpoint file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary checkpoint file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// encodeToken serializes a resume token to Extended JSON.
+func encodeToken(token bson.Raw) ([]byte, error) {
+	data, err := bson.MarshalExtJSON(token, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("serializing resume token: %w", err)
+	}
+	return data, nil
+}
+
+// decodeToken deserializes a resume token from Extended JSON.
+func decodeToken(data []byte) (bson.Raw, error) {
+	var token bson.Raw
+	if err := bson.UnmarshalExtJSON(data, true, &token); err != nil {
+		return nil, fmt.Errorf("deserializing resume token: %w", err)
+	}
+	return token, nil
+}
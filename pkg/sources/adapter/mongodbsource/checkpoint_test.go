@@ -0,0 +1,86 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbsource
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+
+	cmClient := fake.NewSimpleClientset().CoreV1().ConfigMaps("ns")
+	s := &configMapStore{
+		cmClient: cmClient,
+		name:     "checkpoints",
+		key:      "src",
+	}
+
+	token, err := s.load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, token, "no token before the first save")
+
+	token1 := mustMarshal(t, bson.M{"_data": "01"})
+	require.NoError(t, s.save(ctx, token1))
+
+	token2 := mustMarshal(t, bson.M{"_data": "02"})
+	require.NoError(t, s.save(ctx, token2))
+
+	token, err = s.load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, token2, token)
+
+	cm, err := cmClient.Get(ctx, "checkpoints", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"_data":"02"}`, cm.Data["src"])
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+
+	s := &fileStore{
+		path: filepath.Join(t.TempDir(), "token.json"),
+	}
+
+	token, err := s.load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, token, "no token before the first save")
+
+	token1 := mustMarshal(t, bson.M{"_data": "01"})
+	require.NoError(t, s.save(ctx, token1))
+
+	token2 := mustMarshal(t, bson.M{"_data": "02"})
+	require.NoError(t, s.save(ctx, token2))
+
+	token, err = s.load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, token2, token)
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(s.path), "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "temporary files are removed")
+}
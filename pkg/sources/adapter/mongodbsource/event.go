@@ -0,0 +1,128 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbsource
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/sources/v1alpha1"
+)
+
+// changeEventMeta contains the metadata of a change event.
+// https://www.mongodb.com/docs/manual/reference/change-events/
+type changeEventMeta struct {
+	OperationType string `bson:"operationType"`
+	NS            struct {
+		DB   string `bson:"db"`
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey bson.Raw            `bson:"documentKey"`
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+}
+
+// makeEvent returns a CloudEvent for the given change event, which resume
+// token is also given.
+//
+// The type of the CloudEvent is derived from the operation type of the
+// change, its subject from the namespace of the change, and its ID from the
+// key of the changed document and the resume token, so that a change which is
+// read again after a restart produces an event with the same ID.
+func (a *adapter) makeEvent(change bson.Raw, token bson.Raw) (*cloudevents.Event, error) {
+	var meta changeEventMeta
+	if err := bson.Unmarshal(change, &meta); err != nil {
+		return nil, fmt.Errorf("decoding change event metadata: %w", err)
+	}
+
+	var data bson.M
+	if err := bson.Unmarshal(change, &data); err != nil {
+		return nil, fmt.Errorf("decoding change event: %w", err)
+	}
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetSource(a.mt.Namespace + "/" + a.mt.Name)
+
+	typ := v1alpha1.MongoDBSourceEventType
+	if meta.OperationType != "" {
+		typ = v1alpha1.MongoDBSourceChangeEventTypePrefix + meta.OperationType
+	}
+	event.SetType(typ)
+
+	subject := meta.NS.DB
+	if meta.NS.Coll != "" {
+		subject += "." + meta.NS.Coll
+	}
+	if subject != "" {
+		event.SetSubject(subject)
+	}
+
+	event.SetID(changeEventID(meta.DocumentKey, token))
+
+	eventTime := time.Now()
+	if meta.ClusterTime.T != 0 {
+		eventTime = time.Unix(int64(meta.ClusterTime.T), 0)
+	}
+	event.SetTime(eventTime)
+
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, fmt.Errorf("setting event data: %w", err)
+	}
+
+	return &event, nil
+}
+
+// changeEventID returns a deterministic ID for a change event, composed of
+// the ID of the changed document, if any, and of a hash of the resume token
+// of the change.
+func changeEventID(documentKey bson.Raw, token bson.Raw) string {
+	h := fnv.New64a()
+	_, _ = h.Write(token)
+	tokenHash := fmt.Sprintf("%016x", h.Sum64())
+
+	if documentKey == nil {
+		return tokenHash
+	}
+
+	id, err := documentKey.LookupErr("_id")
+	if err != nil {
+		return tokenHash
+	}
+
+	var docID string
+	switch id.Type {
+	case bsontype.ObjectID:
+		docID = id.ObjectID().Hex()
+	case bsontype.String:
+		docID = id.StringValue()
+	case bsontype.Int32:
+		docID = strconv.FormatInt(int64(id.Int32()), 10)
+	case bsontype.Int64:
+		docID = strconv.FormatInt(id.Int64(), 10)
+	default:
+		docID = id.String()
+	}
+
+	return docID + "-" + tokenHash
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbsource
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/sources/v1alpha1"
+)
+
+func TestMakeEvent(t *testing.T) {
+	a := &adapter{
+		mt: &pkgadapter.MetricTag{
+			Namespace: "ns",
+			Name:      "src",
+		},
+	}
+
+	docID, err := primitive.ObjectIDFromHex("64a1f0c2e4b0a1b2c3d4e5f6")
+	require.NoError(t, err)
+
+	token := mustMarshal(t, bson.M{"_data": "8264A1F0C2000000012B022C0100296E5A1004"})
+
+	t.Run("insert", func(t *testing.T) {
+		change := mustMarshal(t, bson.D{
+			{Key: "_id", Value: bson.M{"_data": "8264A1F0C2000000012B022C0100296E5A1004"}},
+			{Key: "operationType", Value: "insert"},
+			{Key: "clusterTime", Value: primitive.Timestamp{T: 1688334530, I: 1}},
+			{Key: "ns", Value: bson.M{"db": "shop", "coll": "orders"}},
+			{Key: "documentKey", Value: bson.M{"_id": docID}},
+			{Key: "fullDocument", Value: bson.M{"_id": docID, "qty": 3}},
+		})
+
+		e, err := a.makeEvent(change, token)
+		require.NoError(t, err)
+
+		assert.Equal(t, v1alpha1.MongoDBSourceEventTypeInsert, e.Type())
+		assert.Equal(t, "ns/src", e.Source())
+		assert.Equal(t, "shop.orders", e.Subject())
+		assert.Equal(t, time.Unix(1688334530, 0).UTC(), e.Time().UTC())
+		assert.Regexp(t, `^64a1f0c2e4b0a1b2c3d4e5f6-[0-9a-f]{16}$`, e.ID())
+
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal(e.Data(), &data))
+		assert.Equal(t, "insert", data["operationType"])
+
+		// the same change read again yields the same ID
+		again, err := a.makeEvent(change, token)
+		require.NoError(t, err)
+		assert.Equal(t, e.ID(), again.ID())
+	})
+
+	t.Run("drop database", func(t *testing.T) {
+		change := mustMarshal(t, bson.D{
+			{Key: "operationType", Value: "dropDatabase"},
+			{Key: "ns", Value: bson.M{"db": "shop"}},
+		})
+
+		e, err := a.makeEvent(change, token)
+		require.NoError(t, err)
+
+		assert.Equal(t, v1alpha1.MongoDBSourceChangeEventTypePrefix+"dropDatabase", e.Type())
+		assert.Equal(t, "shop", e.Subject())
+		assert.Regexp(t, `^[0-9a-f]{16}$`, e.ID())
+	})
+}
+
+func TestChangeEventID(t *testing.T) {
+	token1 := mustMarshal(t, bson.M{"_data": "01"})
+	token2 := mustMarshal(t, bson.M{"_data": "02"})
+
+	key := mustMarshal(t, bson.M{"_id": "order-1"})
+
+	assert.NotEqual(t, changeEventID(key, token1), changeEventID(key, token2),
+		"changes of the same document have distinct IDs")
+	assert.Regexp(t, `^order-1-[0-9a-f]{16}$`, changeEventID(key, token1))
+	assert.Regexp(t, `^42-[0-9a-f]{16}$`,
+		changeEventID(mustMarshal(t, bson.M{"_id": int32(42)}), token1))
+	assert.Regexp(t, `^\{"\$numberDouble":"4\.2"\}-[0-9a-f]{16}$`,
+		changeEventID(mustMarshal(t, bson.M{"_id": 4.2}), token1))
+}
+
+func mustMarshal(t *testing.T, v interface{}) bson.Raw {
+	t.Helper()
+
+	b, err := bson.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
@@ -19,12 +19,16 @@ package webhooksource
 import (
 	"context"
 
+	"go.uber.org/zap"
+
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/logging"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/sources"
+	"github.com/triggermesh/triggermesh/pkg/sources/adapter/common/ratelimiter"
 )
 
 // NewAdapter satisfies pkgadapter.AdapterConstructor.
@@ -36,6 +40,27 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 	}
 
 	env := envAcc.(*envAccessor)
+	logger := logging.FromContext(ctx)
+
+	if env.MaxBodySize < 0 {
+		logger.Panicf("Invalid maximum body size %d, must be positive", env.MaxBodySize)
+	}
+	if env.MaxInFlightRequests < 0 {
+		logger.Panicf("Invalid maximum number of requests in flight %d, must be positive", env.MaxInFlightRequests)
+	}
+
+	var rl cehttp.RateLimiter
+	if env.RequestsPerSecond != 0 {
+		var err error
+		if rl, err = ratelimiter.New(env.RequestsPerSecond); err != nil {
+			logger.Panicw("Could not create rate limiter", zap.Error(err))
+		}
+	}
+
+	var inFlight chan struct{}
+	if env.MaxInFlightRequests > 0 {
+		inFlight = make(chan struct{}, env.MaxInFlightRequests)
+	}
 
 	return &webhookHandler{
 		eventType:               env.EventType,
@@ -45,8 +70,12 @@ func NewAdapter(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClie
 		password:                env.BasicAuthPassword,
 		corsAllowOrigin:         env.CORSAllowOrigin,
 
+		maxBodySize: env.MaxBodySize,
+		rateLimiter: rl,
+		inFlight:    inFlight,
+
 		ceClient: ceClient,
-		logger:   logging.FromContext(ctx),
+		logger:   logger,
 		mt:       mt,
 	}
 }
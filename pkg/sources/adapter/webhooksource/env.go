@@ -37,6 +37,9 @@ type envAccessor struct {
 	BasicAuthUsername            string                   `envconfig:"WEBHOOK_BASICAUTH_USERNAME"`
 	BasicAuthPassword            string                   `envconfig:"WEBHOOK_BASICAUTH_PASSWORD"`
 	CORSAllowOrigin              string                   `envconfig:"WEBHOOK_CORS_ALLOW_ORIGIN"`
+	MaxBodySize                  int64                    `envconfig:"WEBHOOK_MAX_BODY_SIZE"`
+	RequestsPerSecond            uint64                   `envconfig:"WEBHOOK_RATELIMITER_RPS"`
+	MaxInFlightRequests          int                      `envconfig:"WEBHOOK_MAX_IN_FLIGHT_REQUESTS"`
 }
 
 type ExtensionAttributesFrom struct {
@@ -21,13 +21,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/logging"
@@ -48,6 +51,13 @@ type webhookHandler struct {
 	password                string
 	corsAllowOrigin         string
 
+	// maxBodySize is the maximum size of accepted request bodies, unlimited if zero.
+	maxBodySize int64
+	// rateLimiter limits the rate of accepted requests, if not nil.
+	rateLimiter cehttp.RateLimiter
+	// inFlight is a semaphore limiting the number of concurrent requests, if not nil.
+	inFlight chan struct{}
+
 	ceClient cloudevents.Client
 	logger   *zap.SugaredLogger
 	mt       *pkgadapter.MetricTag
@@ -67,6 +77,14 @@ func (h *webhookHandler) Start(ctx context.Context) error {
 		Handler: m,
 	}
 
+	if h.rateLimiter != nil {
+		defer func() {
+			if err := h.rateLimiter.Close(context.Background()); err != nil {
+				h.logger.Errorw("Error closing rate limiter", zap.Error(err))
+			}
+		}()
+	}
+
 	return runHandler(ctx, s)
 }
 
@@ -117,6 +135,7 @@ func (h *webhookHandler) handleAll(ctx context.Context) http.HandlerFunc {
 			return
 		}
 
+		// unauthenticated requests must not consume the limits of legitimate senders
 		if h.username != "" && h.password != "" {
 			us, ps, ok := r.BasicAuth()
 			if !ok {
@@ -129,9 +148,40 @@ func (h *webhookHandler) handleAll(ctx context.Context) http.HandlerFunc {
 			}
 		}
 
+		if h.rateLimiter != nil {
+			ok, reset, err := h.rateLimiter.Allow(r.Context(), r)
+			if err != nil {
+				h.handleError(fmt.Errorf("unable to acquire rate limit token: %w", err), http.StatusInternalServerError, w)
+				return
+			}
+			if !ok {
+				h.rejectTooManyRequests(w, errors.New("rate limit exceeded"), retryAfter(reset))
+				return
+			}
+		}
+
+		if h.inFlight != nil {
+			select {
+			case h.inFlight <- struct{}{}:
+				defer func() { <-h.inFlight }()
+			default:
+				h.rejectTooManyRequests(w, errors.New("too many requests in flight"), 1)
+				return
+			}
+		}
+
+		if h.maxBodySize > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+		}
+
 		defer r.Body.Close()
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				h.handleError(fmt.Errorf("request body exceeds %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge, w)
+				return
+			}
 			h.handleError(err, http.StatusInternalServerError, w)
 			return
 		}
@@ -218,6 +268,23 @@ func (h *webhookHandler) handleError(err error, code int, w http.ResponseWriter)
 	http.Error(w, err.Error(), code)
 }
 
+// rejectTooManyRequests replies to throttled requests with a 429 status and
+// the number of seconds after which the client can retry.
+func (h *webhookHandler) rejectTooManyRequests(w http.ResponseWriter, err error, retryAfterSeconds int) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	h.handleError(err, http.StatusTooManyRequests, w)
+}
+
+// retryAfter returns the number of seconds until the given reset time of the
+// rate limiter, expressed as a UNIX timestamp in nanoseconds.
+func retryAfter(reset uint64) int {
+	secs := int(math.Ceil(time.Until(time.Unix(0, int64(reset))).Seconds()))
+	if secs < 1 {
+		return 1
+	}
+	return secs
+}
+
 func healthCheckHandler(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
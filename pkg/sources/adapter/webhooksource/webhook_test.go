@@ -34,6 +34,7 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	cloudeventst "github.com/cloudevents/sdk-go/v2/client/test"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 )
 
 const (
@@ -240,6 +241,88 @@ func TestWebhookEvent(t *testing.T) {
 	}
 }
 
+func TestWebhookLimits(t *testing.T) {
+	logger := zapt.NewLogger(t).Sugar()
+
+	fullInFlight := make(chan struct{}, 1)
+	fullInFlight <- struct{}{}
+
+	testCases := map[string]struct {
+		maxBodySize int64
+		rateLimiter cehttp.RateLimiter
+		inFlight    chan struct{}
+		username    string
+		password    string
+		body        io.Reader
+
+		expectedCode       int
+		expectedRetryAfter string
+	}{
+		"body too large": {
+			maxBodySize:  4,
+			body:         read(`{"test":"data"}`),
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		"rate limit exceeded": {
+			rateLimiter:        &denyingRateLimiter{reset: uint64(time.Now().Add(3 * time.Second).UnixNano())},
+			body:               read(`{"test":"data"}`),
+			expectedCode:       http.StatusTooManyRequests,
+			expectedRetryAfter: "3",
+		},
+		"too many requests in flight": {
+			inFlight:           fullInFlight,
+			body:               read(`{"test":"data"}`),
+			expectedCode:       http.StatusTooManyRequests,
+			expectedRetryAfter: "1",
+		},
+		"unauthenticated request": {
+			rateLimiter:  &denyingRateLimiter{reset: uint64(time.Now().Add(3 * time.Second).UnixNano())},
+			inFlight:     fullInFlight,
+			username:     "user",
+			password:     "pass",
+			body:         read(`{"test":"data"}`),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for name, c := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			handler := &webhookHandler{
+				eventType:   tEventType,
+				eventSource: tEventSource,
+				maxBodySize: c.maxBodySize,
+				rateLimiter: c.rateLimiter,
+				inFlight:    c.inFlight,
+				username:    c.username,
+				password:    c.password,
+				logger:      logger,
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/", c.body)
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(handler.handleAll(context.Background())).ServeHTTP(rr, req)
+
+			assert.Equal(t, c.expectedCode, rr.Code, "unexpected response code")
+			assert.Equal(t, c.expectedRetryAfter, rr.Header().Get("Retry-After"), "unexpected Retry-After header")
+		})
+	}
+}
+
+// denyingRateLimiter is a cehttp.RateLimiter which rejects all requests.
+type denyingRateLimiter struct {
+	reset uint64
+}
+
+func (l *denyingRateLimiter) Allow(context.Context, *http.Request) (bool, uint64, error) {
+	return false, l.reset, nil
+}
+
+func (l *denyingRateLimiter) Close(context.Context) error {
+	return nil
+}
+
 func read(s string) io.Reader {
 	return strings.NewReader(s)
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package awssqssource
 
 import (
+	"strconv"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
@@ -30,7 +32,11 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/sources/reconciler"
 )
 
-const envMessageProcessor = "SQS_MESSAGE_PROCESSOR"
+const (
+	envMessageProcessor  = "SQS_MESSAGE_PROCESSOR"
+	envVisibilityTimeout = "SQS_VISIBILITY_TIMEOUT"
+	envConcurrency       = "SQS_CONCURRENCY"
+)
 
 const healthPortName = "health"
 
@@ -65,7 +71,7 @@ func (r *Reconciler) BuildAdapter(src commonv1alpha1.Reconcilable, sinkURI *apis
 // maybeSetMessageProcessor conditionally sets the envMessageProcessor
 // environment variable.
 func maybeSetMessageProcessor(envs []corev1.EnvVar, src *v1alpha1.AWSSQSSource) []corev1.EnvVar {
-	if mp := src.Spec.MessageProcessor; mp != nil && *mp == "s3" {
+	if mp := src.Spec.MessageProcessor; mp != nil && *mp != "" {
 		envs = append(envs, corev1.EnvVar{
 			Name:  envMessageProcessor,
 			Value: *mp,
@@ -75,12 +81,38 @@ func maybeSetMessageProcessor(envs []corev1.EnvVar, src *v1alpha1.AWSSQSSource)
 	return envs
 }
 
+// maybeSetReceiveOptions conditionally sets the environment variables which
+// control the behavior of message receivers and processors.
+func maybeSetReceiveOptions(envs []corev1.EnvVar, src *v1alpha1.AWSSQSSource) []corev1.EnvVar {
+	opts := src.Spec.ReceiveOptions
+	if opts == nil {
+		return envs
+	}
+
+	if vt := opts.VisibilityTimeout; vt != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envVisibilityTimeout,
+			Value: vt.String(),
+		})
+	}
+
+	if c := opts.Concurrency; c != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envConcurrency,
+			Value: strconv.FormatInt(int64(*c), 10),
+		})
+	}
+
+	return envs
+}
+
 // MakeAppEnv extracts environment variables from the object.
 // Exported to be used in external tools for local test environments.
 func MakeAppEnv(o *v1alpha1.AWSSQSSource) []corev1.EnvVar {
 	awsEnvs := append(reconciler.MakeAWSAuthEnvVars(o.Spec.Auth),
 		reconciler.MakeAWSEndpointEnvVars(o.Spec.Endpoint)...)
 	awsEnvs = maybeSetMessageProcessor(awsEnvs, o)
+	awsEnvs = maybeSetReceiveOptions(awsEnvs, o)
 
 	return append(awsEnvs, corev1.EnvVar{
 		Name:  common.EnvARN,
@@ -37,6 +37,15 @@ const (
 	envBootstrapServers   = "BOOTSTRAP_SERVERS"
 	envTopic              = "TOPIC"
 	envGroupID            = "GROUP_ID"
+	envTopics             = "TOPICS"
+	envTopicPattern       = "TOPIC_PATTERN"
+	envInitialOffset      = "INITIAL_OFFSET"
+	envMaxInFlight        = "MAX_IN_FLIGHT"
+	envEventType          = "EVENT_TYPE"
+	envDeliveryRetries    = "DELIVERY_RETRIES"
+	envDeliveryBackoff    = "DELIVERY_BACKOFF_DELAY"
+	envDeadLetterTopic    = "DEAD_LETTER_TOPIC"
+	envEventSource        = "EVENT_SOURCE"
 	envUsername           = "USERNAME"
 	envPassword           = "PASSWORD"
 	envSecurityMechanisms = "SECURITY_MECHANISMS"
@@ -121,10 +130,6 @@ func MakeAppEnv(o *v1alpha1.KafkaSource) []corev1.EnvVar {
 			Name:  envBootstrapServers,
 			Value: strings.Join(o.Spec.BootstrapServers, ","),
 		},
-		{
-			Name:  envTopic,
-			Value: o.Spec.Topic,
-		},
 		{
 			Name:  envSaslEnable,
 			Value: strconv.FormatBool(o.Spec.Auth.SASLEnable),
@@ -135,6 +140,78 @@ func MakeAppEnv(o *v1alpha1.KafkaSource) []corev1.EnvVar {
 		},
 	}
 
+	if o.Spec.Topic != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envTopic,
+			Value: o.Spec.Topic,
+		})
+	}
+
+	if len(o.Spec.Topics) > 0 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envTopics,
+			Value: strings.Join(o.Spec.Topics, ","),
+		})
+	}
+
+	if o.Spec.TopicPattern != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envTopicPattern,
+			Value: *o.Spec.TopicPattern,
+		})
+	}
+
+	if o.Spec.InitialOffset != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envInitialOffset,
+			Value: *o.Spec.InitialOffset,
+		})
+	}
+
+	if o.Spec.MaxInFlight != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envMaxInFlight,
+			Value: strconv.Itoa(*o.Spec.MaxInFlight),
+		})
+	}
+
+	if d := o.Spec.Delivery; d != nil {
+		if d.Retries != nil {
+			envs = append(envs, corev1.EnvVar{
+				Name:  envDeliveryRetries,
+				Value: strconv.Itoa(int(*d.Retries)),
+			})
+		}
+
+		if d.BackoffDelay != nil {
+			envs = append(envs, corev1.EnvVar{
+				Name:  envDeliveryBackoff,
+				Value: d.BackoffDelay.String(),
+			})
+		}
+
+		if d.DeadLetterTopic != nil {
+			envs = append(envs, corev1.EnvVar{
+				Name:  envDeadLetterTopic,
+				Value: *d.DeadLetterTopic,
+			})
+		}
+	}
+
+	if o.Spec.EventType != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envEventType,
+			Value: *o.Spec.EventType,
+		})
+	}
+
+	if o.Spec.EventSource != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envEventSource,
+			Value: *o.Spec.EventSource,
+		})
+	}
+
 	if o.Spec.Auth.TLSEnable != nil {
 		envs = append(envs, corev1.EnvVar{
 			Name:  envTLSEnable,
@@ -34,6 +34,13 @@ const (
 	envMongoDBURI      = "MONGODB_URI"
 	envMongoDBDatabase = "MONGODB_DATABASE"
 	envMongoCollection = "MONGODB_COLLECTION"
+
+	envMongoDBPipeline      = "MONGODB_PIPELINE"
+	envMongoDBFullDocument  = "MONGODB_FULL_DOCUMENT"
+	envCheckpointConfigMap  = "MONGODB_CHECKPOINT_CONFIGMAP"
+	envCheckpointDatabase   = "MONGODB_CHECKPOINT_DATABASE"
+	envCheckpointCollection = "MONGODB_CHECKPOINT_COLLECTION"
+	envCheckpointFile       = "MONGODB_CHECKPOINT_FILE"
 )
 
 // adapterConfig contains properties used to configure the target's adapter.
@@ -69,5 +76,28 @@ func MakeAppEnv(o *v1alpha1.MongoDBSource) []corev1.EnvVar {
 		{Name: envMongoCollection, Value: o.Spec.Collection},
 	}
 
+	if o.Spec.Pipeline != nil {
+		env = append(env, corev1.EnvVar{Name: envMongoDBPipeline, Value: *o.Spec.Pipeline})
+	}
+	if o.Spec.FullDocument != nil {
+		env = append(env, corev1.EnvVar{Name: envMongoDBFullDocument, Value: *o.Spec.FullDocument})
+	}
+
+	if c := o.Spec.Checkpoint; c != nil {
+		switch {
+		case c.ConfigMap != nil:
+			env = append(env, corev1.EnvVar{Name: envCheckpointConfigMap, Value: *c.ConfigMap})
+
+		case c.Collection != nil:
+			env = append(env, corev1.EnvVar{Name: envCheckpointCollection, Value: c.Collection.Collection})
+			if c.Collection.Database != nil {
+				env = append(env, corev1.EnvVar{Name: envCheckpointDatabase, Value: *c.Collection.Database})
+			}
+
+		case c.File != nil:
+			env = append(env, corev1.EnvVar{Name: envCheckpointFile, Value: *c.File})
+		}
+	}
+
 	return env
 }
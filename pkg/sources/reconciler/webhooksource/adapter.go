@@ -17,6 +17,7 @@ limitations under the License.
 package webhooksource
 
 import (
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +39,9 @@ const (
 	envWebhookBasicAuthUsername            = "WEBHOOK_BASICAUTH_USERNAME"
 	envWebhookBasicAuthPassword            = "WEBHOOK_BASICAUTH_PASSWORD"
 	envCorsAllowOrigin                     = "WEBHOOK_CORS_ALLOW_ORIGIN"
+	envWebhookMaxBodySize                  = "WEBHOOK_MAX_BODY_SIZE"
+	envWebhookRateLimiterRPS               = "WEBHOOK_RATELIMITER_RPS"
+	envWebhookMaxInFlightRequests          = "WEBHOOK_MAX_IN_FLIGHT_REQUESTS"
 )
 
 // adapterConfig contains properties used to configure the adapter.
@@ -119,5 +123,26 @@ func MakeAppEnv(src *v1alpha1.WebhookSource) []corev1.EnvVar {
 		)
 	}
 
+	if size := src.Spec.MaxBodySize; size != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envWebhookMaxBodySize,
+			Value: strconv.FormatInt(*size, 10),
+		})
+	}
+
+	if rl := src.Spec.RateLimiter; rl != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envWebhookRateLimiterRPS,
+			Value: strconv.Itoa(rl.RequestsPerSecond),
+		})
+	}
+
+	if max := src.Spec.MaxInFlightRequests; max != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  envWebhookMaxInFlightRequests,
+			Value: strconv.Itoa(*max),
+		})
+	}
+
 	return envs
 }
@@ -18,7 +18,7 @@ package awsdynamodbtarget
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"net/http"
 
 	"go.uber.org/zap"
@@ -30,10 +30,11 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
@@ -70,12 +71,28 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		dynamodbTable = MustParseDynamoDBResource(a.Resource)
 	}
 
+	switch op := v1alpha1.AWSDynamoDBTargetOperation(env.Operation); op {
+	case v1alpha1.AWSDynamoDBTargetOperationPut,
+		v1alpha1.AWSDynamoDBTargetOperationUpdate,
+		v1alpha1.AWSDynamoDBTargetOperationDelete,
+		v1alpha1.AWSDynamoDBTargetOperationBatchWrite:
+	default:
+		logger.Panicf("Unsupported operation %q", op)
+	}
+
 	return &adapter{
 		awsArnString:         env.AwsTargetArn,
 		awsArn:               a,
 		awsDynamoDBTableName: dynamodbTable,
 		dynamoDBClient:       dynamodb.New(sess, config),
 
+		operation:           v1alpha1.AWSDynamoDBTargetOperation(env.Operation),
+		key:                 env.Key,
+		updateExpression:    env.UpdateExpression,
+		conditionExpression: env.ConditionExpression,
+		expressionNames:     env.ExpressionAttributeNames,
+		expressionValues:    env.ExpressionAttributeValues,
+
 		discardCEContext: env.DiscardCEContext,
 		ceClient:         ceClient,
 		logger:           logger,
@@ -90,7 +107,15 @@ type adapter struct {
 	awsArnString         string
 	awsArn               arn.ARN
 	awsDynamoDBTableName string
-	dynamoDBClient       *dynamodb.DynamoDB
+	dynamoDBClient       dynamodbiface.DynamoDBAPI
+
+	operation           v1alpha1.AWSDynamoDBTargetOperation
+	key                 *tableKey
+	updateExpression    string
+	conditionExpression string
+	expressionNames     stringMap
+	// placeholders of expression values, mapped to paths in the event data
+	expressionValues stringMap
 
 	discardCEContext bool
 	ceClient         cloudevents.Client
@@ -105,34 +130,20 @@ func (a *adapter) Start(ctx context.Context) error {
 }
 
 // Parse and send the aws event
-func (a *adapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
-	var eventJSONMap map[string]interface{}
-
-	if a.discardCEContext {
-		if err := event.DataAs(&eventJSONMap); err != nil {
-			return a.reportError("Error deserializing event data to map", err)
-		}
-	} else {
-		b, err := json.Marshal(event)
-		if err != nil {
-			return a.reportError("Error serializing event to JSON", err)
-		}
-		if err := json.Unmarshal(b, &eventJSONMap); err != nil {
-			return a.reportError("Error deserializing JSON event to map", err)
-		}
+func (a *adapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	var resp interface{}
+	var err error
+
+	switch op := a.operationFor(&event); op {
+	case v1alpha1.AWSDynamoDBTargetOperationPut:
+		resp, err = a.putItem(ctx, &event)
+	case v1alpha1.AWSDynamoDBTargetOperationUpdate:
+		resp, err = a.updateItem(ctx, &event)
+	case v1alpha1.AWSDynamoDBTargetOperationDelete:
+		resp, err = a.deleteItem(ctx, &event)
+	case v1alpha1.AWSDynamoDBTargetOperationBatchWrite:
+		resp, err = a.batchWriteItems(ctx, &event)
 	}
-
-	av, err := dynamodbattribute.MarshalMap(eventJSONMap)
-	if err != nil {
-		return a.reportError("Error marshalling attribute", err)
-	}
-
-	input := &dynamodb.PutItemInput{
-		Item:      av,
-		TableName: &a.awsDynamoDBTableName,
-	}
-
-	resp, err := a.dynamoDBClient.PutItem(input)
 	if err != nil {
 		return a.reportError("Error invoking DynamoDB", err)
 	}
@@ -148,7 +159,23 @@ func (a *adapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudev
 	return &responseEvent, cloudevents.ResultACK
 }
 
+// reportError logs the given error and returns a result which status code
+// indicates whether the event should be redelivered.
 func (a *adapter) reportError(msg string, err error) (*cloudevents.Event, cloudevents.Result) {
 	a.logger.Errorw(msg, zap.Error(err))
-	return nil, cloudevents.NewHTTPResult(http.StatusInternalServerError, msg)
+
+	status := http.StatusInternalServerError
+
+	var invalidErr *invalidEventError
+	var awsErr awserr.Error
+
+	switch {
+	case errors.As(err, &invalidErr):
+		status = http.StatusBadRequest
+	case errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException:
+		// the item was modified by a more recent event, retrying is pointless
+		status = http.StatusPreconditionFailed
+	}
+
+	return nil, cloudevents.NewHTTPResult(status, "%s: %s", msg, err)
 }
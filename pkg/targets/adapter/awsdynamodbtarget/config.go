@@ -17,6 +17,8 @@ limitations under the License.
 package awsdynamodbtarget
 
 import (
+	"encoding/json"
+
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
 )
 
@@ -35,9 +37,28 @@ type envAccessor struct {
 
 	DiscardCEContext bool `envconfig:"AWS_DISCARD_CE_CONTEXT"`
 
+	// Operation performed on the table for events which type doesn't
+	// select an operation.
+	Operation string `envconfig:"DYNAMODB_OPERATION" default:"put"`
+	// Primary key of the table, extracted from the data of events.
+	Key *tableKey `envconfig:"DYNAMODB_KEY"`
+
+	UpdateExpression          string    `envconfig:"DYNAMODB_UPDATE_EXPRESSION"`
+	ConditionExpression       string    `envconfig:"DYNAMODB_CONDITION_EXPRESSION"`
+	ExpressionAttributeNames  stringMap `envconfig:"DYNAMODB_EXPRESSION_ATTRIBUTE_NAMES"`
+	ExpressionAttributeValues stringMap `envconfig:"DYNAMODB_EXPRESSION_ATTRIBUTE_VALUES"`
+
 	// The environment variables below aren't read from the envConfig struct
 	// by the AWS SDK, but rather directly using os.Getenv().
 	// They are nevertheless listed here for documentation purposes.
 	_ string `envconfig:"AWS_ACCESS_KEY_ID"`
 	_ string `envconfig:"AWS_SECRET_ACCESS_KEY"`
 }
+
+// stringMap is a map of strings which is decoded from JSON.
+type stringMap map[string]string
+
+// Decode implements envconfig.Decoder.
+func (m *stringMap) Decode(value string) error {
+	return json.Unmarshal([]byte(value), m)
+}
@@ -0,0 +1,408 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdynamodbtarget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+)
+
+const (
+	// Maximum number of write requests in a BatchWriteItem request.
+	maxBatchWriteItems = 25
+	// Maximum number of attempts at writing the unprocessed items of a
+	// BatchWriteItem request.
+	maxBatchWriteAttempts = 5
+	// Delay before the first retry of unprocessed items, doubled at each
+	// attempt.
+	batchWriteRetryDelay = 50 * time.Millisecond
+)
+
+// expressionTokenRegexp matches the substitution tokens of attribute names
+// and values in DynamoDB expressions.
+var expressionTokenRegexp = regexp.MustCompile(`[#:][A-Za-z0-9_]+`)
+
+// invalidEventError is returned when an event can not be processed because
+// of its contents, in which case retrying is pointless.
+type invalidEventError struct {
+	err error
+}
+
+// Error implements error.
+func (e *invalidEventError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *invalidEventError) Unwrap() error {
+	return e.err
+}
+
+// newInvalidEventError returns an invalidEventError with the given message.
+func newInvalidEventError(format string, a ...interface{}) error {
+	return &invalidEventError{err: fmt.Errorf(format, a...)}
+}
+
+// tableKey is the primary key of a DynamoDB table.
+type tableKey v1alpha1.AWSDynamoDBTargetKey
+
+// Decode implements envconfig.Decoder.
+func (k *tableKey) Decode(value string) error {
+	return json.Unmarshal([]byte(value), k)
+}
+
+// extract returns the key of the item described by the given JSON document.
+func (k *tableKey) extract(data []byte) (map[string]*dynamodb.AttributeValue, error) {
+	attrs := []v1alpha1.AWSDynamoDBTargetKeyAttribute{k.PartitionKey}
+	if k.SortKey != nil {
+		attrs = append(attrs, *k.SortKey)
+	}
+
+	key := make(map[string]*dynamodb.AttributeValue, len(attrs))
+
+	for _, attr := range attrs {
+		path := attr.Name
+		if attr.Path != nil {
+			path = *attr.Path
+		}
+
+		res := gjson.GetBytes(data, path)
+		if !res.Exists() {
+			return nil, newInvalidEventError("key attribute %q not found at path %q", attr.Name, path)
+		}
+
+		switch res.Type {
+		case gjson.String:
+			key[attr.Name] = &dynamodb.AttributeValue{S: aws.String(res.Str)}
+		case gjson.Number:
+			key[attr.Name] = &dynamodb.AttributeValue{N: aws.String(res.Raw)}
+		default:
+			return nil, newInvalidEventError("key attribute %q at path %q is neither a string nor a number",
+				attr.Name, path)
+		}
+	}
+
+	return key, nil
+}
+
+// attributeValue returns the DynamoDB representation of the given JSON value.
+func attributeValue(res gjson.Result) (*dynamodb.AttributeValue, error) {
+	switch res.Type {
+	case gjson.String:
+		return &dynamodb.AttributeValue{S: aws.String(res.Str)}, nil
+	case gjson.Number:
+		// preserves the precision of large integers
+		return &dynamodb.AttributeValue{N: aws.String(res.Raw)}, nil
+	}
+	return dynamodbattribute.Marshal(res.Value())
+}
+
+// expressionAttributes holds the parameters of the expressions of a request.
+type expressionAttributes struct {
+	names  map[string]*string
+	values map[string]*dynamodb.AttributeValue
+}
+
+// expressionAttributes returns the attribute names and values referenced in
+// the given expressions. Values are selected from the given JSON document.
+//
+// DynamoDB rejects requests containing unused attribute names or values,
+// which is why only attributes referenced in the given expressions are
+// returned.
+func (a *adapter) expressionAttributes(data []byte, exprs ...string) (*expressionAttributes, error) {
+	attrs := &expressionAttributes{}
+
+	for _, expr := range exprs {
+		for _, token := range expressionTokenRegexp.FindAllString(expr, -1) {
+			if name, ok := a.expressionNames[token]; ok {
+				if attrs.names == nil {
+					attrs.names = make(map[string]*string)
+				}
+				attrs.names[token] = aws.String(name)
+				continue
+			}
+
+			path, ok := a.expressionValues[token]
+			if !ok {
+				continue
+			}
+
+			res := gjson.GetBytes(data, path)
+			if !res.Exists() {
+				return nil, newInvalidEventError("value of %q not found at path %q", token, path)
+			}
+
+			val, err := attributeValue(res)
+			if err != nil {
+				return nil, newInvalidEventError("converting value of %q: %w", token, err)
+			}
+
+			if attrs.values == nil {
+				attrs.values = make(map[string]*dynamodb.AttributeValue)
+			}
+			attrs.values[token] = val
+		}
+	}
+
+	return attrs, nil
+}
+
+// operationFor returns the operation to perform for the given event.
+func (a *adapter) operationFor(event *cloudevents.Event) v1alpha1.AWSDynamoDBTargetOperation {
+	switch event.Type() {
+	case v1alpha1.EventTypeAWSDynamoDBPut:
+		return v1alpha1.AWSDynamoDBTargetOperationPut
+	case v1alpha1.EventTypeAWSDynamoDBUpdate:
+		return v1alpha1.AWSDynamoDBTargetOperationUpdate
+	case v1alpha1.EventTypeAWSDynamoDBDelete:
+		return v1alpha1.AWSDynamoDBTargetOperationDelete
+	case v1alpha1.EventTypeAWSDynamoDBBatchWrite:
+		return v1alpha1.AWSDynamoDBTargetOperationBatchWrite
+	}
+	return a.operation
+}
+
+// putItem creates or replaces the item represented by the given event.
+func (a *adapter) putItem(ctx context.Context, event *cloudevents.Event) (interface{}, error) {
+	var eventJSONMap map[string]interface{}
+
+	if a.discardCEContext {
+		if err := event.DataAs(&eventJSONMap); err != nil {
+			return nil, newInvalidEventError("deserializing event data to map: %w", err)
+		}
+	} else {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return nil, newInvalidEventError("serializing event to JSON: %w", err)
+		}
+		if err := json.Unmarshal(b, &eventJSONMap); err != nil {
+			return nil, newInvalidEventError("deserializing JSON event to map: %w", err)
+		}
+	}
+
+	av, err := dynamodbattribute.MarshalMap(eventJSONMap)
+	if err != nil {
+		return nil, newInvalidEventError("marshalling attribute: %w", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:      av,
+		TableName: &a.awsDynamoDBTableName,
+	}
+
+	if a.conditionExpression != "" {
+		attrs, err := a.expressionAttributes(event.Data(), a.conditionExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		input.ConditionExpression = &a.conditionExpression
+		input.ExpressionAttributeNames = attrs.names
+		input.ExpressionAttributeValues = attrs.values
+	}
+
+	return a.dynamoDBClient.PutItemWithContext(ctx, input)
+}
+
+// updateItem updates the attributes of the item identified by the data of the
+// given event, using the update expression.
+func (a *adapter) updateItem(ctx context.Context, event *cloudevents.Event) (interface{}, error) {
+	if a.updateExpression == "" {
+		return nil, newInvalidEventError("update operations require an update expression")
+	}
+
+	key, err := a.itemKey(event.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := a.expressionAttributes(event.Data(), a.updateExpression, a.conditionExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key:                       key,
+		TableName:                 &a.awsDynamoDBTableName,
+		UpdateExpression:          &a.updateExpression,
+		ExpressionAttributeNames:  attrs.names,
+		ExpressionAttributeValues: attrs.values,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	}
+	if a.conditionExpression != "" {
+		input.ConditionExpression = &a.conditionExpression
+	}
+
+	return a.dynamoDBClient.UpdateItemWithContext(ctx, input)
+}
+
+// deleteItem deletes the item identified by the data of the given event.
+func (a *adapter) deleteItem(ctx context.Context, event *cloudevents.Event) (interface{}, error) {
+	key, err := a.itemKey(event.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dynamodb.DeleteItemInput{
+		Key:          key,
+		TableName:    &a.awsDynamoDBTableName,
+		ReturnValues: aws.String(dynamodb.ReturnValueAllOld),
+	}
+
+	if a.conditionExpression != "" {
+		attrs, err := a.expressionAttributes(event.Data(), a.conditionExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		input.ConditionExpression = &a.conditionExpression
+		input.ExpressionAttributeNames = attrs.names
+		input.ExpressionAttributeValues = attrs.values
+	}
+
+	return a.dynamoDBClient.DeleteItemWithContext(ctx, input)
+}
+
+// itemKey returns the key of the item described by the given JSON document.
+func (a *adapter) itemKey(data []byte) (map[string]*dynamodb.AttributeValue, error) {
+	if a.key == nil {
+		return nil, newInvalidEventError("the key of the table must be configured to identify items")
+	}
+	return a.key.extract(data)
+}
+
+// batchWriteRequest is an element of the JSON array carried by batch write
+// events. Exactly one of its fields must be set.
+type batchWriteRequest struct {
+	// Item to create or replace.
+	Put map[string]interface{} `json:"put"`
+	// Document containing the key of the item to delete.
+	Delete json.RawMessage `json:"delete"`
+}
+
+// batchWriteResult is the result of a batch write.
+type batchWriteResult struct {
+	Written  int                          `json:"written"`
+	Capacity []*dynamodb.ConsumedCapacity `json:"consumedCapacity,omitempty"`
+}
+
+// batchWriteItems puts and deletes the items described by the data of the
+// given event, in batches of up to 25 write requests.
+//
+// Condition expressions are not supported by the BatchWriteItem API.
+func (a *adapter) batchWriteItems(ctx context.Context, event *cloudevents.Event) (interface{}, error) {
+	var reqs []batchWriteRequest
+	if err := json.Unmarshal(event.Data(), &reqs); err != nil {
+		return nil, newInvalidEventError("deserializing event data to an array of write requests: %w", err)
+	}
+
+	writeReqs := make([]*dynamodb.WriteRequest, 0, len(reqs))
+
+	for i, req := range reqs {
+		switch {
+		case req.Put != nil && req.Delete == nil:
+			item, err := dynamodbattribute.MarshalMap(req.Put)
+			if err != nil {
+				return nil, newInvalidEventError("marshalling item of write request %d: %w", i, err)
+			}
+			writeReqs = append(writeReqs, &dynamodb.WriteRequest{
+				PutRequest: &dynamodb.PutRequest{Item: item},
+			})
+
+		case req.Delete != nil && req.Put == nil:
+			key, err := a.itemKey(req.Delete)
+			if err != nil {
+				return nil, fmt.Errorf("write request %d: %w", i, err)
+			}
+			writeReqs = append(writeReqs, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: key},
+			})
+
+		default:
+			return nil, newInvalidEventError("write request %d must contain either a put or a delete request", i)
+		}
+	}
+
+	res := &batchWriteResult{}
+
+	for start := 0; start < len(writeReqs); start += maxBatchWriteItems {
+		end := start + maxBatchWriteItems
+		if end > len(writeReqs) {
+			end = len(writeReqs)
+		}
+
+		capacity, err := a.batchWrite(ctx, writeReqs[start:end])
+		res.Capacity = append(res.Capacity, capacity...)
+		if err != nil {
+			return nil, fmt.Errorf("writing items %d to %d: %w", start, end-1, err)
+		}
+
+		res.Written = end
+	}
+
+	return res, nil
+}
+
+// batchWrite sends the given write requests in a single BatchWriteItem
+// request, retrying unprocessed items with an exponential backoff.
+func (a *adapter) batchWrite(ctx context.Context, reqs []*dynamodb.WriteRequest) ([]*dynamodb.ConsumedCapacity, error) {
+	var capacity []*dynamodb.ConsumedCapacity
+
+	delay := batchWriteRetryDelay
+	items := map[string][]*dynamodb.WriteRequest{a.awsDynamoDBTableName: reqs}
+
+	for attempt := 1; ; attempt++ {
+		out, err := a.dynamoDBClient.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems:           items,
+			ReturnConsumedCapacity: aws.String(dynamodb.ReturnConsumedCapacityTotal),
+		})
+		if err != nil {
+			return capacity, err
+		}
+		capacity = append(capacity, out.ConsumedCapacity...)
+
+		items = out.UnprocessedItems
+		if len(items[a.awsDynamoDBTableName]) == 0 {
+			return capacity, nil
+		}
+
+		if attempt == maxBatchWriteAttempts {
+			return capacity, fmt.Errorf("%d items remained unprocessed after %d attempts",
+				len(items[a.awsDynamoDBTableName]), maxBatchWriteAttempts)
+		}
+
+		select {
+		case <-ctx.Done():
+			return capacity, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
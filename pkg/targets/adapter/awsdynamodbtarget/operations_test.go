@@ -0,0 +1,295 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdynamodbtarget
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	loggingtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+)
+
+const tstTable = "orders"
+
+func TestDispatch(t *testing.T) {
+	key := &tableKey{
+		PartitionKey: v1alpha1.AWSDynamoDBTargetKeyAttribute{
+			Name: "pk",
+			Path: aws.String("order.id"),
+		},
+		SortKey: &v1alpha1.AWSDynamoDBTargetKeyAttribute{
+			Name: "region",
+		},
+	}
+
+	testCases := map[string]struct {
+		operation           v1alpha1.AWSDynamoDBTargetOperation
+		eventType           string
+		data                string
+		key                 *tableKey
+		updateExpression    string
+		conditionExpression string
+		clientErr           error
+
+		expectStatus int
+		check        func(*testing.T, *mockDynamoDBClient)
+	}{
+		"put from spec": {
+			operation:           v1alpha1.AWSDynamoDBTargetOperationPut,
+			eventType:           "some.type",
+			data:                `{"order":{"id":"o-1","version":2}}`,
+			conditionExpression: "attribute_not_exists(pk) OR version < :version",
+			check: func(t *testing.T, c *mockDynamoDBClient) {
+				require.NotNil(t, c.put)
+				assert.Equal(t, map[string]*dynamodb.AttributeValue{
+					":version": {N: aws.String("2")},
+				}, c.put.ExpressionAttributeValues)
+				assert.Nil(t, c.put.ExpressionAttributeNames)
+				assert.Contains(t, c.put.Item, "order")
+			},
+		},
+		"update from event type": {
+			operation:           v1alpha1.AWSDynamoDBTargetOperationPut,
+			eventType:           v1alpha1.EventTypeAWSDynamoDBUpdate,
+			data:                `{"order":{"id":"o-1","status":"shipped","version":2},"region":"eu"}`,
+			key:                 key,
+			updateExpression:    "SET #st = :status, version = :version",
+			conditionExpression: "version < :version",
+			check: func(t *testing.T, c *mockDynamoDBClient) {
+				require.NotNil(t, c.update)
+				assert.Equal(t, map[string]*dynamodb.AttributeValue{
+					"pk":     {S: aws.String("o-1")},
+					"region": {S: aws.String("eu")},
+				}, c.update.Key)
+				assert.Equal(t, map[string]*string{"#st": aws.String("status")}, c.update.ExpressionAttributeNames)
+				assert.Equal(t, map[string]*dynamodb.AttributeValue{
+					":status":  {S: aws.String("shipped")},
+					":version": {N: aws.String("2")},
+				}, c.update.ExpressionAttributeValues)
+				assert.Equal(t, "version < :version", *c.update.ConditionExpression)
+			},
+		},
+		"update without expression": {
+			operation:    v1alpha1.AWSDynamoDBTargetOperationUpdate,
+			data:         `{"order":{"id":"o-1"},"region":"eu"}`,
+			key:          key,
+			expectStatus: http.StatusBadRequest,
+		},
+		"delete with missing key": {
+			operation:    v1alpha1.AWSDynamoDBTargetOperationDelete,
+			data:         `{"order":{"id":"o-1"}}`,
+			key:          key,
+			expectStatus: http.StatusBadRequest,
+		},
+		"delete without key": {
+			operation:    v1alpha1.AWSDynamoDBTargetOperationDelete,
+			data:         `{"order":{"id":"o-1"}}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		"delete with failed condition": {
+			operation:           v1alpha1.AWSDynamoDBTargetOperationDelete,
+			data:                `{"order":{"id":123456789012345678},"region":"eu","version":3}`,
+			key:                 key,
+			conditionExpression: "version < :version",
+			clientErr:           awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "failed", nil),
+			expectStatus:        http.StatusPreconditionFailed,
+			check: func(t *testing.T, c *mockDynamoDBClient) {
+				require.NotNil(t, c.delete)
+				assert.Equal(t, map[string]*dynamodb.AttributeValue{
+					"pk":     {N: aws.String("123456789012345678")},
+					"region": {S: aws.String("eu")},
+				}, c.delete.Key)
+			},
+		},
+		"batch write": {
+			operation: v1alpha1.AWSDynamoDBTargetOperationPut,
+			eventType: v1alpha1.EventTypeAWSDynamoDBBatchWrite,
+			data:      `[{"put":{"pk":"o-1","region":"eu"}},{"delete":{"order":{"id":"o-2"},"region":"us"}}]`,
+			key:       key,
+			check: func(t *testing.T, c *mockDynamoDBClient) {
+				require.Len(t, c.batches, 1)
+				reqs := c.batches[0].RequestItems[tstTable]
+				require.Len(t, reqs, 2)
+				assert.Equal(t, "o-1", *reqs[0].PutRequest.Item["pk"].S)
+				assert.Equal(t, map[string]*dynamodb.AttributeValue{
+					"pk":     {S: aws.String("o-2")},
+					"region": {S: aws.String("us")},
+				}, reqs[1].DeleteRequest.Key)
+			},
+		},
+		"batch write with invalid request": {
+			operation:    v1alpha1.AWSDynamoDBTargetOperationBatchWrite,
+			data:         `[{"put":{"pk":"o-1"},"delete":{"pk":"o-1"}}]`,
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			client := &mockDynamoDBClient{err: tc.clientErr}
+
+			a := &adapter{
+				awsArnString:         "arn:aws:dynamodb:us-east-1:123456789012:table/" + tstTable,
+				awsDynamoDBTableName: tstTable,
+				dynamoDBClient:       client,
+				operation:            tc.operation,
+				key:                  tc.key,
+				updateExpression:     tc.updateExpression,
+				conditionExpression:  tc.conditionExpression,
+				expressionNames:      stringMap{"#st": "status"},
+				expressionValues: stringMap{
+					":status":  "order.status",
+					":version": "order.version",
+				},
+				discardCEContext: true,
+				logger:           loggingtesting.TestLogger(t),
+			}
+			if tc.operation == v1alpha1.AWSDynamoDBTargetOperationDelete {
+				a.expressionValues[":version"] = "version"
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("1234")
+			event.SetSource("test.source")
+			event.SetType("some.type")
+			if tc.eventType != "" {
+				event.SetType(tc.eventType)
+			}
+			require.NoError(t, event.SetData(cloudevents.ApplicationJSON, []byte(tc.data)))
+
+			resp, res := a.dispatch(context.Background(), event)
+
+			if tc.expectStatus != 0 {
+				assert.Nil(t, resp)
+				var httpRes *cehttp.Result
+				require.True(t, protocol.ResultAs(res, &httpRes), "result is not a HTTP result")
+				assert.Equal(t, tc.expectStatus, httpRes.StatusCode)
+			} else {
+				assert.True(t, protocol.IsACK(res))
+				require.NotNil(t, resp)
+				assert.Equal(t, v1alpha1.EventTypeAWSDynamoDBResult, resp.Type())
+			}
+
+			if tc.check != nil {
+				tc.check(t, client)
+			}
+		})
+	}
+}
+
+func TestBatchWriteUnprocessedItems(t *testing.T) {
+	client := &mockDynamoDBClient{unprocessed: 2}
+
+	a := &adapter{
+		awsDynamoDBTableName: tstTable,
+		dynamoDBClient:       client,
+		logger:               loggingtesting.TestLogger(t),
+	}
+
+	data := make([]byte, 0, 1024)
+	data = append(data, '[')
+	for i := 0; i < 30; i++ {
+		if i > 0 {
+			data = append(data, ',')
+		}
+		data = append(data, `{"put":{"pk":"item"}}`...)
+	}
+	data = append(data, ']')
+
+	event := cloudevents.NewEvent()
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON, data))
+
+	res, err := a.batchWriteItems(context.Background(), &event)
+	require.NoError(t, err)
+	assert.Equal(t, 30, res.(*batchWriteResult).Written)
+
+	// 2 batches of 25 and 5 items, the first one being retried twice
+	require.Len(t, client.batches, 4)
+	assert.Len(t, client.batches[0].RequestItems[tstTable], 25)
+	assert.Len(t, client.batches[1].RequestItems[tstTable], 1)
+	assert.Len(t, client.batches[2].RequestItems[tstTable], 1)
+	assert.Len(t, client.batches[3].RequestItems[tstTable], 5)
+}
+
+// mockDynamoDBClient records the requests it receives.
+type mockDynamoDBClient struct {
+	dynamodbiface.DynamoDBAPI
+
+	put     *dynamodb.PutItemInput
+	update  *dynamodb.UpdateItemInput
+	delete  *dynamodb.DeleteItemInput
+	batches []*dynamodb.BatchWriteItemInput
+
+	// number of times a single item is returned as unprocessed
+	unprocessed int
+
+	err error
+}
+
+func (c *mockDynamoDBClient) PutItemWithContext(_ aws.Context, in *dynamodb.PutItemInput,
+	_ ...request.Option) (*dynamodb.PutItemOutput, error) {
+
+	c.put = in
+	return &dynamodb.PutItemOutput{}, c.err
+}
+
+func (c *mockDynamoDBClient) UpdateItemWithContext(_ aws.Context, in *dynamodb.UpdateItemInput,
+	_ ...request.Option) (*dynamodb.UpdateItemOutput, error) {
+
+	c.update = in
+	return &dynamodb.UpdateItemOutput{}, c.err
+}
+
+func (c *mockDynamoDBClient) DeleteItemWithContext(_ aws.Context, in *dynamodb.DeleteItemInput,
+	_ ...request.Option) (*dynamodb.DeleteItemOutput, error) {
+
+	c.delete = in
+	return &dynamodb.DeleteItemOutput{}, c.err
+}
+
+func (c *mockDynamoDBClient) BatchWriteItemWithContext(_ aws.Context, in *dynamodb.BatchWriteItemInput,
+	_ ...request.Option) (*dynamodb.BatchWriteItemOutput, error) {
+
+	c.batches = append(c.batches, in)
+
+	out := &dynamodb.BatchWriteItemOutput{}
+	if c.unprocessed > 0 {
+		c.unprocessed--
+		out.UnprocessedItems = map[string][]*dynamodb.WriteRequest{
+			tstTable: in.RequestItems[tstTable][:1],
+		}
+	}
+
+	return out, c.err
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
@@ -71,10 +72,38 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		config.Credentials = stscreds.NewCredentials(sess, env.AssumeIamRole)
 	}
 
+	s3Client := s3.New(sess, config)
+	bucket := strings.Split(a.Resource, "/")[0]
+
+	keyTmplText := env.KeyTemplate
+	if keyTmplText == "" && env.Batching {
+		keyTmplText = defaultBatchKeyTemplate
+	}
+
+	var keyTmpl *keyTemplate
+	if keyTmplText != "" {
+		if keyTmpl, err = newKeyTemplate(keyTmplText); err != nil {
+			logger.Panicw("Invalid key template", zap.Error(err))
+		}
+	}
+
+	var b *batcher
+	if env.Batching {
+		b, err = newBatcher(s3Client, bucket, env.BatchMaxEvents, env.BatchMaxBytes, env.BatchMaxAge,
+			env.BatchCompression, logger)
+		if err != nil {
+			logger.Panicw("Invalid batching configuration", zap.Error(err))
+		}
+	}
+
 	return &adapter{
 		awsArnString: env.AwsTargetArn,
 		awsArn:       a,
-		s3Client:     s3.New(sess, config),
+		bucket:       bucket,
+		s3Client:     s3Client,
+
+		keyTmpl: keyTmpl,
+		batcher: b,
 
 		discardCEContext: env.DiscardCEContext,
 		ceClient:         ceClient,
@@ -86,10 +115,19 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 
 var _ pkgadapter.Adapter = (*adapter)(nil)
 
+// Key prefix of batch objects when no key template is set.
+const defaultBatchKeyTemplate = "{{.type}}"
+
 type adapter struct {
 	awsArnString string
 	awsArn       arn.ARN
-	s3Client     *s3.S3
+	bucket       string
+	s3Client     s3iface.S3API
+
+	// generates object keys, or key prefixes in batching mode
+	keyTmpl *keyTemplate
+	// buffers events into batch objects, if batching is enabled
+	batcher *batcher
 
 	discardCEContext bool
 	ceClient         cloudevents.Client
@@ -100,11 +138,23 @@ type adapter struct {
 
 func (a *adapter) Start(ctx context.Context) error {
 	a.logger.Info("Starting AWS S3 Target adapter")
-	return a.ceClient.StartReceiver(ctx, a.dispatch)
+
+	err := a.ceClient.StartReceiver(ctx, a.dispatch)
+
+	if a.batcher != nil {
+		a.logger.Info("Writing pending batches to S3")
+		a.batcher.flush(context.Background())
+	}
+
+	return err
 }
 
 // Parse and send the aws event
-func (a *adapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+func (a *adapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	if a.batcher != nil {
+		return a.dispatchBatched(ctx, &event)
+	}
+
 	var dataReader *bytes.Reader
 	if event.Type() == v1alpha1.EventTypeAWSS3Put || a.discardCEContext {
 		dataReader = bytes.NewReader(event.Data())
@@ -116,14 +166,21 @@ func (a *adapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudev
 		dataReader = bytes.NewReader(d)
 	}
 
-	key := event.Subject()
-	if key == "" {
-		key = event.Type() + "/" + event.Source() + "/" + event.Time().String()
+	var key string
+	if a.keyTmpl != nil {
+		var err error
+		if key, err = a.keyTmpl.render(&event); err != nil {
+			return a.reportError("error generating object key", err)
+		}
+	} else {
+		key = event.Subject()
+		if key == "" {
+			key = event.Type() + "/" + event.Source() + "/" + event.Time().String()
+		}
 	}
 
-	bucket := strings.Split(a.awsArn.Resource, "/")[0]
 	putInput := s3.PutObjectInput{
-		Bucket: &bucket,
+		Bucket: &a.bucket,
 		Key:    &key,
 		Body:   dataReader,
 	}
@@ -144,6 +201,45 @@ func (a *adapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudev
 	return &responseEvent, cloudevents.ResultACK
 }
 
+// dispatchBatched buffers the given event into the batch object matching its
+// key prefix, and replies once this object was written.
+func (a *adapter) dispatchBatched(ctx context.Context, event *cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	prefix, err := a.keyTmpl.render(event)
+	if err != nil {
+		return a.reportError("error generating object key prefix", err)
+	}
+
+	var record []byte
+	if event.Type() == v1alpha1.EventTypeAWSS3Put || a.discardCEContext {
+		record, err = ndjsonRecord(event.Data())
+	} else {
+		record, err = json.Marshal(event)
+	}
+	if err != nil {
+		return a.reportError("error serializing CloudEvent", err)
+	}
+
+	if err := a.batcher.add(ctx, prefix, record); err != nil {
+		return a.reportError("error writing batch to s3 bucket", err)
+	}
+
+	return nil, cloudevents.ResultACK
+}
+
+// ndjsonRecord returns the given event data as a single-line JSON value.
+// Data which isn't valid JSON is encoded as a JSON string.
+func ndjsonRecord(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return json.Marshal(string(data))
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (a *adapter) reportError(msg string, err error) (*cloudevents.Event, cloudevents.Result) {
 	a.logger.Errorw(msg, zap.Error(err))
 	return nil, cloudevents.NewHTTPResult(http.StatusInternalServerError, msg)
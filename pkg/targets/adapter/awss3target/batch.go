@@ -0,0 +1,230 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awss3target
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
+)
+
+const (
+	// Content type of batch objects.
+	ndjsonContentType = "application/x-ndjson"
+
+	// Calls to PutObject are cancelled when they exceed this duration.
+	uploadTimeout = 1 * time.Minute
+
+	// Number of times batches which failed to be written because of a
+	// transient error are retried.
+	uploadMaxRetries = 3
+)
+
+// Supported compressions of batch objects.
+const (
+	compressionNone = "none"
+	compressionGzip = "gzip"
+)
+
+// batcher buffers NDJSON records by key prefix, and writes each buffer to a
+// new S3 object once it reaches either a number of records, a size or an age
+// limit. Callers are blocked until the object containing their record was
+// written.
+type batcher struct {
+	s3Client s3iface.S3API
+	bucket   string
+	cfg      batch.Config
+
+	logger *zap.SugaredLogger
+
+	m        sync.Mutex
+	prefixes map[ /*key prefix*/ string]*prefixBatcher
+}
+
+// prefixBatcher buffers the records of a single key prefix.
+type prefixBatcher struct {
+	*batch.Batcher
+	// number of callers with a record in this buffer
+	users int
+}
+
+// newBatcher returns a batcher which writes objects to the given bucket.
+func newBatcher(cli s3iface.S3API, bucket string, maxEvents, maxBytes int, maxAge time.Duration,
+	compression string, logger *zap.SugaredLogger) (*batcher, error) {
+
+	var useGzip bool
+	switch compression {
+	case "", compressionNone:
+	case compressionGzip:
+		useGzip = true
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+
+	return &batcher{
+		s3Client: cli,
+		bucket:   bucket,
+		cfg: batch.Config{
+			MaxEvents:  maxEvents,
+			MaxBytes:   maxBytes,
+			Linger:     maxAge,
+			Compress:   useGzip,
+			MaxRetries: uploadMaxRetries,
+		},
+		logger:   logger,
+		prefixes: make(map[string]*prefixBatcher),
+	}, nil
+}
+
+// add appends the given record to the batch of the given key prefix, and
+// blocks until this batch was written to S3, or until the context is done.
+func (b *batcher) add(ctx context.Context, prefix string, record []byte) error {
+	pb := b.acquire(prefix)
+	defer b.release(prefix, pb)
+
+	_, err := pb.Add(ctx, record)
+	return err
+}
+
+// acquire returns the buffer of the given key prefix.
+func (b *batcher) acquire(prefix string) *prefixBatcher {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	pb, ok := b.prefixes[prefix]
+	if !ok {
+		pb = &prefixBatcher{
+			Batcher: batch.New(b.sender(prefix), batch.EncodeNDJSON, b.cfg),
+		}
+		b.prefixes[prefix] = pb
+	}
+	pb.users++
+
+	return pb
+}
+
+// release discards the buffer of the given key prefix once it no longer
+// contains any record, so that key prefixes which are not generated anymore,
+// such as time-based ones, don't accumulate.
+func (b *batcher) release(prefix string, pb *prefixBatcher) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if pb.users--; pb.users == 0 {
+		delete(b.prefixes, prefix)
+	}
+}
+
+// flush writes all pending batches to S3, and waits for the completion of
+// uploads in progress.
+func (b *batcher) flush(ctx context.Context) {
+	b.m.Lock()
+	pending := make([]*prefixBatcher, 0, len(b.prefixes))
+	for _, pb := range b.prefixes {
+		pending = append(pending, pb)
+	}
+	b.m.Unlock()
+
+	for _, pb := range pending {
+		pb.Flush(ctx)
+	}
+}
+
+// sender returns a batch.SendFunc which writes batches to new S3 objects
+// with the given key prefix.
+func (b *batcher) sender(prefix string) batch.SendFunc {
+	return func(ctx context.Context, req *batch.Request) ([]byte, error) {
+		return nil, b.upload(ctx, prefix, req)
+	}
+}
+
+// upload writes the given batch to a new S3 object.
+func (b *batcher) upload(ctx context.Context, prefix string, req *batch.Request) error {
+	in := &s3.PutObjectInput{
+		Bucket:      &b.bucket,
+		Key:         aws.String(b.objectKey(prefix)),
+		ContentType: aws.String(ndjsonContentType),
+		Body:        bytes.NewReader(req.Body),
+	}
+	if req.ContentEncoding != "" {
+		in.ContentEncoding = aws.String(req.ContentEncoding)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+
+	if _, err := b.s3Client.PutObjectWithContext(ctx, in); err != nil {
+		b.logger.Errorw("Failed to write batch to S3", zap.Error(err),
+			zap.String("key", *in.Key), zap.Int("events", len(req.Items)))
+
+		retryable := isRetryable(err)
+		if err = fmt.Errorf("writing object %q: %w", *in.Key, err); retryable {
+			return batch.Retryable(err)
+		}
+		return err
+	}
+
+	b.logger.Debugw("Wrote batch to S3", zap.String("key", *in.Key), zap.Int("events", len(req.Items)))
+
+	return nil
+}
+
+// isRetryable returns whether the given error returned by the S3 client is
+// transient.
+func isRetryable(err error) bool {
+	if request.IsErrorRetryable(err) || request.IsErrorThrottle(err) {
+		return true
+	}
+
+	var reqErr awserr.RequestFailure
+	return errors.As(err, &reqErr) && batch.IsRetryableStatus(reqErr.StatusCode())
+}
+
+// objectKey returns a unique object key with the given prefix.
+func (b *batcher) objectKey(prefix string) string {
+	var key strings.Builder
+
+	key.WriteString(prefix)
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		key.WriteByte('/')
+	}
+
+	key.WriteString(time.Now().UTC().Format("20060102T150405Z"))
+	key.WriteByte('-')
+	key.WriteString(uuid.New().String())
+	key.WriteString(".ndjson")
+	if b.cfg.Compress {
+		key.WriteString(".gz")
+	}
+
+	return key.String()
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awss3target
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	loggingtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestBatcher(t *testing.T) {
+	const bucket = "my-bucket"
+
+	t.Run("flush on max events", func(t *testing.T) {
+		cli := &mockS3Client{}
+
+		b, err := newBatcher(cli, bucket, 2, 1<<20, time.Hour, compressionNone, loggingtesting.TestLogger(t))
+		require.NoError(t, err)
+
+		errA1 := addAsync(b, "type-a", `{"n":1}`)
+		errB := addAsync(b, "type-b", `{"n":2}`)
+		errA2 := addAsync(b, "type-a", `{"n":3}`)
+
+		require.NoError(t, <-errA1)
+		require.NoError(t, <-errA2)
+
+		objs := cli.objects()
+		require.Len(t, objs, 1)
+		assert.True(t, strings.HasPrefix(objs[0].key, "type-a/"), "Unexpected key %q", objs[0].key)
+		assert.True(t, strings.HasSuffix(objs[0].key, ".ndjson"), "Unexpected key %q", objs[0].key)
+		assert.Equal(t, ndjsonContentType, objs[0].contentType)
+		assert.ElementsMatch(t, []string{`{"n":1}`, `{"n":3}`}, records(objs[0].body))
+
+		// replies are held until the batch is written
+		require.Eventually(t, func() bool {
+			return b.users("type-b") == 1
+		}, time.Second, time.Millisecond)
+		select {
+		case <-errB:
+			assert.Fail(t, "Event replied to before its batch was written")
+		default:
+		}
+
+		b.flush(context.Background())
+		require.NoError(t, <-errB)
+
+		objs = cli.objects()
+		require.Len(t, objs, 2)
+		assert.True(t, strings.HasPrefix(objs[1].key, "type-b/"), "Unexpected key %q", objs[1].key)
+		assert.Equal(t, "{\"n\":2}\n", objs[1].body)
+
+		assert.Empty(t, b.prefixes, "Buffers of written batches are discarded")
+	})
+
+	t.Run("flush on max bytes", func(t *testing.T) {
+		cli := &mockS3Client{}
+
+		b, err := newBatcher(cli, bucket, 1000, 10, time.Hour, compressionNone, loggingtesting.TestLogger(t))
+		require.NoError(t, err)
+
+		require.NoError(t, b.add(context.Background(), "prefix/", []byte(`"longer than 10 bytes"`)))
+
+		require.Len(t, cli.objects(), 1)
+		assert.True(t, strings.HasPrefix(cli.objects()[0].key, "prefix/2"), "Unexpected key %q", cli.objects()[0].key)
+	})
+
+	t.Run("flush on max age with gzip", func(t *testing.T) {
+		cli := &mockS3Client{}
+
+		b, err := newBatcher(cli, bucket, 1000, 1<<20, 10*time.Millisecond, compressionGzip, loggingtesting.TestLogger(t))
+		require.NoError(t, err)
+
+		require.NoError(t, b.add(context.Background(), "type-a", []byte(`{"n":1}`)))
+
+		require.Len(t, cli.objects(), 1)
+		obj := cli.objects()[0]
+		assert.True(t, strings.HasSuffix(obj.key, ".ndjson.gz"), "Unexpected key %q", obj.key)
+		assert.Equal(t, compressionGzip, obj.contentEncoding)
+
+		zr, err := gzip.NewReader(strings.NewReader(obj.body))
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, "{\"n\":1}\n", string(body))
+
+		b.flush(context.Background())
+		assert.Len(t, cli.objects(), 1, "Expired batch shouldn't be written twice")
+	})
+
+	t.Run("upload failures", func(t *testing.T) {
+		testCases := map[string]struct {
+			err            error
+			expectAttempts int
+			expectErr      bool
+		}{
+			"transient error": {
+				err:            awserr.NewRequestFailure(awserr.New("SlowDown", "Please reduce your request rate.", nil), 503, ""),
+				expectAttempts: 2,
+			},
+			"throttling": {
+				err:            awserr.New("Throttling", "Rate exceeded", nil),
+				expectAttempts: 2,
+			},
+			"permanent error": {
+				err:            awserr.NewRequestFailure(awserr.New("AccessDenied", "Access Denied", nil), 403, ""),
+				expectAttempts: 1,
+				expectErr:      true,
+			},
+		}
+
+		for name, tc := range testCases {
+			//nolint:scopelint
+			t.Run(name, func(t *testing.T) {
+				cli := &mockS3Client{errs: []error{tc.err}}
+
+				b, err := newBatcher(cli, bucket, 1, 1<<20, time.Hour, compressionNone, loggingtesting.TestLogger(t))
+				require.NoError(t, err)
+				b.cfg.RetryBackoff = time.Millisecond
+
+				err = b.add(context.Background(), "type-a", []byte(`{"n":1}`))
+				if tc.expectErr {
+					assert.Error(t, err)
+					assert.Empty(t, cli.objects())
+				} else {
+					assert.NoError(t, err)
+					assert.Len(t, cli.objects(), 1)
+				}
+				assert.Equal(t, tc.expectAttempts, cli.attempts())
+			})
+		}
+	})
+
+	t.Run("unsupported compression", func(t *testing.T) {
+		_, err := newBatcher(&mockS3Client{}, bucket, 1, 1, time.Second, "brotli", loggingtesting.TestLogger(t))
+		assert.Error(t, err)
+	})
+}
+
+// addAsync adds the given record to the batcher in the background, and
+// returns a channel which receives the outcome of the operation.
+func addAsync(b *batcher, prefix, record string) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- b.add(context.Background(), prefix, []byte(record))
+	}()
+	return errCh
+}
+
+// users returns the number of callers with a record in the buffer of the
+// given key prefix.
+func (b *batcher) users(prefix string) int {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if pb, ok := b.prefixes[prefix]; ok {
+		return pb.users
+	}
+	return 0
+}
+
+// records returns the NDJSON records contained in the given body.
+func records(body string) []string {
+	return strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+}
+
+func TestNDJSONRecord(t *testing.T) {
+	rec, err := ndjsonRecord([]byte("{\n  \"a\": 1\n}"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(rec))
+
+	rec, err = ndjsonRecord([]byte("plain\ntext"))
+	require.NoError(t, err)
+	assert.Equal(t, `"plain\ntext"`, string(rec))
+}
+
+// mockS3Client is a mocked S3 client which records written objects.
+type mockS3Client struct {
+	s3iface.S3API
+
+	m    sync.Mutex
+	objs []mockObject
+	// errors returned by successive calls, before objects get written
+	errs  []error
+	calls int
+}
+
+// mockObject is an object written to a mockS3Client.
+type mockObject struct {
+	key             string
+	contentType     string
+	contentEncoding string
+	body            string
+}
+
+func (c *mockS3Client) PutObjectWithContext(_ aws.Context, in *s3.PutObjectInput,
+	_ ...request.Option) (*s3.PutObjectOutput, error) {
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(in.Body); err != nil {
+		return nil, err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.calls++; c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+
+	c.objs = append(c.objs, mockObject{
+		key:             *in.Key,
+		contentType:     aws.StringValue(in.ContentType),
+		contentEncoding: aws.StringValue(in.ContentEncoding),
+		body:            body.String(),
+	})
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (c *mockS3Client) attempts() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.calls
+}
+
+func (c *mockS3Client) objects() []mockObject {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return append([]mockObject(nil), c.objs...)
+}
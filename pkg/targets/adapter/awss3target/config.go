@@ -17,6 +17,8 @@ limitations under the License.
 package awss3target
 
 import (
+	"time"
+
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
 )
 
@@ -32,6 +34,16 @@ type envAccessor struct {
 
 	DiscardCEContext bool `envconfig:"AWS_DISCARD_CE_CONTEXT"`
 
+	// Go template of the keys of created objects.
+	KeyTemplate string `envconfig:"S3_KEY_TEMPLATE"`
+
+	// Batching of events into NDJSON objects.
+	Batching         bool          `envconfig:"S3_BATCHING"`
+	BatchMaxEvents   int           `envconfig:"S3_BATCH_MAX_EVENTS" default:"1000"`
+	BatchMaxBytes    int           `envconfig:"S3_BATCH_MAX_BYTES" default:"5242880"`
+	BatchMaxAge      time.Duration `envconfig:"S3_BATCH_MAX_AGE" default:"1m"`
+	BatchCompression string        `envconfig:"S3_BATCH_COMPRESSION" default:"none"`
+
 	// Assume this IAM Role when access keys provided.
 	AssumeIamRole string `envconfig:"AWS_ASSUME_ROLE_ARN"`
 
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awss3target
+
+import (
+	"errors"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/eventtemplate"
+)
+
+// keyTemplate generates object keys from CloudEvents.
+type keyTemplate struct {
+	tmpl *eventtemplate.Template
+}
+
+// newKeyTemplate parses the given Go template of object keys.
+func newKeyTemplate(text string) (*keyTemplate, error) {
+	tmpl, err := eventtemplate.New("key", text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyTemplate{tmpl: tmpl}, nil
+}
+
+// render returns the object key generated from the given event.
+func (k *keyTemplate) render(event *cloudevents.Event) (string, error) {
+	key, err := k.tmpl.Execute(event)
+	if err != nil {
+		return "", err
+	}
+
+	// S3 keys are not expected to start with a delimiter
+	key = strings.TrimLeft(key, "/")
+	if key == "" {
+		return "", errors.New("key template generated an empty key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awss3target
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestKeyTemplate(t *testing.T) {
+	newEvent := func() *cloudevents.Event {
+		e := cloudevents.NewEvent()
+		e.SetID("abc")
+		e.SetType("com.example.order")
+		e.SetSource("orders")
+		e.SetTime(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+		e.SetExtension("region", "eu")
+		_ = e.SetData(cloudevents.ApplicationJSON, map[string]interface{}{"customer": "c1"})
+		return &e
+	}
+
+	testCases := map[string]struct {
+		tmpl      string
+		expectKey string
+		expectErr bool
+	}{
+		"attributes and time": {
+			tmpl:      `{{.type}}/dt={{time "2006-01-02"}}/{{.id}}.json`,
+			expectKey: "com.example.order/dt=2023-03-01/abc.json",
+		},
+		"extensions and data": {
+			tmpl:      `{{.region}}/{{.data.customer}}`,
+			expectKey: "eu/c1",
+		},
+		"leading slashes": {
+			tmpl:      `/{{.source}}/{{.id}}`,
+			expectKey: "orders/abc",
+		},
+		"missing attribute": {
+			tmpl:      `{{.missing}}/{{.id}}`,
+			expectErr: true,
+		},
+		"empty key": {
+			tmpl:      `{{.subject}}`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			kt, err := newKeyTemplate(tc.tmpl)
+			require.NoError(t, err)
+
+			key, err := kt.render(newEvent())
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectKey, key)
+		})
+	}
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batch contains a component which buffers the payloads received by
+// targets and sends them to their backend in batches.
+package batch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ContentEncodingGzip is the content encoding of compressed batches.
+const ContentEncodingGzip = "gzip"
+
+// Upper bound of the exponential backoff between retries.
+const maxRetryBackoff = 10 * time.Second
+
+// Maximum amount of time allowed for sending a batch triggered by a threshold
+// or by the linger time, retries included.
+const sendTimeout = 1 * time.Minute
+
+// Request is an encoded batch of items.
+type Request struct {
+	// Body of the batch, compressed when ContentEncoding is set.
+	Body []byte
+	// ContentEncoding is set to "gzip" when the body is compressed.
+	ContentEncoding string
+
+	// Items contained in the body, in order.
+	Items [][]byte
+
+	// Responses of the individual items, in order. A SendFunc may set it
+	// when the backend responds to each item separately, in which case the
+	// response of an item is returned to its caller instead of the response
+	// of the whole batch.
+	Responses [][]byte
+}
+
+// NewHTTPRequest returns a POST http.Request which carries the batch.
+func (r *Request) NewHTTPRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ContentEncoding != "" {
+		req.Header.Set("Content-Encoding", r.ContentEncoding)
+	}
+
+	return req, nil
+}
+
+// SendFunc sends a batch to a backend, and returns the response of the
+// backend, if any.
+//
+// Failures of individual items are reported by returning a *PartialError.
+// Errors wrapped with Retryable cause the batch, or its failed items, to be
+// sent again.
+type SendFunc func(context.Context, *Request) ([]byte, error)
+
+// EncodeFunc encodes a list of items into the body of a batch.
+type EncodeFunc func(items [][]byte) []byte
+
+// EncodeNDJSON encodes items as newline-delimited JSON. Items must be
+// single-line JSON documents.
+func EncodeNDJSON(items [][]byte) []byte {
+	var body bytes.Buffer
+	for _, item := range items {
+		body.Write(item)
+		body.WriteByte('\n')
+	}
+	return body.Bytes()
+}
+
+// EncodeJSONArray encodes items as a JSON array.
+func EncodeJSONArray(items [][]byte) []byte {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(item)
+	}
+	body.WriteByte(']')
+	return body.Bytes()
+}
+
+// Batcher buffers items and sends them in batches once their number or their
+// size reaches a threshold, or after a given linger time, whichever happens
+// first. Callers are notified of the outcome of the sending of each
+// individual item.
+type Batcher struct {
+	send   SendFunc
+	encode EncodeFunc
+	cfg    Config
+
+	m       sync.Mutex
+	pending []*item
+	size    int
+	timer   *time.Timer
+
+	// tracks batches sent in the background.
+	wg sync.WaitGroup
+}
+
+// item is a buffered item.
+type item struct {
+	data []byte
+
+	res chan result
+}
+
+// result is the outcome of the sending of an item.
+type result struct {
+	resp []byte
+	err  error
+}
+
+// New returns a Batcher which sends batches encoded with encode using send.
+// Unset parameters of the given Config are replaced with their default value.
+func New(send SendFunc, encode EncodeFunc, cfg Config) *Batcher {
+	return &Batcher{
+		send:   send,
+		encode: encode,
+		cfg:    cfg.withDefaults(),
+	}
+}
+
+// Add buffers the given item, and blocks until it was sent, or until the
+// context is done. The response returned by the backend for the batch which
+// contained the item is returned.
+//
+// An item which is still buffered when the context is done is discarded. An
+// item which is already being sent can't be recalled, so its outcome is
+// awaited instead.
+func (b *Batcher) Add(ctx context.Context, data []byte) ([]byte, error) {
+	it := &item{
+		data: data,
+		res:  make(chan result, 1),
+	}
+
+	b.m.Lock()
+
+	// keep batches within the byte limit, unless a single item exceeds it.
+	if len(b.pending) > 0 && b.size+len(data) > b.cfg.MaxBytes {
+		b.sendAsync(b.takePending())
+	}
+
+	b.pending = append(b.pending, it)
+	b.size += len(data)
+
+	switch {
+	case len(b.pending) >= b.cfg.MaxEvents || b.size >= b.cfg.MaxBytes:
+		b.sendAsync(b.takePending())
+	case b.timer == nil:
+		b.timer = time.AfterFunc(b.cfg.Linger, b.expire)
+	}
+
+	b.m.Unlock()
+
+	select {
+	case res := <-it.res:
+		return res.resp, res.err
+	case <-ctx.Done():
+	}
+
+	b.m.Lock()
+	discarded := b.discard(it)
+	b.m.Unlock()
+
+	if discarded {
+		return nil, ctx.Err()
+	}
+
+	res := <-it.res
+	return res.resp, res.err
+}
+
+// discard removes the given item from the pending items, and reports whether
+// it was found there. Must be called with the lock held.
+func (b *Batcher) discard(it *item) bool {
+	for i, p := range b.pending {
+		if p != it {
+			continue
+		}
+
+		b.pending = append(b.pending[:i], b.pending[i+1:]...)
+		b.size -= len(it.data)
+		if len(b.pending) == 0 && b.timer != nil {
+			b.timer.Stop()
+			b.timer = nil
+		}
+		return true
+	}
+
+	return false
+}
+
+// takePending returns the pending items and resets the buffer.
+// Must be called with the lock held.
+func (b *Batcher) takePending() []*item {
+	batch := b.pending
+
+	b.pending = nil
+	b.size = 0
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	return batch
+}
+
+// expire sends the pending items once the linger time has elapsed.
+func (b *Batcher) expire() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if batch := b.takePending(); len(batch) > 0 {
+		b.sendAsync(batch)
+	}
+}
+
+// sendAsync sends the given items in the background, independently of the
+// goroutines of their callers. Must be called with the lock held, so that
+// Flush can't miss the batch.
+func (b *Batcher) sendAsync(batch []*item) {
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+
+		b.sendWithRetries(ctx, batch)
+	}()
+}
+
+// Flush sends all pending items, and waits for the completion of batches in
+// progress.
+func (b *Batcher) Flush(ctx context.Context) {
+	b.m.Lock()
+	batch := b.takePending()
+	b.m.Unlock()
+
+	if len(batch) > 0 {
+		b.sendWithRetries(ctx, batch)
+	}
+
+	b.wg.Wait()
+}
+
+// sendWithRetries sends the given items, retries those which failed with a
+// retryable error, and notifies the caller of each item of its outcome.
+func (b *Batcher) sendWithRetries(ctx context.Context, batch []*item) {
+	backoff := b.cfg.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		canRetry := attempt < b.cfg.MaxRetries
+
+		resps, err := b.sendBatch(ctx, batch)
+
+		var retry []*item
+		var partialErr *PartialError
+
+		switch {
+		case err == nil:
+			for i, it := range batch {
+				it.res <- result{resp: resps[i]}
+			}
+
+		case errors.As(err, &partialErr):
+			for i, it := range batch {
+				itemErr, failed := partialErr.Errors[i]
+				switch {
+				case !failed:
+					it.res <- result{resp: resps[i]}
+				case canRetry && IsRetryable(itemErr):
+					retry = append(retry, it)
+				default:
+					it.res <- result{err: itemErr}
+				}
+			}
+
+		case canRetry && IsRetryable(err):
+			retry = batch
+
+		default:
+			notify(batch, result{err: err})
+		}
+
+		if len(retry) == 0 {
+			return
+		}
+
+		if err := sleep(ctx, backoff); err != nil {
+			notify(retry, result{err: err})
+			return
+		}
+
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+		batch = retry
+	}
+}
+
+// sendBatch encodes the given items, sends them in a single batch, and returns
+// the response of each item.
+func (b *Batcher) sendBatch(ctx context.Context, batch []*item) ([][]byte, error) {
+	items := make([][]byte, len(batch))
+	for i, it := range batch {
+		items[i] = it.data
+	}
+
+	req := &Request{
+		Body:  b.encode(items),
+		Items: items,
+	}
+
+	if b.cfg.Compress {
+		body, err := gzipCompress(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("compressing batch: %w", err)
+		}
+		req.Body = body
+		req.ContentEncoding = ContentEncodingGzip
+	}
+
+	resp, err := b.send(ctx, req)
+	if len(req.Responses) == len(batch) {
+		return req.Responses, err
+	}
+
+	resps := make([][]byte, len(batch))
+	for i := range resps {
+		resps[i] = resp
+	}
+
+	return resps, err
+}
+
+// notify sends the given result to the caller of each item.
+func notify(batch []*item, res result) {
+	for _, it := range batch {
+		it.res <- res
+	}
+}
+
+// gzipCompress compresses the given data using gzip.
+func gzipCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sleep waits for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
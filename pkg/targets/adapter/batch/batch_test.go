@@ -0,0 +1,349 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcherFlushTriggers(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       Config
+		items     []string
+		expectLen []int
+	}{
+		"max events": {
+			cfg:       Config{MaxEvents: 2, Linger: time.Hour},
+			items:     []string{"1", "2", "3", "4"},
+			expectLen: []int{2, 2},
+		},
+		"max bytes": {
+			cfg:       Config{MaxEvents: 100, MaxBytes: 4, Linger: time.Hour},
+			items:     []string{"aa", "bb", "cc"},
+			expectLen: []int{2},
+		},
+		"byte limit not exceeded": {
+			cfg:       Config{MaxEvents: 100, MaxBytes: 5, Linger: time.Hour},
+			items:     []string{"aa", "bb", "cc"},
+			expectLen: []int{2},
+		},
+		"batching disabled": {
+			cfg:       Config{Linger: time.Hour},
+			items:     []string{"1", "2"},
+			expectLen: []int{1, 1},
+		},
+		"linger": {
+			cfg:       Config{MaxEvents: 100, Linger: 100 * time.Millisecond},
+			items:     []string{"1", "2", "3"},
+			expectLen: []int{3},
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			s := &recordingSender{}
+			b := New(s.send, EncodeNDJSON, tc.cfg)
+
+			var wg sync.WaitGroup
+			for _, it := range tc.items {
+				wg.Add(1)
+				go func(it string) {
+					defer wg.Done()
+					_, _ = b.Add(context.Background(), []byte(it))
+				}(it)
+				// preserves the order of items
+				time.Sleep(5 * time.Millisecond)
+			}
+
+			require.Eventually(t, func() bool {
+				return len(s.batchSizes()) >= len(tc.expectLen)
+			}, time.Second, time.Millisecond)
+
+			assert.Equal(t, tc.expectLen, s.batchSizes())
+
+			b.Flush(context.Background())
+			wg.Wait()
+		})
+	}
+}
+
+func TestBatcherEncoding(t *testing.T) {
+	testCases := map[string]struct {
+		encode EncodeFunc
+		expect string
+	}{
+		"NDJSON": {
+			encode: EncodeNDJSON,
+			expect: "{\"a\":1}\n{\"b\":2}\n",
+		},
+		"JSON array": {
+			encode: EncodeJSONArray,
+			expect: `[{"a":1},{"b":2}]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			s := &recordingSender{}
+			b := New(s.send, tc.encode, Config{MaxEvents: 2, Compress: true})
+
+			addAll(t, b, `{"a":1}`, `{"b":2}`)
+
+			require.Len(t, s.reqs, 1)
+			req := s.reqs[0]
+			assert.Equal(t, ContentEncodingGzip, req.ContentEncoding)
+
+			zr, err := gzip.NewReader(bytes.NewReader(req.Body))
+			require.NoError(t, err)
+			body, err := io.ReadAll(zr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, string(body))
+		})
+	}
+}
+
+func TestBatcherPartialFailure(t *testing.T) {
+	errRejected := errors.New("rejected")
+	errThrottled := errors.New("throttled")
+
+	var attempts int
+	send := func(_ context.Context, req *Request) ([]byte, error) {
+		attempts++
+		if attempts > 1 {
+			return []byte("ok-" + strconv.Itoa(attempts)), nil
+		}
+		return []byte("ok"), &PartialError{Errors: map[int]error{
+			1: errRejected,
+			2: Retryable(errThrottled),
+		}}
+	}
+
+	b := New(send, EncodeNDJSON, Config{MaxEvents: 3, MaxRetries: 1, RetryBackoff: time.Millisecond})
+
+	res := addAll(t, b, "a", "b", "c")
+
+	assert.Equal(t, 2, attempts)
+
+	assert.NoError(t, res[0].err)
+	assert.Equal(t, "ok", string(res[0].resp))
+
+	assert.ErrorIs(t, res[1].err, errRejected)
+
+	assert.NoError(t, res[2].err)
+	assert.Equal(t, "ok-2", string(res[2].resp))
+}
+
+func TestBatcherItemResponses(t *testing.T) {
+	send := func(_ context.Context, req *Request) ([]byte, error) {
+		req.Responses = make([][]byte, len(req.Items))
+		for i, it := range req.Items {
+			req.Responses[i] = append([]byte("ok-"), it...)
+		}
+		return []byte("ok"), nil
+	}
+
+	b := New(send, EncodeNDJSON, Config{MaxEvents: 2})
+
+	res := addAll(t, b, "a", "b")
+
+	assert.NoError(t, res[0].err)
+	assert.Equal(t, "ok-a", string(res[0].resp))
+	assert.NoError(t, res[1].err)
+	assert.Equal(t, "ok-b", string(res[1].resp))
+}
+
+func TestBatcherRetries(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+
+	testCases := map[string]struct {
+		err          error
+		maxRetries   int
+		expectSends  int
+		expectErrors bool
+	}{
+		"retryable error": {
+			err:          Retryable(errUnavailable),
+			maxRetries:   2,
+			expectSends:  3,
+			expectErrors: true,
+		},
+		"non-retryable error": {
+			err:          errUnavailable,
+			maxRetries:   2,
+			expectSends:  1,
+			expectErrors: true,
+		},
+		"retries disabled": {
+			err:          Retryable(errUnavailable),
+			maxRetries:   0,
+			expectSends:  1,
+			expectErrors: true,
+		},
+		"success": {
+			maxRetries:  2,
+			expectSends: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			var sends int
+			send := func(context.Context, *Request) ([]byte, error) {
+				sends++
+				return nil, tc.err
+			}
+
+			b := New(send, EncodeNDJSON, Config{MaxEvents: 2, MaxRetries: tc.maxRetries, RetryBackoff: time.Millisecond})
+
+			for _, res := range addAll(t, b, "a", "b") {
+				if tc.expectErrors {
+					assert.ErrorIs(t, res.err, errUnavailable)
+				} else {
+					assert.NoError(t, res.err)
+				}
+			}
+			assert.Equal(t, tc.expectSends, sends)
+		})
+	}
+}
+
+func TestBatcherFlush(t *testing.T) {
+	s := &recordingSender{}
+	b := New(s.send, EncodeNDJSON, Config{MaxEvents: 100, Linger: time.Hour})
+
+	errCh := make(chan error)
+	go func() {
+		_, err := b.Add(context.Background(), []byte("a"))
+		errCh <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		b.m.Lock()
+		defer b.m.Unlock()
+		return len(b.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	b.Flush(context.Background())
+
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, []int{1}, s.batchSizes())
+}
+
+func TestBatcherCancel(t *testing.T) {
+	s := &recordingSender{}
+	b := New(s.send, EncodeNDJSON, Config{MaxEvents: 100, Linger: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error)
+	go func() {
+		_, err := b.Add(ctx, []byte("a"))
+		errCh <- err
+	}()
+	go func() {
+		_, err := b.Add(context.Background(), []byte("b"))
+		errCh <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		b.m.Lock()
+		defer b.m.Unlock()
+		return len(b.pending) == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+
+	b.Flush(context.Background())
+
+	assert.NoError(t, <-errCh)
+
+	// the cancelled item is not sent
+	require.Len(t, s.reqs, 1)
+	assert.Equal(t, [][]byte{[]byte("b")}, s.reqs[0].Items)
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	assert.True(t, IsRetryableStatus(429))
+	assert.True(t, IsRetryableStatus(503))
+	assert.False(t, IsRetryableStatus(400))
+	assert.False(t, IsRetryableStatus(413))
+
+	assert.True(t, IsRetryable(ResponseError(500, nil)))
+	assert.False(t, IsRetryable(ResponseError(401, nil)))
+}
+
+// recordingSender records the batches it is requested to send.
+type recordingSender struct {
+	m    sync.Mutex
+	reqs []*Request
+}
+
+func (s *recordingSender) send(_ context.Context, req *Request) ([]byte, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.reqs = append(s.reqs, req)
+	return nil, nil
+}
+
+func (s *recordingSender) batchSizes() []int {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	sizes := make([]int, len(s.reqs))
+	for i, req := range s.reqs {
+		sizes[i] = len(req.Items)
+	}
+	return sizes
+}
+
+// addAll adds the given items concurrently, in order, and returns their
+// results once all of them have been sent.
+func addAll(t *testing.T, b *Batcher, items ...string) []result {
+	t.Helper()
+
+	res := make([]result, len(items))
+
+	var wg sync.WaitGroup
+	for i, it := range items {
+		wg.Add(1)
+		go func(i int, it string) {
+			defer wg.Done()
+			resp, err := b.Add(context.Background(), []byte(it))
+			res[i] = result{resp: resp, err: err}
+		}(i, it)
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	return res
+}
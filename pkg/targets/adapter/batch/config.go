@@ -0,0 +1,75 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import "time"
+
+// Default batching parameters.
+const (
+	DefaultMaxEvents    = 1
+	DefaultMaxBytes     = 1024 * 1024
+	DefaultLinger       = 1 * time.Second
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// Config contains the batching parameters of a target's adapter. It is
+// intended to be embedded in the envconfig of adapters.
+type Config struct {
+	// Number of buffered events which triggers the sending of a batch.
+	// Batching is disabled by default.
+	MaxEvents int `envconfig:"BATCH_MAX_EVENTS" default:"1"`
+	// Size in bytes of buffered events which triggers the sending of a
+	// batch, before compression.
+	MaxBytes int `envconfig:"BATCH_MAX_BYTES" default:"1048576"`
+	// Maximum amount of time events are buffered before being sent.
+	Linger time.Duration `envconfig:"BATCH_LINGER" default:"1s"`
+	// Whether batches are compressed with gzip.
+	Compress bool `envconfig:"BATCH_COMPRESS" default:"false"`
+
+	// Number of times failed events are sent again.
+	// Failed events aren't retried by default.
+	MaxRetries int `envconfig:"BATCH_MAX_RETRIES" default:"0"`
+	// Initial delay between retries, doubled after each attempt.
+	RetryBackoff time.Duration `envconfig:"BATCH_RETRY_BACKOFF" default:"200ms"`
+}
+
+// withDefaults returns a copy of the Config where unset parameters are
+// replaced with their default value.
+func (c Config) withDefaults() Config {
+	if c.MaxEvents <= 0 {
+		c.MaxEvents = DefaultMaxEvents
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultMaxBytes
+	}
+	if c.Linger <= 0 {
+		c.Linger = DefaultLinger
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+	return c
+}
+
+// Enabled returns whether the Config causes events to be sent in batches,
+// rather than individually.
+func (c Config) Enabled() bool {
+	return c.MaxEvents > 1
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PartialError is returned by a SendFunc when the backend rejected only some
+// of the items of a batch. Items which are not referenced are considered
+// successfully sent.
+type PartialError struct {
+	// Errors of the failed items, indexed by their position in the batch.
+	Errors map[int]error
+}
+
+var _ error = (*PartialError)(nil)
+
+// Error implements error.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d items of the batch failed", len(e.Errors))
+}
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err error
+}
+
+// Error implements error.
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// Retryable marks the given error as transient, so that the items it applies
+// to are sent again.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// IsRetryable returns whether the given error was marked as transient.
+func IsRetryable(err error) bool {
+	var retryErr *retryableError
+	return errors.As(err, &retryErr)
+}
+
+// IsRetryableStatus returns whether the given HTTP status code denotes a
+// transient failure.
+func IsRetryableStatus(code int) bool {
+	return code == http.StatusRequestTimeout ||
+		code == http.StatusTooManyRequests ||
+		code >= http.StatusInternalServerError
+}
+
+// ResponseError returns an error for a failed HTTP response, which is marked
+// as retryable when its status code denotes a transient failure.
+func ResponseError(code int, body []byte) error {
+	err := fmt.Errorf("received HTTP code %d: %s", code, body)
+	if IsRetryableStatus(code) {
+		return Retryable(err)
+	}
+	return err
+}
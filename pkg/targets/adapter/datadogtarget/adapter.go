@@ -33,6 +33,7 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 )
 
@@ -43,8 +44,9 @@ const (
 )
 
 const (
-	contentTypeHeader = "Content-Type"
-	contentTypeJSON   = "application/json"
+	contentTypeHeader     = "Content-Type"
+	contentEncodingHeader = "Content-Encoding"
+	contentTypeJSON       = "application/json"
 )
 
 // NewTarget adapter implementation
@@ -69,7 +71,7 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicf("Error creating CloudEvents replier: %v", err)
 	}
 
-	return &datadogAdapter{
+	a := &datadogAdapter{
 		apiKey:     env.APIKey,
 		apiURL:     fmt.Sprintf("%s.%s", apiBaseDomain, env.Site),
 		apiLogsURL: fmt.Sprintf("%s.%s", logsAPIBaseDomain, env.Site),
@@ -81,6 +83,11 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 
 		sr: metrics.MustNewEventProcessingStatsReporter(mt),
 	}
+
+	// the logs API accepts arrays of log entries.
+	a.logsBatcher = batch.New(a.sendLogs, batch.EncodeJSONArray, env.Config)
+
+	return a
 }
 
 var _ pkgadapter.Adapter = (*datadogAdapter)(nil)
@@ -90,6 +97,9 @@ type datadogAdapter struct {
 	apiURL     string
 	apiLogsURL string
 
+	// buffers log entries which are sent to the logs API in batches.
+	logsBatcher *batch.Batcher
+
 	replier    *targetce.Replier
 	httpClient *http.Client
 	ceClient   cloudevents.Client
@@ -101,6 +111,10 @@ type datadogAdapter struct {
 // Returns if stopCh is closed or Send() returns an error.
 func (a *datadogAdapter) Start(ctx context.Context) error {
 	a.logger.Info("Starting Datadog adapter")
+
+	// send buffered log entries before returning
+	defer a.logsBatcher.Flush(context.Background())
+
 	return a.ceClient.StartReceiver(ctx, a.dispatch)
 }
 
@@ -111,41 +125,52 @@ func (a *datadogAdapter) dispatch(ctx context.Context, event cloudevents.Event)
 	case v1alpha1.EventTypeDatadogEvent:
 		return a.postEvent(event)
 	case v1alpha1.EventTypeDatadogLog:
-		return a.postLog(event)
+		return a.postLog(ctx, event)
 	default:
 		return a.replier.Error(&event, targetce.ErrorCodeEventContext, fmt.Errorf("event type %q is not supported", typ), nil)
 	}
 }
 
-func (a *datadogAdapter) postLog(event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+func (a *datadogAdapter) postLog(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
 	if err := event.DataAs(&LogData{}); err != nil {
 		return a.replier.Error(&event, targetce.ErrorCodeRequestParsing, err, nil)
 	}
 
-	request, err := newLogsAPIRequest(a.apiLogsURL, "/v1/input", a.apiKey, event.Data())
+	resBody, err := a.logsBatcher.Add(ctx, event.Data())
 	if err != nil {
 		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+	}
+
+	return a.replier.Ok(&event, resBody)
+}
 
+// sendLogs sends a batch of log entries to the Datadog logs API.
+func (a *datadogAdapter) sendLogs(ctx context.Context, b *batch.Request) ([]byte, error) {
+	request, err := newLogsAPIRequest(a.apiLogsURL, "/v1/input", a.apiKey, b.Body)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	if b.ContentEncoding != "" {
+		request.Header.Set(contentEncodingHeader, b.ContentEncoding)
 	}
 
 	res, err := a.httpClient.Do(request)
 	if err != nil {
-		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+		return nil, batch.Retryable(err)
 	}
 
 	defer res.Body.Close()
 	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
-		return a.replier.Error(&event, targetce.ErrorCodeParseResponse, err, nil)
+		return nil, batch.Retryable(err)
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess,
-			fmt.Errorf("received HTTP code %d", res.StatusCode),
-			map[string]string{"body": string(resBody)})
+		return nil, batch.ResponseError(res.StatusCode, resBody)
 	}
 
-	return a.replier.Ok(&event, resBody)
+	return resBody, nil
 }
 
 func (a *datadogAdapter) postEvent(event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
@@ -18,6 +18,8 @@ package datadogtarget
 
 import (
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 )
 
 // EnvAccessorCtor for configuration parameters
@@ -38,4 +40,7 @@ type envAccessor struct {
 
 	// BridgeIdentifier is the name of the bridge workflow this target is part of
 	BridgeIdentifier string `envconfig:"EVENTS_BRIDGE_IDENTIFIER"`
+
+	// Batching of log entries
+	batch.Config
 }
@@ -22,6 +22,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -61,13 +63,22 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicf("Error creating CloudEvents replier: %v", err)
 	}
 
+	index, err := newIndexName(env.IndexName)
+	if err != nil {
+		logger.Panicw("Invalid index name", zap.Error(err))
+	}
+
 	return &esAdapter{
-		config:           env.GetElasticsearchConfig(),
-		replier:          replier,
-		index:            env.IndexName,
-		discardCEContext: env.DiscardCEContext,
-		ceClient:         ceClient,
-		logger:           logger,
+		config:              env.GetElasticsearchConfig(),
+		replier:             replier,
+		index:               index,
+		documentIDFromEvent: env.DocumentIDFromEvent,
+		discardCEContext:    env.DiscardCEContext,
+		bulk:                env.Bulk,
+		bulkFlushBytes:      env.BulkFlushBytes,
+		bulkFlushInterval:   env.BulkFlushInterval,
+		ceClient:            ceClient,
+		logger:              logger,
 
 		sr: metrics.MustNewEventProcessingStatsReporter(mt),
 	}
@@ -79,9 +90,16 @@ type esAdapter struct {
 	config *elasticsearch.Config
 	client *elasticsearch.Client
 
-	index string
+	index *indexName
 
-	discardCEContext bool
+	documentIDFromEvent bool
+	discardCEContext    bool
+
+	// bulk indexing
+	bulk              bool
+	bulkFlushBytes    int
+	bulkFlushInterval time.Duration
+	bulkIndexer       *bulkIndexer
 
 	replier  *targetce.Replier
 	ceClient cloudevents.Client
@@ -113,6 +131,12 @@ func (a *esAdapter) Start(ctx context.Context) error {
 		a.logger.Debug("Connected to Elasticsearch: %s", string(info))
 	}
 
+	if a.bulk {
+		a.bulkIndexer = newBulkIndexer(client, a.bulkFlushBytes, a.bulkFlushInterval)
+		// index buffered documents before returning
+		defer a.bulkIndexer.flush(context.Background())
+	}
+
 	return a.ceClient.StartReceiver(ctx, a.dispatch)
 }
 
@@ -129,9 +153,24 @@ func (a *esAdapter) dispatch(ctx context.Context, event cloudevents.Event) (*clo
 		data = jsonEvent
 	}
 
+	index, err := a.index.render(&event)
+	if err != nil {
+		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+	}
+
+	var docID string
+	if a.documentIDFromEvent {
+		docID = event.ID()
+	}
+
+	if a.bulkIndexer != nil {
+		return a.dispatchBulk(ctx, &event, index, docID, data)
+	}
+
 	req := esapi.IndexRequest{
-		Index: a.index,
-		Body:  bytes.NewReader(data),
+		Index:      index,
+		DocumentID: docID,
+		Body:       bytes.NewReader(data),
 	}
 
 	res, err := req.Do(ctx, a.client)
@@ -154,3 +193,32 @@ func (a *esAdapter) dispatch(ctx context.Context, event cloudevents.Event) (*clo
 	a.logger.Debug("Indexed CloudEvent: ", resp["result"])
 	return a.replier.Ok(&event, res)
 }
+
+// dispatchBulk indexes the given document using the Bulk API, and replies
+// with the result of its indexing.
+func (a *esAdapter) dispatchBulk(ctx context.Context, event *cloudevents.Event,
+	index, docID string, data []byte) (*cloudevents.Event, cloudevents.Result) {
+
+	// documents are separated by newlines in bulk requests
+	var body bytes.Buffer
+	if err := json.Compact(&body, data); err != nil {
+		return a.replier.Error(event, targetce.ErrorCodeRequestParsing,
+			fmt.Errorf("document is not valid JSON: %w", err), nil)
+	}
+
+	item, err := a.bulkIndexer.index(ctx, index, docID, body.Bytes())
+	switch {
+	case item == nil && err != nil:
+		// the whole bulk request failed, let the sender retry
+		return a.replier.ErrorKnativeManaged(event, err)
+
+	case err != nil:
+		if item.Status == http.StatusTooManyRequests || item.Status >= http.StatusInternalServerError {
+			return a.replier.ErrorKnativeManaged(event, err)
+		}
+		return a.replier.Error(event, targetce.ErrorCodeAdapterProcess, err, item)
+	}
+
+	a.logger.Debug("Indexed CloudEvent: ", item.Result)
+	return a.replier.Ok(event, item)
+}
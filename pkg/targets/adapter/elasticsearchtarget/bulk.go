@@ -0,0 +1,210 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchtarget
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
+)
+
+// Bulk requests are cancelled when they exceed this duration.
+const bulkRequestTimeout = 1 * time.Minute
+
+// bulkIndexer buffers documents and indexes them using the Bulk API once
+// their size reaches a threshold, or after a given interval, whichever
+// happens first. Callers are notified of the outcome of the indexing of each
+// individual document.
+type bulkIndexer struct {
+	client  *elasticsearch.Client
+	batcher *batch.Batcher
+}
+
+// bulkResponse is the response to a request to the Bulk API.
+type bulkResponse struct {
+	Errors bool                         `json:"errors"`
+	Items  []map[string]json.RawMessage `json:"items"`
+}
+
+// bulkResponseItem is the result of a single action of a bulk request.
+type bulkResponseItem struct {
+	Index      string `json:"_index"`
+	DocumentID string `json:"_id"`
+	Version    int64  `json:"_version,omitempty"`
+	Result     string `json:"result,omitempty"`
+	Status     int    `json:"status"`
+
+	Error *bulkResponseItemError `json:"error,omitempty"`
+}
+
+// bulkResponseItemError is the error which caused an action of a bulk request
+// to fail.
+type bulkResponseItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Error implements error.
+func (e *bulkResponseItemError) Error() string {
+	return e.Type + ": " + e.Reason
+}
+
+// bulkItemError is returned for a document which failed to be indexed.
+type bulkItemError struct {
+	item *bulkResponseItem
+}
+
+// Error implements error.
+func (e *bulkItemError) Error() string {
+	return e.item.Error.Error()
+}
+
+// newBulkIndexer returns a bulkIndexer which uses the given client.
+func newBulkIndexer(client *elasticsearch.Client, flushBytes int, flushInterval time.Duration) *bulkIndexer {
+	b := &bulkIndexer{
+		client: client,
+	}
+
+	// bulk requests are delimited by newlines
+	b.batcher = batch.New(b.send, batch.EncodeNDJSON, batch.Config{
+		// batches are bounded by their size only
+		MaxEvents: math.MaxInt,
+		MaxBytes:  flushBytes,
+		Linger:    flushInterval,
+	})
+
+	return b
+}
+
+// index buffers the given document, and blocks until it was indexed, or until
+// the context is done. The body must be a single-line JSON document.
+//
+// The returned item is nil when the whole bulk request failed.
+func (b *bulkIndexer) index(ctx context.Context, index, docID string, body []byte) (*bulkResponseItem, error) {
+	meta := struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id,omitempty"`
+	}{
+		Index: index,
+		ID:    docID,
+	}
+
+	action, err := json.Marshal(map[string]interface{}{"index": meta})
+	if err != nil {
+		return nil, fmt.Errorf("serializing action metadata: %w", err)
+	}
+
+	action = append(action, '\n')
+	action = append(action, body...)
+
+	resp, err := b.batcher.Add(ctx, action)
+	if err != nil {
+		var itemErr *bulkItemError
+		if errors.As(err, &itemErr) {
+			return itemErr.item, err
+		}
+		return nil, err
+	}
+
+	item := &bulkResponseItem{}
+	if err := json.Unmarshal(resp, item); err != nil {
+		return nil, fmt.Errorf("decoding bulk response item: %w", err)
+	}
+
+	return item, nil
+}
+
+// flush indexes all pending documents, and waits for the completion of bulk
+// requests in progress.
+func (b *bulkIndexer) flush(ctx context.Context) {
+	b.batcher.Flush(ctx)
+}
+
+// send sends a bulk request for the given batch of actions, and sets the
+// result of each action as the response of its item.
+func (b *bulkIndexer) send(ctx context.Context, req *batch.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, bulkRequestTimeout)
+	defer cancel()
+
+	bulkReq := esapi.BulkRequest{
+		Body: bytes.NewReader(req.Body),
+	}
+
+	res, err := bulkReq.Do(ctx, b.client)
+	if err != nil {
+		return nil, batch.Retryable(fmt.Errorf("sending bulk request: %w", err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		err := fmt.Errorf("bulk request failed: %s", res.String())
+		if batch.IsRetryableStatus(res.StatusCode) {
+			return nil, batch.Retryable(err)
+		}
+		return nil, err
+	}
+
+	var resp bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decoding bulk response: %w", err)
+	}
+
+	if len(resp.Items) != len(req.Items) {
+		return nil, fmt.Errorf("bulk response contains %d items, expected %d", len(resp.Items), len(req.Items))
+	}
+
+	req.Responses = make([][]byte, len(resp.Items))
+	itemErrs := make(map[int]error)
+
+	for i, actions := range resp.Items {
+		// each item contains a single action, keyed by its name
+		for _, action := range actions {
+			req.Responses[i] = action
+		}
+		if req.Responses[i] == nil {
+			return nil, fmt.Errorf("bulk response item %d is empty", i)
+		}
+
+		item := &bulkResponseItem{}
+		if err := json.Unmarshal(req.Responses[i], item); err != nil {
+			return nil, fmt.Errorf("decoding bulk response item %d: %w", i, err)
+		}
+
+		if item.Error != nil {
+			var err error = &bulkItemError{item: item}
+			if batch.IsRetryableStatus(item.Status) {
+				err = batch.Retryable(err)
+			}
+			itemErrs[i] = err
+		}
+	}
+
+	if len(itemErrs) > 0 {
+		return nil, &batch.PartialError{Errors: itemErrs}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchtarget
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-elasticsearch/v7"
+)
+
+func TestBulkIndexer(t *testing.T) {
+	var m sync.Mutex
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			// product check performed by the client before its first request
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+			return
+		case "/_bulk":
+		default:
+			http.Error(w, "unexpected path", http.StatusNotFound)
+			return
+		}
+
+		m.Lock()
+		requests++
+		m.Unlock()
+
+		// fail documents which have the "fail" ID
+		type item struct {
+			Index  string                 `json:"_index"`
+			ID     string                 `json:"_id"`
+			Status int                    `json:"status"`
+			Error  *bulkResponseItemError `json:"error,omitempty"`
+		}
+		var items []map[string]item
+
+		s := bufio.NewScanner(r.Body)
+		for s.Scan() {
+			var meta map[string]item
+			require.NoError(t, json.Unmarshal(s.Bytes(), &meta))
+			require.True(t, s.Scan(), "missing document line")
+
+			it := meta["index"]
+			it.Status = http.StatusCreated
+			if it.ID == "fail" {
+				it.Status = http.StatusBadRequest
+				it.Error = &bulkResponseItemError{Type: "mapper_parsing_exception", Reason: "bad document"}
+			}
+			items = append(items, map[string]item{"index": it})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": true,
+			"items":  items,
+		})
+	}))
+	defer srv.Close()
+
+	requestCount := func() int {
+		m.Lock()
+		defer m.Unlock()
+		return requests
+	}
+	resetRequests := func() {
+		m.Lock()
+		defer m.Unlock()
+		requests = 0
+	}
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	t.Run("flush on size", func(t *testing.T) {
+		resetRequests()
+		// combined size of both actions, metadata lines included
+		b := newBulkIndexer(client, 121, time.Hour)
+
+		var wg sync.WaitGroup
+		results := make(map[string]error)
+
+		for _, id := range []string{"a", "fail"} {
+			id := id
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				item, err := b.index(context.Background(), "idx", id, []byte(`{"value":"0123456789"}`))
+				m.Lock()
+				defer m.Unlock()
+				results[id] = err
+				if err == nil {
+					assert.Equal(t, "idx", item.Index)
+					assert.Equal(t, http.StatusCreated, item.Status)
+				}
+			}()
+			// ensures documents are buffered in order
+			time.Sleep(10 * time.Millisecond)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, requestCount())
+		assert.NoError(t, results["a"])
+		assert.EqualError(t, results["fail"], "mapper_parsing_exception: bad document")
+	})
+
+	t.Run("flush on interval", func(t *testing.T) {
+		resetRequests()
+		b := newBulkIndexer(client, 1<<20, 10*time.Millisecond)
+
+		item, err := b.index(context.Background(), "idx", "b", []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "b", item.DocumentID)
+		assert.Equal(t, 1, requestCount())
+	})
+
+	t.Run("request failure", func(t *testing.T) {
+		failClient, err := elasticsearch.NewClient(elasticsearch.Config{
+			Addresses:    []string{"http://127.0.0.1:1"},
+			DisableRetry: true,
+		})
+		require.NoError(t, err)
+
+		b := newBulkIndexer(failClient, 1, time.Hour)
+
+		item, err := b.index(context.Background(), "idx", "c", []byte(`{}`))
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+
+	t.Run("cancelled caller", func(t *testing.T) {
+		resetRequests()
+		b := newBulkIndexer(client, 1<<20, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		res := make(chan error, 1)
+		go func() {
+			_, err := b.index(ctx, "idx", "e", []byte(`{}`))
+			res <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-res, context.Canceled)
+
+		b.flush(context.Background())
+		assert.Equal(t, 0, requestCount(), "Cancelled document was indexed")
+	})
+
+	t.Run("flush on close", func(t *testing.T) {
+		resetRequests()
+		b := newBulkIndexer(client, 1<<20, time.Hour)
+
+		res := make(chan error, 1)
+		go func() {
+			_, err := b.index(context.Background(), "idx", "d", []byte(`{}`))
+			res <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		b.flush(context.Background())
+		assert.NoError(t, <-res)
+		assert.Equal(t, 1, requestCount())
+	})
+}
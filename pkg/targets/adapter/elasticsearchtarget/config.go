@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"net/http"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
@@ -42,6 +43,13 @@ type envAccessor struct {
 
 	IndexName string `envconfig:"ELASTICSEARCH_INDEX" required:"true"`
 
+	DocumentIDFromEvent bool `envconfig:"ELASTICSEARCH_DOCUMENT_ID_FROM_EVENT"`
+
+	// Bulk indexing
+	Bulk              bool          `envconfig:"ELASTICSEARCH_BULK"`
+	BulkFlushBytes    int           `envconfig:"ELASTICSEARCH_BULK_FLUSH_BYTES" default:"5242880"`
+	BulkFlushInterval time.Duration `envconfig:"ELASTICSEARCH_BULK_FLUSH_INTERVAL" default:"1s"`
+
 	DiscardCEContext bool `envconfig:"ELASTICSEARCH_DISCARD_CE_CONTEXT"`
 
 	// CloudEvents responses parametrization
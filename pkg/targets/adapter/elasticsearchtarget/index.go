@@ -0,0 +1,68 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchtarget
+
+import (
+	"errors"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/eventtemplate"
+)
+
+// indexName generates the name of the index events are written to.
+type indexName struct {
+	// static index name, used when the index name isn't a template
+	static string
+	tmpl   *eventtemplate.Template
+}
+
+// newIndexName returns an indexName for the given index name, which may be a
+// Go template.
+func newIndexName(name string) (*indexName, error) {
+	if !strings.Contains(name, "{{") {
+		return &indexName{static: name}, nil
+	}
+
+	tmpl, err := eventtemplate.New("index name", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexName{tmpl: tmpl}, nil
+}
+
+// render returns the name of the index the given event is written to.
+func (n *indexName) render(event *cloudevents.Event) (string, error) {
+	if n.tmpl == nil {
+		return n.static, nil
+	}
+
+	name, err := n.tmpl.Execute(event)
+	if err != nil {
+		return "", err
+	}
+
+	// Elasticsearch index names must be lowercase
+	name = strings.ToLower(name)
+	if name == "" {
+		return "", errors.New("index name template generated an empty name")
+	}
+
+	return name, nil
+}
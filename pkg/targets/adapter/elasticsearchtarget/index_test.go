@@ -0,0 +1,84 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchtarget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestIndexName(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	event.SetType("io.triggermesh.Test")
+	event.SetSource("test.source")
+	event.SetTime(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+	event.SetExtension("tenant", "acme")
+
+	testCases := map[string]struct {
+		name      string
+		expect    string
+		expectErr bool
+	}{
+		"static": {
+			name:   "my-index",
+			expect: "my-index",
+		},
+		"event attributes": {
+			name:   "logs-{{.type}}-{{.tenant}}",
+			expect: "logs-io.triggermesh.test-acme",
+		},
+		"event time": {
+			name:   `logs-{{.source}}-{{time "2006.01.02"}}`,
+			expect: "logs-test.source-2023.03.01",
+		},
+		"missing attribute": {
+			name:      "logs-{{.nope}}",
+			expectErr: true,
+		},
+		"empty name": {
+			name:      "{{.subject}}",
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			n, err := newIndexName(tc.name)
+			require.NoError(t, err)
+
+			index, err := n.render(&event)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, index)
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := newIndexName("logs-{{.type")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventtemplate renders Go templates, such as templated names of
+// destinations, from CloudEvents.
+package eventtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// Template is a Go template rendered from CloudEvents.
+//
+// Templates have access to the CloudEvent context attributes and extensions
+// (e.g. .type, .id, .myextension), to the CloudEvent data (.data), to a 'time'
+// function which formats the time of the CloudEvent using the given Go time
+// layout, and to a 'json' function which encodes a value as JSON.
+type Template struct {
+	name string
+	tmpl *template.Template
+}
+
+// New parses the given Go template. The name of the template is used in
+// error messages.
+func New(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			// placeholder, replaced with a function bound to each rendered event
+			"time": func(string) string { return "" },
+			"json": toJSON,
+		}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s template: %w", name, err)
+	}
+
+	return &Template{name: name, tmpl: tmpl}, nil
+}
+
+// Execute renders the template for the given event.
+func (t *Template) Execute(event *cloudevents.Event) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", fmt.Errorf("cloning %s template: %w", t.name, err)
+	}
+
+	eventTime := event.Time()
+	if eventTime.IsZero() {
+		eventTime = time.Now()
+	}
+
+	tmpl.Funcs(template.FuncMap{
+		"time": func(layout string) string {
+			return eventTime.UTC().Format(layout)
+		},
+	})
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, Data(event)); err != nil {
+		return "", fmt.Errorf("executing %s template: %w", t.name, err)
+	}
+
+	return b.String(), nil
+}
+
+// Data returns the data passed to templates for the given event. The data of
+// the event is decoded when it is JSON, and exposed as a string otherwise.
+func Data(event *cloudevents.Event) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":          event.ID(),
+		"type":        event.Type(),
+		"source":      event.Source(),
+		"subject":     event.Subject(),
+		"time":        event.Time(),
+		"specversion": event.SpecVersion(),
+	}
+
+	for name, val := range event.Extensions() {
+		data[name] = val
+	}
+
+	var eventData interface{}
+	if err := json.Unmarshal(event.Data(), &eventData); err == nil {
+		data["data"] = eventData
+	} else {
+		data["data"] = string(event.Data())
+	}
+
+	return data
+}
+
+// toJSON encodes the given value as JSON, which allows values to be safely
+// inserted in templated JSON documents.
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
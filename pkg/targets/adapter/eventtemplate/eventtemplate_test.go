@@ -0,0 +1,113 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventtemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestTemplate(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("abc")
+	event.SetType("com.example.order")
+	event.SetSource("orders")
+	event.SetTime(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+	event.SetExtension("region", "eu")
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON, map[string]interface{}{"customer": "c1"}))
+
+	testCases := map[string]struct {
+		tmpl      string
+		expect    string
+		expectErr bool
+	}{
+		"attributes": {
+			tmpl:   `{{.type}}/{{.source}}/{{.id}}`,
+			expect: "com.example.order/orders/abc",
+		},
+		"extensions and data": {
+			tmpl:   `{{.region}}/{{.data.customer}}`,
+			expect: "eu/c1",
+		},
+		"event time": {
+			tmpl:   `dt={{time "2006-01-02"}}`,
+			expect: "dt=2023-03-01",
+		},
+		"json function": {
+			tmpl:   `{{json .data}}`,
+			expect: `{"customer":"c1"}`,
+		},
+		"missing attribute": {
+			tmpl:      `{{.missing}}`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := New("test", tc.tmpl)
+			require.NoError(t, err)
+
+			out, err := tmpl.Execute(&event)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, out)
+		})
+	}
+
+	t.Run("time function bound to each event", func(t *testing.T) {
+		tmpl, err := New("test", `{{time "2006"}}`)
+		require.NoError(t, err)
+
+		other := event.Clone()
+		other.SetTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		out, err := tmpl.Execute(&other)
+		require.NoError(t, err)
+		assert.Equal(t, "2020", out)
+
+		out, err = tmpl.Execute(&event)
+		require.NoError(t, err)
+		assert.Equal(t, "2023", out)
+	})
+
+	t.Run("data is not JSON", func(t *testing.T) {
+		tmpl, err := New("test", `{{.data}}`)
+		require.NoError(t, err)
+
+		other := event.Clone()
+		require.NoError(t, other.SetData(cloudevents.TextPlain, "plain text"))
+
+		out, err := tmpl.Execute(&other)
+		require.NoError(t, err)
+		assert.Equal(t, "plain text", out)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := New("test", `{{.type`)
+		assert.Error(t, err)
+	})
+}
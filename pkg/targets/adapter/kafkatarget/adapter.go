@@ -22,7 +22,6 @@ import (
 	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"time"
 
 	"go.uber.org/zap"
@@ -35,6 +34,7 @@ import (
 	"github.com/Shopify/sarama"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	"github.com/triggermesh/triggermesh/pkg/common/kafka"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
 )
@@ -115,6 +115,18 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 	config.Producer.Return.Successes = true
 	config.ClientID = "triggermesh-kafkatarget"
 
+	err = kafka.ApplyProducerOptions(config, kafka.ProducerOptions{
+		Linger:      env.ProducerLinger,
+		BatchSize:   env.ProducerBatchSize,
+		BatchBytes:  env.ProducerBatchBytes,
+		Compression: env.ProducerCompression,
+		Acks:        env.ProducerAcks,
+		Idempotent:  env.ProducerIdempotent,
+	})
+	if err != nil {
+		logger.Panicw("Invalid Kafka producer options", zap.Error(err))
+	}
+
 	scc, err := kafka.NewSaramaCachedClient(ctx, env.BootstrapServers, config,
 		logger.Named("sarama").Desugar(),
 		kafka.WithSaramaCachedClientRefresh(env.ConnectionRefreshPeriod),
@@ -123,8 +135,17 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicw("Error creating kafka client", zap.Error(err))
 	}
 
+	var producer *kafka.AsyncProducer
+	if env.ProducerAsync {
+		producer, err = kafka.NewAsyncProducer(env.BootstrapServers, config)
+		if err != nil {
+			logger.Panicw("Error creating kafka async producer", zap.Error(err))
+		}
+	}
+
 	return &kafkaAdapter{
 		saramaCachedClient:        scc,
+		asyncProducer:             producer,
 		topic:                     env.Topic,
 		createTopicIfMissing:      env.CreateTopicIfMissing,
 		flushTimeout:              env.FlushOnExitTimeoutMillisecs,
@@ -133,6 +154,9 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		newTopicPartitions:        env.NewTopicPartitions,
 
 		discardCEContext: env.DiscardCEContext,
+		contentMode:      v1alpha1.KafkaTargetContentMode(env.ContentMode),
+		partitionKey:     env.PartitionKey,
+		headers:          env.Headers,
 
 		ceClient: ceClient,
 		logger:   logger,
@@ -145,7 +169,9 @@ var _ pkgadapter.Adapter = (*kafkaAdapter)(nil)
 
 type kafkaAdapter struct {
 	saramaCachedClient *kafka.SaramaCachedClient
-	topic              string
+	// asyncProducer produces records when the asynchronous mode is enabled.
+	asyncProducer *kafka.AsyncProducer
+	topic         string
 
 	createTopicIfMissing bool
 
@@ -155,6 +181,9 @@ type kafkaAdapter struct {
 	newTopicPartitions        int32
 
 	discardCEContext bool
+	contentMode      v1alpha1.KafkaTargetContentMode
+	partitionKey     *valueSelector
+	headers          recordHeaders
 
 	ceClient cloudevents.Client
 	logger   *zap.SugaredLogger
@@ -175,40 +204,35 @@ func (a *kafkaAdapter) Start(ctx context.Context) error {
 		}
 	}()
 
+	if a.asyncProducer != nil {
+		defer func() {
+			if err := a.asyncProducer.Close(); err != nil {
+				a.logger.Warnw("could not close kafka async producer", zap.Error(err))
+			}
+		}()
+	}
+
 	return a.ceClient.StartReceiver(ctx, a.dispatch)
 }
 
-func (a *kafkaAdapter) dispatch(event cloudevents.Event) cloudevents.Result {
+func (a *kafkaAdapter) dispatch(ctx context.Context, event cloudevents.Event) cloudevents.Result {
 	ceTypeTag := metrics.TagEventType(event.Type())
 	ceSrcTag := metrics.TagEventSource(event.Source())
 
-	var msgVal []byte
-
 	start := time.Now()
 	defer func() {
 		a.sr.ReportProcessingLatency(time.Since(start), ceTypeTag, ceSrcTag)
 	}()
 
-	if a.discardCEContext {
-		msgVal = event.Data()
-	} else {
-		jsonEvent, err := json.Marshal(event)
-		if err != nil {
-			a.logger.Errorw("Error marshalling CloudEvent", zap.Error(err))
-			a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
-			return err
-		}
-		msgVal = jsonEvent
-	}
-
-	msg := &sarama.ProducerMessage{
-		Topic: a.topic,
-		Key:   sarama.StringEncoder(event.ID()),
-		Value: sarama.ByteEncoder(msgVal),
+	msg, err := a.newRecord(&event)
+	if err != nil {
+		a.logger.Errorw("Error encoding CloudEvent", zap.Error(err))
+		a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
+		return err
 	}
 
-	if err := a.saramaCachedClient.SendMessageSync(msg); err != nil {
-		a.logger.Errorw("Error producing Kafka message", zap.String("msg", string(msgVal)), zap.Error(err))
+	if err := a.send(ctx, msg); err != nil {
+		a.logger.Errorw("Error producing Kafka message", zap.String("id", event.ID()), zap.Error(err))
 		a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
 		return err
 	}
@@ -216,6 +240,51 @@ func (a *kafkaAdapter) dispatch(event cloudevents.Event) cloudevents.Result {
 	return cloudevents.ResultACK
 }
 
+// send produces the given record and returns once it has been acknowledged.
+func (a *kafkaAdapter) send(ctx context.Context, msg *sarama.ProducerMessage) error {
+	if a.asyncProducer != nil {
+		return a.asyncProducer.SendMessage(ctx, msg)
+	}
+	return a.saramaCachedClient.SendMessageSync(msg)
+}
+
+// newRecord returns the Kafka record to produce for the given event.
+func (a *kafkaAdapter) newRecord(event *cloudevents.Event) (*sarama.ProducerMessage, error) {
+	var val []byte
+	var hdrs []sarama.RecordHeader
+
+	switch {
+	case a.discardCEContext:
+		val = event.Data()
+	case a.contentMode == v1alpha1.KafkaTargetContentModeBinary:
+		var err error
+		if val, hdrs, err = encodeBinary(event); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		if val, hdrs, err = encodeStructured(event); err != nil {
+			return nil, err
+		}
+	}
+
+	hdrs = append(hdrs, a.headers.headersFor(event)...)
+
+	key := event.ID()
+	if a.partitionKey != nil {
+		if k, ok := a.partitionKey.value(event); ok {
+			key = k
+		}
+	}
+
+	return &sarama.ProducerMessage{
+		Topic:   a.topic,
+		Key:     sarama.StringEncoder(key),
+		Value:   sarama.ByteEncoder(val),
+		Headers: hdrs,
+	}, nil
+}
+
 func addCAConfig(tlsConfig *tls.Config, caCert string) {
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM([]byte(caCert))
@@ -65,4 +65,21 @@ type envAccessor struct {
 	NewTopicReplicationFactor   int16 `envconfig:"TOPIC_REPLICATION_FACTOR" default:"1"`
 
 	DiscardCEContext bool `envconfig:"DISCARD_CE_CONTEXT"`
+
+	// Content mode of the CloudEvents Kafka protocol binding (structured|binary).
+	ContentMode string `envconfig:"CONTENT_MODE" default:"structured"`
+	// Selector of the event value used as the key of produced records.
+	PartitionKey *valueSelector `envconfig:"PARTITION_KEY"`
+	// Static and derived headers set on produced records.
+	Headers recordHeaders `envconfig:"HEADERS"`
+
+	// Producer tuning. Batching settings also apply to the synchronous
+	// producer, but only batch records of concurrent events.
+	ProducerAsync       bool          `envconfig:"PRODUCER_ASYNC"`
+	ProducerLinger      time.Duration `envconfig:"PRODUCER_LINGER"`
+	ProducerBatchSize   int           `envconfig:"PRODUCER_BATCH_SIZE"`
+	ProducerBatchBytes  int           `envconfig:"PRODUCER_BATCH_BYTES"`
+	ProducerCompression string        `envconfig:"PRODUCER_COMPRESSION"`
+	ProducerAcks        string        `envconfig:"PRODUCER_ACKS"`
+	ProducerIdempotent  bool          `envconfig:"PRODUCER_IDEMPOTENT"`
 }
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkatarget
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Shopify/sarama"
+	"github.com/tidwall/gjson"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/types"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+)
+
+const (
+	// prefix of record headers carrying CloudEvent attributes in binary content mode.
+	ceHeaderPrefix = "ce_"
+	// header carrying the content type of the record value.
+	contentTypeHeader = "content-type"
+	// content type of records produced in structured content mode.
+	structuredContentType = cloudevents.ApplicationCloudEventsJSON
+)
+
+// ceHeaders contains the CloudEvent attributes mapped to 'ce_' prefixed headers.
+var ceHeaders = spec.WithPrefix(ceHeaderPrefix)
+
+// valueSelector selects a value from a CloudEvent.
+// It is decoded by envconfig from its JSON representation.
+type valueSelector v1alpha1.KafkaTargetValueSelector
+
+// Decode implements envconfig.Decoder.
+func (s *valueSelector) Decode(value string) error {
+	return json.Unmarshal([]byte(value), s)
+}
+
+// value returns the value selected from the given event, and whether that
+// value was found.
+func (s *valueSelector) value(e *cloudevents.Event) (string, bool) {
+	switch {
+	case s.Attribute != nil:
+		version := spec.VS.Version(e.SpecVersion())
+		if version == nil {
+			return "", false
+		}
+		attr := version.Attribute(*s.Attribute)
+		if attr == nil {
+			return "", false
+		}
+		return formatValue(attr.Get(e.Context))
+
+	case s.Extension != nil:
+		return formatValue(e.Extensions()[*s.Extension])
+
+	case s.DataPath != nil:
+		res := gjson.GetBytes(e.Data(), *s.DataPath)
+		if !res.Exists() {
+			return "", false
+		}
+		return res.String(), true
+	}
+
+	return "", false
+}
+
+// formatValue returns the canonical string representation of a CloudEvent
+// attribute value.
+func formatValue(v interface{}) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	s, err := types.Format(v)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// recordHeaders is a list of headers set on produced records.
+// It is decoded by envconfig from its JSON representation.
+type recordHeaders []v1alpha1.KafkaTargetHeader
+
+// Decode implements envconfig.Decoder.
+func (h *recordHeaders) Decode(value string) error {
+	return json.Unmarshal([]byte(value), h)
+}
+
+// headersFor returns the headers to set on the record produced from the
+// given event. Derived headers are omitted when the event does not contain
+// the selected value.
+func (h recordHeaders) headersFor(e *cloudevents.Event) []sarama.RecordHeader {
+	hdrs := make([]sarama.RecordHeader, 0, len(h))
+
+	for _, hdr := range h {
+		var val string
+
+		switch {
+		case hdr.Value != nil:
+			val = *hdr.Value
+		case hdr.ValueFrom != nil:
+			var ok bool
+			if val, ok = (*valueSelector)(hdr.ValueFrom).value(e); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+
+		hdrs = append(hdrs, sarama.RecordHeader{
+			Key:   []byte(hdr.Name),
+			Value: []byte(val),
+		})
+	}
+
+	return hdrs
+}
+
+// encodeStructured encodes the given event as a record value and headers
+// according to the structured content mode of the CloudEvents Kafka protocol
+// binding.
+func encodeStructured(e *cloudevents.Event) ([]byte, []sarama.RecordHeader, error) {
+	val, err := json.Marshal(e)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling CloudEvent to JSON: %w", err)
+	}
+
+	hdrs := []sarama.RecordHeader{{
+		Key:   []byte(contentTypeHeader),
+		Value: []byte(structuredContentType),
+	}}
+
+	return val, hdrs, nil
+}
+
+// encodeBinary encodes the given event as a record value and headers
+// according to the binary content mode of the CloudEvents Kafka protocol
+// binding.
+func encodeBinary(e *cloudevents.Event) ([]byte, []sarama.RecordHeader, error) {
+	version := ceHeaders.Version(e.SpecVersion())
+	if version == nil {
+		return nil, nil, fmt.Errorf("unsupported CloudEvents spec version %q", e.SpecVersion())
+	}
+
+	var hdrs []sarama.RecordHeader
+
+	for _, attr := range version.Attributes() {
+		val, ok := formatValue(attr.Get(e.Context))
+		if !ok {
+			continue
+		}
+
+		key := attr.PrefixedName()
+		if attr.Kind() == spec.DataContentType {
+			key = contentTypeHeader
+		}
+
+		hdrs = append(hdrs, sarama.RecordHeader{
+			Key:   []byte(key),
+			Value: []byte(val),
+		})
+	}
+
+	exts := e.Extensions()
+	names := make([]string, 0, len(exts))
+	for name := range exts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		val, ok := formatValue(exts[name])
+		if !ok {
+			continue
+		}
+
+		hdrs = append(hdrs, sarama.RecordHeader{
+			Key:   []byte(ceHeaderPrefix + name),
+			Value: []byte(val),
+		})
+	}
+
+	return e.Data(), hdrs, nil
+}
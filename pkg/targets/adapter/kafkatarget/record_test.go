@@ -0,0 +1,171 @@
+/*
+Copyright 2022 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkatarget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+)
+
+func TestNewRecord(t *testing.T) {
+	const topic = "test-topic"
+
+	testCases := map[string]struct {
+		adapter *kafkaAdapter
+
+		expectKey     string
+		expectValue   string
+		expectHeaders map[string]string
+	}{
+		"default structured mode": {
+			adapter:   &kafkaAdapter{},
+			expectKey: "abc-123",
+			expectValue: `{"specversion":"1.0","id":"abc-123","source":"test.source","type":"test.type",` +
+				`"subject":"test-subject","datacontenttype":"application/json","time":"2022-10-17T10:00:00Z",` +
+				`"customerid":"c-1","data":{"customer":{"id":"c-2"},"msg":"hi"}}`,
+			expectHeaders: map[string]string{
+				"content-type": "application/cloudevents+json",
+			},
+		},
+		"binary mode": {
+			adapter: &kafkaAdapter{
+				contentMode: v1alpha1.KafkaTargetContentModeBinary,
+			},
+			expectKey:   "abc-123",
+			expectValue: `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{
+				"ce_specversion": "1.0",
+				"ce_id":          "abc-123",
+				"ce_source":      "test.source",
+				"ce_type":        "test.type",
+				"ce_subject":     "test-subject",
+				"ce_time":        "2022-10-17T10:00:00Z",
+				"ce_customerid":  "c-1",
+				"content-type":   "application/json",
+			},
+		},
+		"discarded context": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				contentMode:      v1alpha1.KafkaTargetContentModeBinary,
+			},
+			expectKey:     "abc-123",
+			expectValue:   `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{},
+		},
+		"key from attribute": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				partitionKey:     &valueSelector{Attribute: strPtr("subject")},
+			},
+			expectKey:     "test-subject",
+			expectValue:   `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{},
+		},
+		"key from extension": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				partitionKey:     &valueSelector{Extension: strPtr("customerid")},
+			},
+			expectKey:     "c-1",
+			expectValue:   `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{},
+		},
+		"key from data path": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				partitionKey:     &valueSelector{DataPath: strPtr("customer.id")},
+			},
+			expectKey:     "c-2",
+			expectValue:   `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{},
+		},
+		"missing key falls back to ID": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				partitionKey:     &valueSelector{Extension: strPtr("missing")},
+			},
+			expectKey:     "abc-123",
+			expectValue:   `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{},
+		},
+		"static and derived headers": {
+			adapter: &kafkaAdapter{
+				discardCEContext: true,
+				headers: recordHeaders{
+					{Name: "static", Value: strPtr("value")},
+					{Name: "type", ValueFrom: &v1alpha1.KafkaTargetValueSelector{Attribute: strPtr("type")}},
+					{Name: "msg", ValueFrom: &v1alpha1.KafkaTargetValueSelector{DataPath: strPtr("msg")}},
+					{Name: "missing", ValueFrom: &v1alpha1.KafkaTargetValueSelector{Extension: strPtr("missing")}},
+				},
+			},
+			expectKey:   "abc-123",
+			expectValue: `{"customer":{"id":"c-2"},"msg":"hi"}`,
+			expectHeaders: map[string]string{
+				"static": "value",
+				"type":   "test.type",
+				"msg":    "hi",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			tc.adapter.topic = topic
+
+			msg, err := tc.adapter.newRecord(newTestEvent())
+			require.NoError(t, err)
+
+			assert.Equal(t, topic, msg.Topic)
+			assert.Equal(t, sarama.StringEncoder(tc.expectKey), msg.Key)
+			assert.JSONEq(t, tc.expectValue, string(msg.Value.(sarama.ByteEncoder)))
+
+			hdrs := make(map[string]string, len(msg.Headers))
+			for _, h := range msg.Headers {
+				hdrs[string(h.Key)] = string(h.Value)
+			}
+			assert.Equal(t, tc.expectHeaders, hdrs)
+		})
+	}
+}
+
+func newTestEvent() *cloudevents.Event {
+	e := cloudevents.NewEvent()
+	e.SetID("abc-123")
+	e.SetSource("test.source")
+	e.SetType("test.type")
+	e.SetSubject("test-subject")
+	e.SetTime(time.Date(2022, 10, 17, 10, 0, 0, 0, time.UTC))
+	e.SetExtension("customerid", "c-1")
+	e.SetDataContentType(cloudevents.ApplicationJSON)
+	e.DataEncoded = []byte(`{"customer":{"id":"c-2"},"msg":"hi"}`)
+
+	return &e
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -17,7 +17,14 @@ limitations under the License.
 package logztarget
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -31,9 +38,16 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 )
 
+// Port of the HTTPS bulk API of the listener.
+// https://docs.logz.io/shipping/log-sources/json-uploader.html
+const listenerPort = "8071"
+
+const httpTimeout = 30 * time.Second
+
 // NewTarget adapter implementation
 func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClient cloudevents.Client) pkgadapter.Adapter {
 	logger := logging.FromContext(ctx)
@@ -57,30 +71,53 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicf("Error creating CloudEvents replier: %v", err)
 	}
 
-	l, err := logzio.New(
-		env.ShippingToken,
-		logzio.SetUrl("https://"+env.LogsListenerURL+":8071"),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	return &logzAdapter{
-		l: l,
-
+	a := &logzAdapter{
 		replier:  replier,
 		ceClient: ceClient,
 		logger:   logger,
 
 		sr: metrics.MustNewEventProcessingStatsReporter(mt),
 	}
+
+	if !env.Config.Enabled() {
+		l, err := logzio.New(
+			env.ShippingToken,
+			logzio.SetUrl("https://"+env.LogsListenerURL+":"+listenerPort),
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		a.l = l
+		return a
+	}
+
+	listenerURL := url.URL{
+		Scheme:   "https",
+		Host:     env.LogsListenerURL + ":" + listenerPort,
+		RawQuery: url.Values{"token": []string{env.ShippingToken}}.Encode(),
+	}
+
+	a.listenerURL = listenerURL.String()
+	a.httpClient = &http.Client{Timeout: httpTimeout}
+
+	// the bulk API accepts newline-delimited logs.
+	a.batcher = batch.New(a.sendBatch, batch.EncodeNDJSON, env.Config)
+
+	return a
 }
 
 var _ pkgadapter.Adapter = (*logzAdapter)(nil)
 
 type logzAdapter struct {
+	// used when batching is disabled
 	l *logzio.LogzioSender
 
+	// used when batching is enabled
+	listenerURL string
+	httpClient  *http.Client
+	batcher     *batch.Batcher
+
 	replier  *targetce.Replier
 	ceClient cloudevents.Client
 	logger   *zap.SugaredLogger
@@ -91,7 +128,15 @@ type logzAdapter struct {
 // Returns if stopCh is closed or Send() returns an error.
 func (a *logzAdapter) Start(ctx context.Context) error {
 	a.logger.Info("Starting Logz adapter")
-	return a.ceClient.StartReceiver(ctx, a.dispatch)
+
+	if a.batcher == nil {
+		return a.ceClient.StartReceiver(ctx, a.dispatch)
+	}
+
+	// send buffered logs before returning
+	defer a.batcher.Flush(context.Background())
+
+	return a.ceClient.StartReceiver(ctx, a.dispatchBatch)
 }
 
 func (a *logzAdapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
@@ -102,3 +147,73 @@ func (a *logzAdapter) dispatch(ctx context.Context, event cloudevents.Event) (*c
 
 	return a.replier.Ok(&event, "ok")
 }
+
+func (a *logzAdapter) dispatchBatch(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	if _, err := a.batcher.Add(ctx, logLine(event.Data())); err != nil {
+		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+	}
+
+	return a.replier.Ok(&event, "ok")
+}
+
+// logLine returns the given data as a single-line JSON log. Data which isn't
+// a JSON object is used as the message of the log.
+func logLine(data []byte) []byte {
+	var line bytes.Buffer
+	if err := json.Compact(&line, data); err == nil && bytes.HasPrefix(line.Bytes(), []byte{'{'}) {
+		return line.Bytes()
+	}
+
+	// marshaling a string can not fail
+	msg, _ := json.Marshal(struct {
+		Message string `json:"message"`
+	}{Message: string(data)})
+
+	return msg
+}
+
+// bulkResponse is returned by the listener when some logs of a bulk request
+// were rejected.
+type bulkResponse struct {
+	MalformedLines  int `json:"malformedLines"`
+	OversizedLines  int `json:"oversizedLines"`
+	EmptyLogLines   int `json:"emptyLogLines"`
+	SuccessfulLines int `json:"successfulLines"`
+}
+
+// sendBatch sends a batch of logs to the bulk API of the listener.
+func (a *logzAdapter) sendBatch(ctx context.Context, b *batch.Request) ([]byte, error) {
+	req, err := b.NewHTTPRequest(ctx, a.listenerURL)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, batch.Retryable(err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, batch.Retryable(err)
+	}
+
+	if res.StatusCode == http.StatusOK {
+		return resBody, nil
+	}
+
+	// The listener doesn't report which lines it rejected, only how many of
+	// them, so the failure applies to the whole batch.
+	var bulkRes bulkResponse
+	if res.StatusCode == http.StatusBadRequest && json.Unmarshal(resBody, &bulkRes) == nil &&
+		bulkRes.MalformedLines+bulkRes.OversizedLines+bulkRes.EmptyLogLines > 0 {
+
+		return nil, fmt.Errorf("listener rejected %d of %d logs (malformed: %d, oversized: %d, empty: %d)",
+			len(b.Items)-bulkRes.SuccessfulLines, len(b.Items),
+			bulkRes.MalformedLines, bulkRes.OversizedLines, bulkRes.EmptyLogLines)
+	}
+
+	return nil, batch.ResponseError(res.StatusCode, resBody)
+}
@@ -18,6 +18,8 @@ package logztarget
 
 import (
 	pkgadapter "knative.dev/eventing/pkg/adapter/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 )
 
 // EnvAccessorCtor for configuration parameters
@@ -39,4 +41,7 @@ type envAccessor struct {
 
 	// CloudEvents responses parametrization
 	CloudEventPayloadPolicy string `envconfig:"EVENTS_PAYLOAD_POLICY" default:"error"`
+
+	// Batching of logs
+	batch.Config
 }
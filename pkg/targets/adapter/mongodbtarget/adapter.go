@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // Package mongodbtarget implements an adapter that connects to a MongoDB database
-// and allows a user to insert, query, and update documents via cloudevents.
+// and allows a user to insert, query, update and delete documents via cloudevents.
 package mongodbtarget
 
 import (
@@ -59,6 +59,15 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicf("Error creating CloudEvents replier: %v", err)
 	}
 
+	// query results are always sent back, regardless of the payload policy.
+	queryReplier, err := targetce.New(env.Component, logger.Named("replier"),
+		targetce.ReplierWithStatefulHeaders(env.BridgeIdentifier),
+		targetce.ReplierWithStaticResponseType(v1alpha1.EventTypeMongoDBQueryResponse),
+		targetce.ReplierWithPayloadPolicy(targetce.PayloadPolicyAlways))
+	if err != nil {
+		logger.Panicf("Error creating CloudEvents query replier: %v", err)
+	}
+
 	mt := &pkgadapter.MetricTag{
 		ResourceGroup: targets.MongoDBTargetResource.String(),
 		Namespace:     envAcc.GetNamespace(),
@@ -70,10 +79,11 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		defaultDatabase:   env.DefaultDatabase,
 		defaultCollection: env.DefaultCollection,
 
-		replier:  replier,
-		ceClient: ceClient,
-		logger:   logger,
-		sr:       metrics.MustNewEventProcessingStatsReporter(mt),
+		replier:      replier,
+		queryReplier: queryReplier,
+		ceClient:     ceClient,
+		logger:       logger,
+		sr:           metrics.MustNewEventProcessingStatsReporter(mt),
 	}
 }
 
@@ -84,10 +94,11 @@ type adapter struct {
 	defaultDatabase   string
 	defaultCollection string
 
-	replier  *targetce.Replier
-	ceClient cloudevents.Client
-	logger   *zap.SugaredLogger
-	sr       *metrics.EventProcessingStatsReporter
+	replier      *targetce.Replier
+	queryReplier *targetce.Replier
+	ceClient     cloudevents.Client
+	logger       *zap.SugaredLogger
+	sr           *metrics.EventProcessingStatsReporter
 }
 
 func (a *adapter) Start(ctx context.Context) error {
@@ -125,6 +136,35 @@ func (a *adapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloud
 			a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
 			return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
 		}
+	case v1alpha1.EventTypeMongoDBDelete:
+		resp, err := a.delete(event, ctx)
+		if err != nil {
+			a.logger.Errorw("invoking .delete: ", zap.Error(err))
+			a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
+			return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+		}
+		return a.replier.Ok(&event, resp)
+	case v1alpha1.EventTypeMongoDBBulkWrite:
+		resp, err := a.bulkWrite(event, ctx)
+		if err != nil {
+			a.logger.Errorw("invoking .bulkwrite: ", zap.Error(err))
+			a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
+			// partially applied writes are reported along with the error
+			var details interface{}
+			if resp != nil {
+				details = resp
+			}
+			return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, details)
+		}
+		return a.replier.Ok(&event, resp)
+	case v1alpha1.EventTypeMongoDBQueryAggregate:
+		resp, err := a.aggregateQuery(event, ctx)
+		if err != nil {
+			a.logger.Errorw("invoking .query.aggregate: ", zap.Error(err))
+			a.sr.ReportProcessingError(true, ceTypeTag, ceSrcTag)
+			return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+		}
+		return a.queryReplier.Ok(&event, resp, targetce.ResponseWithSubject("query-result"))
 	default:
 		if err := a.insertFromConfig(event, ctx); err != nil {
 			a.logger.Errorw("invoking arbirary insert: ", zap.Error(err))
@@ -240,6 +280,7 @@ func (a *adapter) update(e cloudevents.Event, ctx context.Context) error {
 		ctx,
 		bson.M{up.SearchKey: up.SearchValue},
 		bson.D{{Key: "$set", Value: bson.D{{Key: up.UpdateKey, Value: up.UpdateValue}}}},
+		options.Update().SetUpsert(up.Upsert),
 	)
 	if err != nil {
 		return err
@@ -247,3 +288,106 @@ func (a *adapter) update(e cloudevents.Event, ctx context.Context) error {
 
 	return nil
 }
+
+// delete deletes the documents matching a key/value pair from a mongodb collection.
+func (a *adapter) delete(e cloudevents.Event, ctx context.Context) (*DeleteResponse, error) {
+	dp := &DeletePayload{}
+	if err := e.DataAs(dp); err != nil {
+		return nil, err
+	}
+	col := a.defaultCollection
+	db := a.defaultDatabase
+	if dp.Collection != "" {
+		col = dp.Collection
+	}
+	if dp.Database != "" {
+		db = dp.Database
+	}
+
+	if dp.SearchKey == "" {
+		return nil, fmt.Errorf("no search key to match documents against")
+	}
+
+	collection := a.mclient.Database(db).Collection(col)
+	filter := bson.M{dp.SearchKey: dp.SearchValue}
+
+	var res *mongo.DeleteResult
+	var err error
+	if dp.Many {
+		res, err = collection.DeleteMany(ctx, filter)
+	} else {
+		res, err = collection.DeleteOne(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteResponse{DeletedCount: res.DeletedCount}, nil
+}
+
+// bulkWrite executes a list of write operations against a mongodb collection.
+// When some of the operations fail, the result of the applied ones is returned
+// along with the error.
+func (a *adapter) bulkWrite(e cloudevents.Event, ctx context.Context) (*BulkWriteResponse, error) {
+	bp := &BulkWritePayload{}
+	if err := e.DataAs(bp); err != nil {
+		return nil, err
+	}
+	col := a.defaultCollection
+	db := a.defaultDatabase
+	if bp.Collection != "" {
+		col = bp.Collection
+	}
+	if bp.Database != "" {
+		db = bp.Database
+	}
+
+	models, err := writeModels(bp.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.BulkWrite()
+	if bp.Ordered != nil {
+		opts.SetOrdered(*bp.Ordered)
+	}
+
+	collection := a.mclient.Database(db).Collection(col)
+	res, err := collection.BulkWrite(ctx, models, opts)
+	return bulkWriteResponse(res), err
+}
+
+// aggregateQuery runs an aggregation pipeline against a mongodb collection.
+func (a *adapter) aggregateQuery(e cloudevents.Event, ctx context.Context) ([]bson.M, error) {
+	ap := &AggregatePayload{}
+	if err := e.DataAs(ap); err != nil {
+		return nil, err
+	}
+	col := a.defaultCollection
+	db := a.defaultDatabase
+	if ap.Collection != "" {
+		col = ap.Collection
+	}
+	if ap.Database != "" {
+		db = ap.Database
+	}
+
+	pipeline, err := parsePipeline(ap.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := a.mclient.Database(db).Collection(col)
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty result is returned as an empty array rather than null
+	results := []bson.M{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbtarget
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/triggermesh/triggermesh/pkg/common/mongodb"
+)
+
+// writeModels returns the driver models of the given bulk write operations.
+func writeModels(ops []BulkWriteOperation) ([]mongo.WriteModel, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("no operations to write")
+	}
+
+	models := make([]mongo.WriteModel, 0, len(ops))
+	for i, op := range ops {
+		m, err := writeModel(op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		models = append(models, m)
+	}
+
+	return models, nil
+}
+
+// writeModel returns the driver model of a single bulk write operation.
+func writeModel(op BulkWriteOperation) (mongo.WriteModel, error) {
+	var models []mongo.WriteModel
+
+	if op.InsertOne != nil {
+		doc, err := extJSONDocument(op.InsertOne.Document, "document")
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewInsertOneModel().SetDocument(doc))
+	}
+
+	if op.UpdateOne != nil {
+		filter, update, err := updateDocuments(op.UpdateOne)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(filter).SetUpdate(update).SetUpsert(op.UpdateOne.Upsert))
+	}
+
+	if op.UpdateMany != nil {
+		filter, update, err := updateDocuments(op.UpdateMany)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewUpdateManyModel().
+			SetFilter(filter).SetUpdate(update).SetUpsert(op.UpdateMany.Upsert))
+	}
+
+	if op.ReplaceOne != nil {
+		filter, err := extJSONDocument(op.ReplaceOne.Filter, "filter")
+		if err != nil {
+			return nil, err
+		}
+		replacement, err := extJSONDocument(op.ReplaceOne.Replacement, "replacement")
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(filter).SetReplacement(replacement).SetUpsert(op.ReplaceOne.Upsert))
+	}
+
+	if op.DeleteOne != nil {
+		filter, err := extJSONDocument(op.DeleteOne.Filter, "filter")
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewDeleteOneModel().SetFilter(filter))
+	}
+
+	if op.DeleteMany != nil {
+		filter, err := extJSONDocument(op.DeleteMany.Filter, "filter")
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, mongo.NewDeleteManyModel().SetFilter(filter))
+	}
+
+	if len(models) != 1 {
+		return nil, fmt.Errorf("exactly one operation must be set, got %d", len(models))
+	}
+
+	return models[0], nil
+}
+
+// updateDocuments returns the filter and update documents of an update operation.
+func updateDocuments(u *BulkUpdate) (filter, update bson.D, err error) {
+	if filter, err = extJSONDocument(u.Filter, "filter"); err != nil {
+		return nil, nil, err
+	}
+	if update, err = extJSONDocument(u.Update, "update"); err != nil {
+		return nil, nil, err
+	}
+	return filter, update, nil
+}
+
+// extJSONDocument parses a document expressed in MongoDB Extended JSON.
+func extJSONDocument(raw json.RawMessage, field string) (bson.D, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("missing %s", field)
+	}
+
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(raw, false, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", field, err)
+	}
+	return doc, nil
+}
+
+// parsePipeline parses an aggregation pipeline expressed in MongoDB Extended JSON.
+func parsePipeline(raw json.RawMessage) (mongo.Pipeline, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("missing pipeline")
+	}
+
+	pipeline, err := mongodb.ParsePipeline(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pipeline: %w", err)
+	}
+	return pipeline, nil
+}
+
+// bulkWriteResponse returns the response data of a bulk write result.
+func bulkWriteResponse(res *mongo.BulkWriteResult) *BulkWriteResponse {
+	if res == nil {
+		return nil
+	}
+
+	return &BulkWriteResponse{
+		InsertedCount: res.InsertedCount,
+		MatchedCount:  res.MatchedCount,
+		ModifiedCount: res.ModifiedCount,
+		DeletedCount:  res.DeletedCount,
+		UpsertedCount: res.UpsertedCount,
+		UpsertedIDs:   res.UpsertedIDs,
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbtarget
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWriteModels(t *testing.T) {
+	testCases := map[string]struct {
+		payload   string
+		expect    []mongo.WriteModel
+		expectErr bool
+	}{
+		"all operations": {
+			payload: `[
+				{"insertOne": {"document": {"name": "a", "n": 1}}},
+				{"updateOne": {"filter": {"name": "a"}, "update": {"$inc": {"n": 1}}, "upsert": true}},
+				{"updateMany": {"filter": {}, "update": {"$set": {"seen": true}}}},
+				{"replaceOne": {"filter": {"_id": {"$oid": "63c829397c2fdbfebdd93883"}}, "replacement": {"name": "b"}}},
+				{"deleteOne": {"filter": {"name": "b"}}},
+				{"deleteMany": {"filter": {"seen": true}}}
+			]`,
+			expect: []mongo.WriteModel{
+				mongo.NewInsertOneModel().SetDocument(bson.D{{Key: "name", Value: "a"}, {Key: "n", Value: int32(1)}}),
+				mongo.NewUpdateOneModel().
+					SetFilter(bson.D{{Key: "name", Value: "a"}}).
+					SetUpdate(bson.D{{Key: "$inc", Value: bson.D{{Key: "n", Value: int32(1)}}}}).
+					SetUpsert(true),
+				mongo.NewUpdateManyModel().
+					SetFilter(bson.D{}).
+					SetUpdate(bson.D{{Key: "$set", Value: bson.D{{Key: "seen", Value: true}}}}).
+					SetUpsert(false),
+				mongo.NewReplaceOneModel().
+					SetFilter(bson.D{{Key: "_id", Value: mustObjectID(t, "63c829397c2fdbfebdd93883")}}).
+					SetReplacement(bson.D{{Key: "name", Value: "b"}}).
+					SetUpsert(false),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{Key: "name", Value: "b"}}),
+				mongo.NewDeleteManyModel().SetFilter(bson.D{{Key: "seen", Value: true}}),
+			},
+		},
+		"no operations": {
+			payload:   `[]`,
+			expectErr: true,
+		},
+		"empty operation": {
+			payload:   `[{}]`,
+			expectErr: true,
+		},
+		"several operations in one entry": {
+			payload:   `[{"insertOne": {"document": {}}, "deleteOne": {"filter": {}}}]`,
+			expectErr: true,
+		},
+		"missing filter": {
+			payload:   `[{"deleteOne": {}}]`,
+			expectErr: true,
+		},
+		"invalid document": {
+			payload:   `[{"insertOne": {"document": [1, 2]}}]`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			var ops []BulkWriteOperation
+			require.NoError(t, json.Unmarshal([]byte(tc.payload), &ops))
+
+			models, err := writeModels(ops)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, models)
+		})
+	}
+}
+
+func TestParsePipeline(t *testing.T) {
+	pipeline, err := parsePipeline(json.RawMessage(`[{"$match": {"status": "A"}}]`))
+	require.NoError(t, err)
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "status", Value: "A"}}}},
+	}, pipeline)
+
+	_, err = parsePipeline(nil)
+	assert.EqualError(t, err, "missing pipeline")
+}
+
+func mustObjectID(t *testing.T, hex string) primitive.ObjectID {
+	t.Helper()
+	id, err := primitive.ObjectIDFromHex(hex)
+	require.NoError(t, err)
+	return id
+}
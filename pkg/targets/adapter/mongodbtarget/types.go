@@ -42,6 +42,86 @@ type UpdatePayload struct {
 	SearchValue string `json:"searchValue"`
 	UpdateKey   string `json:"updateKey"`
 	UpdateValue string `json:"updateValue"`
+	// Upsert inserts a new document when none matches the search key/value pair.
+	Upsert bool `json:"upsert"`
+}
+
+// DeletePayload defines the expected data found at the "io.triggermesh.mongodb.delete" payload.
+type DeletePayload struct {
+	Database    string `json:"database"`
+	Collection  string `json:"collection"`
+	SearchKey   string `json:"searchKey"`
+	SearchValue string `json:"searchValue"`
+	// Many deletes all matching documents instead of the first one.
+	Many bool `json:"many"`
+}
+
+// DeleteResponse defines the data structure returned after a delete operation.
+type DeleteResponse struct {
+	DeletedCount int64 `json:"deletedCount"`
+}
+
+// BulkWritePayload defines the expected data found at the "io.triggermesh.mongodb.bulkwrite" payload.
+type BulkWritePayload struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	// Ordered stops the execution at the first failed operation. Defaults to true.
+	Ordered    *bool                `json:"ordered"`
+	Operations []BulkWriteOperation `json:"operations"`
+}
+
+// BulkWriteOperation is a single write operation of a bulk write. Exactly one
+// of its fields must be set. Documents, filters and updates are expressed in
+// MongoDB Extended JSON.
+type BulkWriteOperation struct {
+	InsertOne  *BulkInsertOne `json:"insertOne,omitempty"`
+	UpdateOne  *BulkUpdate    `json:"updateOne,omitempty"`
+	UpdateMany *BulkUpdate    `json:"updateMany,omitempty"`
+	ReplaceOne *BulkReplace   `json:"replaceOne,omitempty"`
+	DeleteOne  *BulkDelete    `json:"deleteOne,omitempty"`
+	DeleteMany *BulkDelete    `json:"deleteMany,omitempty"`
+}
+
+// BulkInsertOne inserts a single document.
+type BulkInsertOne struct {
+	Document json.RawMessage `json:"document"`
+}
+
+// BulkUpdate updates the documents matching a filter.
+type BulkUpdate struct {
+	Filter json.RawMessage `json:"filter"`
+	Update json.RawMessage `json:"update"`
+	Upsert bool            `json:"upsert"`
+}
+
+// BulkReplace replaces the document matching a filter.
+type BulkReplace struct {
+	Filter      json.RawMessage `json:"filter"`
+	Replacement json.RawMessage `json:"replacement"`
+	Upsert      bool            `json:"upsert"`
+}
+
+// BulkDelete deletes the documents matching a filter.
+type BulkDelete struct {
+	Filter json.RawMessage `json:"filter"`
+}
+
+// BulkWriteResponse defines the data structure returned after a bulk write.
+type BulkWriteResponse struct {
+	InsertedCount int64                 `json:"insertedCount"`
+	MatchedCount  int64                 `json:"matchedCount"`
+	ModifiedCount int64                 `json:"modifiedCount"`
+	DeletedCount  int64                 `json:"deletedCount"`
+	UpsertedCount int64                 `json:"upsertedCount"`
+	UpsertedIDs   map[int64]interface{} `json:"upsertedIds,omitempty"`
+}
+
+// AggregatePayload defines the expected data found at the "io.triggermesh.mongodb.query.aggregate" payload.
+type AggregatePayload struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	// Pipeline is an array of aggregation stages expressed in MongoDB Extended JSON.
+	Pipeline json.RawMessage `json:"pipeline"`
 }
 
 // QueryResponse defines the expected data structure received from a query to MongoDB.
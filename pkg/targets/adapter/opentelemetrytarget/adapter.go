@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -35,9 +36,15 @@ import (
 	"go.opentelemetry.io/contrib/exporters/metric/cortex" //nolint:staticcheck
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	"go.opentelemetry.io/otel/metric/sdkapi"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
@@ -45,7 +52,10 @@ import (
 	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 )
 
-var _ pkgadapter.Adapter = (*cortexAdapter)(nil)
+var (
+	_ pkgadapter.Adapter = (*cortexAdapter)(nil)
+	_ pkgadapter.Adapter = (*otlpAdapter)(nil)
+)
 
 // instrumentRef is a reference to an instrument descriptor and its implementation.
 type instrumentRef struct {
@@ -56,6 +66,10 @@ type instrumentRef struct {
 type opentelemetryAdapter struct {
 	instruments map[string]map[string]*instrumentRef
 
+	// otlp is only set when using the OTLP exporter, which is
+	// required to push logs and traces.
+	otlp otlpClient
+
 	replier  *targetce.Replier
 	ceClient cloudevents.Client
 	logger   *zap.SugaredLogger
@@ -69,6 +83,12 @@ type cortexAdapter struct {
 	cortexConfig *cortex.Config
 }
 
+type otlpAdapter struct {
+	opentelemetryAdapter
+
+	pushInterval time.Duration
+}
+
 // NewTarget adapter implementation
 func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClient cloudevents.Client) pkgadapter.Adapter {
 	logger := logging.FromContext(ctx)
@@ -90,10 +110,6 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicf("Error creating CloudEvents replier: %v", err)
 	}
 
-	if len(env.Instruments) == 0 {
-		logger.Panic("No instruments present")
-	}
-
 	// instruments structure is a map nested with two keys.
 	//
 	// - Instrument name: this will usually be unique, but it could
@@ -111,6 +127,36 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		instruments[i.Name][i.Instrument] = &instrumentRef{descriptor: i.Descriptor}
 	}
 
+	ota := opentelemetryAdapter{
+		instruments: instruments,
+
+		replier:  replier,
+		ceClient: ceClient,
+		logger:   logger,
+
+		sr: metrics.MustNewEventProcessingStatsReporter(mt),
+	}
+
+	switch env.Exporter {
+	case exporterCortex:
+		if len(env.Instruments) == 0 {
+			logger.Panic("No instruments present")
+		}
+
+	case exporterOTLP:
+		if ota.otlp, err = newOTLPClient(env); err != nil {
+			logger.Panicf("Error creating OTLP client: %v", err)
+		}
+
+		return &otlpAdapter{
+			opentelemetryAdapter: ota,
+			pushInterval:         env.OTLPPushInterval,
+		}
+
+	default:
+		logger.Panicf("Unsupported exporter %q", env.Exporter)
+	}
+
 	ccfg := &cortex.Config{
 		Endpoint:      env.CortexEndpoint,
 		BearerToken:   env.CortexBearerToken,
@@ -123,16 +169,8 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 	}
 
 	return &cortexAdapter{
-		cortexConfig: ccfg,
-		opentelemetryAdapter: opentelemetryAdapter{
-			instruments: instruments,
-
-			replier:  replier,
-			ceClient: ceClient,
-			logger:   logger,
-
-			sr: metrics.MustNewEventProcessingStatsReporter(mt),
-		},
+		cortexConfig:         ccfg,
+		opentelemetryAdapter: ota,
 	}
 }
 
@@ -157,14 +195,57 @@ func (a *cortexAdapter) Start(ctx context.Context) error {
 		}
 	}()
 
-	meter := cortexctl.Meter("TriggerMesh")
+	if err := a.registerInstruments(cortexctl.Meter("TriggerMesh")); err != nil {
+		return err
+	}
+
+	return a.ceClient.StartReceiver(ctx, a.dispatch)
+}
 
-	// Iterate over all instruments, create their instances and
-	// store the link back to the instruments map.
+// Start is a blocking function and will return if an error occurs
+// or the context is cancelled.
+func (a *otlpAdapter) Start(ctx context.Context) error {
+	a.logger.Info("Starting OTLP adapter")
+
+	defer func() {
+		if err := a.otlp.close(); err != nil {
+			a.logger.Warnw("Error closing OTLP client", zap.Error(err))
+		}
+	}()
+
+	exporter := &otlpMetricsExporter{client: a.otlp}
+	otlpctl := controller.New(
+		processor.NewFactory(simple.NewWithHistogramDistribution(), exporter),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(a.pushInterval),
+	)
+	if err := otlpctl.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start OTLP controller: %w", err)
+	}
+
+	defer func() {
+		// Stopping the controller pushes pending metrics, which must
+		// not be prevented by the cancellation of the adapter context.
+		if err := otlpctl.Stop(context.Background()); err != nil {
+			a.logger.Warnw("Error stopping OTLP controller", zap.Error(err))
+		}
+	}()
+
+	if err := a.registerInstruments(otlpctl.Meter("TriggerMesh")); err != nil {
+		return err
+	}
+
+	return a.ceClient.StartReceiver(ctx, a.dispatch)
+}
+
+// registerInstruments iterates over all instruments, creates their instances
+// and stores the link back to the instruments map.
+func (a *opentelemetryAdapter) registerInstruments(meter metric.Meter) error {
 	for name, kindm := range a.instruments {
 		for kind, i := range kindm {
 
 			if i.descriptor.InstrumentKind().Synchronous() {
+				var err error
 				i.sync, err = meter.MeterImpl().NewSyncInstrument(i.descriptor)
 				if err != nil {
 					return fmt.Errorf("failed to create sync instrument: %v", err)
@@ -179,15 +260,54 @@ func (a *cortexAdapter) Start(ctx context.Context) error {
 		}
 	}
 
-	return a.ceClient.StartReceiver(ctx, a.dispatch)
+	return nil
 }
 
 func (a *opentelemetryAdapter) dispatch(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
-
-	if typ := event.Type(); typ != v1alpha1.EventTypeOpenTelemetryMetricsPush {
+	switch typ := event.Type(); typ {
+	case v1alpha1.EventTypeOpenTelemetryMetricsPush:
+		return a.pushMetrics(ctx, event)
+	case v1alpha1.EventTypeOpenTelemetryLogsPush, v1alpha1.EventTypeOpenTelemetryTracesPush:
+		if a.otlp == nil {
+			return a.replier.Error(&event, targetce.ErrorCodeEventContext,
+				fmt.Errorf("event type %q is only supported by the OTLP exporter", typ), nil)
+		}
+		return a.pushOTLP(ctx, event)
+	default:
 		return a.replier.Error(&event, targetce.ErrorCodeEventContext, fmt.Errorf("event type %q is not supported", typ), nil)
 	}
+}
+
+// pushOTLP forwards the OTLP logs or traces carried by the event to the
+// OTLP receiver.
+func (a *opentelemetryAdapter) pushOTLP(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	var err error
+
+	switch event.Type() {
+	case v1alpha1.EventTypeOpenTelemetryLogsPush:
+		req := &collogspb.ExportLogsServiceRequest{}
+		if err = unmarshalOTLP(&event, req); err != nil {
+			return a.replier.Error(&event, targetce.ErrorCodeRequestParsing, err, nil)
+		}
+		err = a.otlp.exportLogs(ctx, req)
+
+	case v1alpha1.EventTypeOpenTelemetryTracesPush:
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if err = unmarshalOTLP(&event, req); err != nil {
+			return a.replier.Error(&event, targetce.ErrorCodeRequestParsing, err, nil)
+		}
+		err = a.otlp.exportTraces(ctx, req)
+	}
+
+	if err != nil {
+		return a.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
+	}
+	return a.replier.Ack()
+}
 
+// pushMetrics records the measures carried by the event using the
+// registered instruments.
+func (a *opentelemetryAdapter) pushMetrics(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
 	ms := []Measure{}
 	if err := event.DataAs(&ms); err != nil {
 		return a.replier.Error(&event, targetce.ErrorCodeRequestParsing, err, nil)
@@ -96,17 +96,44 @@ func (is *Instruments) Decode(value string) error {
 	return nil
 }
 
+// Supported metrics exporters.
+const (
+	exporterCortex = "cortex"
+	exporterOTLP   = "otlp"
+)
+
+// Supported OTLP transport protocols.
+const (
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http"
+)
+
 type envAccessor struct {
 	pkgadapter.EnvConfig
 
+	// Exporter used to push telemetry data (cortex|otlp).
+	Exporter string `envconfig:"OPENTELEMETRY_EXPORTER" default:"cortex"`
+
 	// Cortex connection parameters.
 	CortexEndpoint      string        `envconfig:"OPENTELEMETRY_CORTEX_ENDPOINT"`
 	CortexRemoteTimeout time.Duration `envconfig:"OPENTELEMETRY_CORTEX_REMOTE_TIMEOUT" default:"30s"`
 	CortexBearerToken   string        `envconfig:"OPENTELEMETRY_CORTEX_BEARER_TOKEN"`
 	CortexPushInterval  time.Duration `envconfig:"OPENTELEMETRY_CORTEX_PUSH_INTERVAL" default:"10s"`
 
+	// OTLP connection parameters.
+	// The endpoint is a host:port address when using gRPC, and the base URL
+	// of the OTLP receiver, to which signal paths are appended, when using HTTP.
+	OTLPEndpoint     string            `envconfig:"OPENTELEMETRY_OTLP_ENDPOINT"`
+	OTLPProtocol     string            `envconfig:"OPENTELEMETRY_OTLP_PROTOCOL" default:"grpc"`
+	OTLPInsecure     bool              `envconfig:"OPENTELEMETRY_OTLP_INSECURE"`
+	OTLPHeaders      map[string]string `envconfig:"OPENTELEMETRY_OTLP_HEADERS"`
+	OTLPTimeout      time.Duration     `envconfig:"OPENTELEMETRY_OTLP_TIMEOUT" default:"10s"`
+	OTLPPushInterval time.Duration     `envconfig:"OPENTELEMETRY_OTLP_PUSH_INTERVAL" default:"10s"`
+
 	// OpenTelemetry instruments information.
-	Instruments Instruments `envconfig:"OPENTELEMETRY_INSTRUMENTS" required:"true"`
+	// Instruments are mandatory for the Cortex exporter, which only
+	// supports metrics.
+	Instruments Instruments `envconfig:"OPENTELEMETRY_INSTRUMENTS"`
 
 	// BridgeIdentifier is the name of the bridge workflow this target is part of
 	BridgeIdentifier string `envconfig:"EVENTS_BRIDGE_IDENTIFIER"`
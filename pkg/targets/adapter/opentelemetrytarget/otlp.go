@@ -0,0 +1,266 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opentelemetrytarget
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// Paths of the OTLP/HTTP receiver for each signal.
+const (
+	otlpHTTPMetricsPath = "/v1/metrics"
+	otlpHTTPLogsPath    = "/v1/logs"
+	otlpHTTPTracesPath  = "/v1/traces"
+)
+
+const contentTypeProtobuf = "application/x-protobuf"
+
+// otlpClient sends telemetry data to an OTLP receiver.
+type otlpClient interface {
+	exportMetrics(context.Context, *colmetricspb.ExportMetricsServiceRequest) error
+	exportLogs(context.Context, *collogspb.ExportLogsServiceRequest) error
+	exportTraces(context.Context, *coltracepb.ExportTraceServiceRequest) error
+	close() error
+}
+
+// newOTLPClient returns an OTLP client for the configured protocol.
+func newOTLPClient(env *envAccessor) (otlpClient, error) {
+	if env.OTLPEndpoint == "" {
+		return nil, fmt.Errorf("an OTLP endpoint is required")
+	}
+
+	switch env.OTLPProtocol {
+	case otlpProtocolGRPC:
+		return newOTLPGRPCClient(env.OTLPEndpoint, env.OTLPInsecure, env.OTLPHeaders, env.OTLPTimeout)
+	case otlpProtocolHTTP:
+		return newOTLPHTTPClient(env.OTLPEndpoint, env.OTLPHeaders, env.OTLPTimeout), nil
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", env.OTLPProtocol)
+	}
+}
+
+// otlpGRPCClient sends telemetry data using OTLP/gRPC.
+type otlpGRPCClient struct {
+	conn    *grpc.ClientConn
+	metrics colmetricspb.MetricsServiceClient
+	logs    collogspb.LogsServiceClient
+	traces  coltracepb.TraceServiceClient
+
+	headers metadata.MD
+	timeout time.Duration
+}
+
+var _ otlpClient = (*otlpGRPCClient)(nil)
+
+func newOTLPGRPCClient(endpoint string, plaintext bool, headers map[string]string, timeout time.Duration) (*otlpGRPCClient, error) {
+	creds := credentials.NewTLS(nil)
+	if plaintext {
+		creds = insecure.NewCredentials()
+	}
+
+	// the connection is established lazily, upon the first request.
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("creating gRPC connection to %q: %w", endpoint, err)
+	}
+
+	return &otlpGRPCClient{
+		conn:    conn,
+		metrics: colmetricspb.NewMetricsServiceClient(conn),
+		logs:    collogspb.NewLogsServiceClient(conn),
+		traces:  coltracepb.NewTraceServiceClient(conn),
+		headers: metadata.New(headers),
+		timeout: timeout,
+	}, nil
+}
+
+func (c *otlpGRPCClient) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *otlpGRPCClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+
+	res, err := c.metrics.Export(ctx, req)
+	if err != nil {
+		return err
+	}
+	return metricsPartialSuccessError(res)
+}
+
+func (c *otlpGRPCClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+
+	res, err := c.logs.Export(ctx, req)
+	if err != nil {
+		return err
+	}
+	return logsPartialSuccessError(res)
+}
+
+func (c *otlpGRPCClient) exportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+
+	res, err := c.traces.Export(ctx, req)
+	if err != nil {
+		return err
+	}
+	return tracesPartialSuccessError(res)
+}
+
+func (c *otlpGRPCClient) close() error {
+	return c.conn.Close()
+}
+
+// otlpHTTPClient sends telemetry data using OTLP/HTTP with binary protobuf
+// encoded payloads.
+type otlpHTTPClient struct {
+	client  *http.Client
+	baseURL string
+	headers map[string]string
+}
+
+var _ otlpClient = (*otlpHTTPClient)(nil)
+
+func newOTLPHTTPClient(baseURL string, headers map[string]string, timeout time.Duration) *otlpHTTPClient {
+	return &otlpHTTPClient{
+		client:  &http.Client{Timeout: timeout},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		headers: headers,
+	}
+}
+
+func (c *otlpHTTPClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	res := &colmetricspb.ExportMetricsServiceResponse{}
+	if err := c.post(ctx, otlpHTTPMetricsPath, req, res); err != nil {
+		return err
+	}
+	return metricsPartialSuccessError(res)
+}
+
+func (c *otlpHTTPClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	res := &collogspb.ExportLogsServiceResponse{}
+	if err := c.post(ctx, otlpHTTPLogsPath, req, res); err != nil {
+		return err
+	}
+	return logsPartialSuccessError(res)
+}
+
+func (c *otlpHTTPClient) exportTraces(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	res := &coltracepb.ExportTraceServiceResponse{}
+	if err := c.post(ctx, otlpHTTPTracesPath, req, res); err != nil {
+		return err
+	}
+	return tracesPartialSuccessError(res)
+}
+
+// post sends a protobuf encoded request to the given path of the OTLP receiver
+// and decodes its response.
+func (c *otlpHTTPClient) post(ctx context.Context, path string, in, out proto.Message) error {
+	body, err := proto.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshaling OTLP request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeProtobuf)
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending OTLP request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading OTLP response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("OTLP receiver responded with status %d: %s", res.StatusCode, resBody)
+	}
+
+	// an empty response is a full success.
+	if len(resBody) == 0 || !strings.HasPrefix(res.Header.Get("Content-Type"), contentTypeProtobuf) {
+		return nil
+	}
+	if err := proto.Unmarshal(resBody, out); err != nil {
+		return fmt.Errorf("decoding OTLP response: %w", err)
+	}
+	return nil
+}
+
+func (c *otlpHTTPClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// metricsPartialSuccessError returns an error when the OTLP receiver rejected
+// some of the exported data points.
+func metricsPartialSuccessError(res *colmetricspb.ExportMetricsServiceResponse) error {
+	ps := res.GetPartialSuccess()
+	if ps.GetRejectedDataPoints() == 0 {
+		return nil
+	}
+	return fmt.Errorf("OTLP receiver rejected %d data points: %s", ps.GetRejectedDataPoints(), ps.GetErrorMessage())
+}
+
+// logsPartialSuccessError returns an error when the OTLP receiver rejected
+// some of the exported log records.
+func logsPartialSuccessError(res *collogspb.ExportLogsServiceResponse) error {
+	ps := res.GetPartialSuccess()
+	if ps.GetRejectedLogRecords() == 0 {
+		return nil
+	}
+	return fmt.Errorf("OTLP receiver rejected %d log records: %s", ps.GetRejectedLogRecords(), ps.GetErrorMessage())
+}
+
+// tracesPartialSuccessError returns an error when the OTLP receiver rejected
+// some of the exported spans.
+func tracesPartialSuccessError(res *coltracepb.ExportTraceServiceResponse) error {
+	ps := res.GetPartialSuccess()
+	if ps.GetRejectedSpans() == 0 {
+		return nil
+	}
+	return fmt.Errorf("OTLP receiver rejected %d spans: %s", ps.GetRejectedSpans(), ps.GetErrorMessage())
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opentelemetrytarget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	export "go.opentelemetry.io/otel/sdk/metric/export"
+	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// otlpMetricsExporter converts the metrics collected by the OpenTelemetry
+// SDK to OTLP and pushes them to an OTLP receiver.
+//
+// The otlpmetric exporters can't be used instead: the only release which
+// supports the v0.27 metric SDK required by the Cortex exporter is built
+// against a pre-1.0 OTLP protocol, which the forwarding of logs and traces
+// can't use.
+type otlpMetricsExporter struct {
+	client otlpClient
+}
+
+var _ export.Exporter = (*otlpMetricsExporter)(nil)
+
+// TemporalityFor implements export.Exporter.
+// Cumulative values are exported, the same way the Cortex exporter does.
+func (*otlpMetricsExporter) TemporalityFor(*sdkapi.Descriptor, aggregation.Kind) aggregation.Temporality {
+	return aggregation.CumulativeTemporality
+}
+
+// Export implements export.Exporter.
+func (e *otlpMetricsExporter) Export(ctx context.Context, res *resource.Resource, reader export.InstrumentationLibraryReader) error {
+	rm, err := resourceMetrics(res, reader, e)
+	if err != nil {
+		return err
+	}
+	if rm == nil {
+		return nil
+	}
+
+	return e.client.exportMetrics(ctx, &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{rm},
+	})
+}
+
+// resourceMetrics converts the records of the given reader to OTLP. A nil
+// value is returned when there are no records to export.
+func resourceMetrics(res *resource.Resource, reader export.InstrumentationLibraryReader,
+	ts aggregation.TemporalitySelector) (*metricspb.ResourceMetrics, error) {
+
+	var sms []*metricspb.ScopeMetrics
+
+	err := reader.ForEach(func(lib instrumentation.Library, r export.Reader) error {
+		var ms []*metricspb.Metric
+
+		err := r.ForEach(ts, func(rec export.Record) error {
+			m, err := otlpMetric(rec, ts)
+			switch {
+			case errors.Is(err, aggregation.ErrNoData):
+				return nil
+			case err != nil:
+				return err
+			}
+			ms = append(ms, m)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(ms) > 0 {
+			sms = append(sms, &metricspb.ScopeMetrics{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    lib.Name,
+					Version: lib.Version,
+				},
+				SchemaUrl: lib.SchemaURL,
+				Metrics:   ms,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting metrics to OTLP: %w", err)
+	}
+
+	if len(sms) == 0 {
+		return nil, nil
+	}
+
+	rm := &metricspb.ResourceMetrics{
+		ScopeMetrics: sms,
+	}
+	if res != nil {
+		rm.Resource = &resourcepb.Resource{Attributes: keyValues(res.Iter())}
+		rm.SchemaUrl = res.SchemaURL()
+	}
+
+	return rm, nil
+}
+
+// otlpMetric converts an exported record to an OTLP metric, based on the
+// strongest aggregation it supports.
+func otlpMetric(rec export.Record, ts aggregation.TemporalitySelector) (*metricspb.Metric, error) {
+	desc := rec.Descriptor()
+	attrs := keyValues(rec.Labels().Iter())
+	start := uint64(rec.StartTime().UnixNano())
+	end := uint64(rec.EndTime().UnixNano())
+	temporality := otlpTemporality(ts.TemporalityFor(desc, rec.Aggregation().Kind()))
+
+	m := &metricspb.Metric{
+		Name:        desc.Name(),
+		Description: desc.Description(),
+		Unit:        string(desc.Unit()),
+	}
+
+	switch agg := rec.Aggregation().(type) {
+	case aggregation.Histogram:
+		count, err := agg.Count()
+		if err != nil {
+			return nil, err
+		}
+		sum, err := agg.Sum()
+		if err != nil {
+			return nil, err
+		}
+		buckets, err := agg.Histogram()
+		if err != nil {
+			return nil, err
+		}
+
+		s := sum.CoerceToFloat64(desc.NumberKind())
+		m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: temporality,
+			DataPoints: []*metricspb.HistogramDataPoint{{
+				Attributes:        attrs,
+				StartTimeUnixNano: start,
+				TimeUnixNano:      end,
+				Count:             count,
+				Sum:               &s,
+				BucketCounts:      buckets.Counts,
+				ExplicitBounds:    buckets.Boundaries,
+			}},
+		}}
+
+	case aggregation.Sum:
+		sum, err := agg.Sum()
+		if err != nil {
+			return nil, err
+		}
+
+		dp := numberDataPoint(sum, desc.NumberKind())
+		dp.Attributes = attrs
+		dp.StartTimeUnixNano = start
+		dp.TimeUnixNano = end
+
+		m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			AggregationTemporality: temporality,
+			IsMonotonic:            desc.InstrumentKind().Monotonic(),
+			DataPoints:             []*metricspb.NumberDataPoint{dp},
+		}}
+
+	case aggregation.LastValue:
+		value, t, err := agg.LastValue()
+		if err != nil {
+			return nil, err
+		}
+
+		dp := numberDataPoint(value, desc.NumberKind())
+		dp.Attributes = attrs
+		dp.TimeUnixNano = uint64(t.UnixNano())
+
+		m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+			DataPoints: []*metricspb.NumberDataPoint{dp},
+		}}
+
+	default:
+		return nil, fmt.Errorf("unsupported aggregation %q for instrument %q", agg.Kind(), desc.Name())
+	}
+
+	return m, nil
+}
+
+// numberDataPoint returns an OTLP data point holding the given number.
+func numberDataPoint(n number.Number, kind number.Kind) *metricspb.NumberDataPoint {
+	if kind == number.Int64Kind {
+		return &metricspb.NumberDataPoint{
+			Value: &metricspb.NumberDataPoint_AsInt{AsInt: n.AsInt64()},
+		}
+	}
+	return &metricspb.NumberDataPoint{
+		Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: n.CoerceToFloat64(kind)},
+	}
+}
+
+// otlpTemporality returns the OTLP counterpart of a SDK temporality.
+func otlpTemporality(t aggregation.Temporality) metricspb.AggregationTemporality {
+	switch t {
+	case aggregation.CumulativeTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	case aggregation.DeltaTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	default:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+	}
+}
+
+// keyValues converts a set of attributes to OTLP.
+func keyValues(iter attribute.Iterator) []*commonpb.KeyValue {
+	if iter.Len() == 0 {
+		return nil
+	}
+
+	kvs := make([]*commonpb.KeyValue, 0, iter.Len())
+	for iter.Next() {
+		kv := iter.Attribute()
+		kvs = append(kvs, &commonpb.KeyValue{
+			Key:   string(kv.Key),
+			Value: anyValue(kv.Value),
+		})
+	}
+	return kvs
+}
+
+// anyValue converts an attribute value to OTLP.
+func anyValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.STRING:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	}
+
+	var vals []*commonpb.AnyValue
+	switch v.Type() {
+	case attribute.BOOLSLICE:
+		for _, b := range v.AsBoolSlice() {
+			vals = append(vals, anyValue(attribute.BoolValue(b)))
+		}
+	case attribute.INT64SLICE:
+		for _, i := range v.AsInt64Slice() {
+			vals = append(vals, anyValue(attribute.Int64Value(i)))
+		}
+	case attribute.FLOAT64SLICE:
+		for _, f := range v.AsFloat64Slice() {
+			vals = append(vals, anyValue(attribute.Float64Value(f)))
+		}
+	case attribute.STRINGSLICE:
+		for _, s := range v.AsStringSlice() {
+			vals = append(vals, anyValue(attribute.StringValue(s)))
+		}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+		ArrayValue: &commonpb.ArrayValue{Values: vals},
+	}}
+}
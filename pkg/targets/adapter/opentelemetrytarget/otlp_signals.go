@@ -0,0 +1,105 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opentelemetrytarget
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// OTLP/JSON fields which carry hex encoded identifiers instead of the base64
+// encoding protobuf uses for bytes.
+var otlpHexFields = map[string]struct{}{
+	"traceId":      {},
+	"spanId":       {},
+	"parentSpanId": {},
+}
+
+// unmarshalOTLP decodes the OTLP payload of the given event into msg.
+// Payloads can be encoded either as binary protobuf or as OTLP/JSON.
+func unmarshalOTLP(event *cloudevents.Event, msg proto.Message) error {
+	if strings.HasPrefix(event.DataContentType(), contentTypeProtobuf) {
+		return proto.Unmarshal(event.Data(), msg)
+	}
+
+	data, err := hexToBase64IDs(event.Data())
+	if err != nil {
+		return err
+	}
+
+	// receivers must ignore unknown fields, as per the OTLP specification.
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+// hexToBase64IDs re-encodes the trace and span identifiers of an OTLP/JSON
+// payload to base64, so that it can be decoded as standard protobuf JSON.
+func hexToBase64IDs(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// preserves the precision of 64-bit timestamps.
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decoding OTLP/JSON payload: %w", err)
+	}
+
+	if err := walkHexIDs(v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// walkHexIDs recursively replaces the hex encoded identifiers found in v.
+func walkHexIDs(v interface{}) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if s, ok := val.(string); ok {
+				if _, isID := otlpHexFields[k]; isID {
+					id, err := hex.DecodeString(s)
+					if err != nil {
+						return fmt.Errorf("field %q is not hex encoded: %w", k, err)
+					}
+					t[k] = base64.StdEncoding.EncodeToString(id)
+				}
+				continue
+			}
+			if err := walkHexIDs(val); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, val := range t {
+			if err := walkHexIDs(val); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
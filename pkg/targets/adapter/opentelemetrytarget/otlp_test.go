@@ -0,0 +1,415 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opentelemetrytarget
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"go.opentelemetry.io/otel/metric/sdkapi"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	loggingtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
+)
+
+const (
+	tHeaderKey   = "x-api-key"
+	tHeaderValue = "secret"
+)
+
+func TestOTLPMetricsExport(t *testing.T) {
+	for _, protocol := range []string{otlpProtocolGRPC, otlpProtocolHTTP} {
+		//nolint:scopelint
+		t.Run(protocol, func(t *testing.T) {
+			rcv := &otlpReceiver{}
+			a := newTestOTLPAdapter(t, rcv, protocol)
+
+			a.instruments = map[string]map[string]*instrumentRef{
+				"requests": {"Counter": {descriptor: sdkapi.NewDescriptor("requests",
+					sdkapi.CounterInstrumentKind, numberKinds["Int64"], "total requests", "")}},
+				"duration": {"Histogram": {descriptor: sdkapi.NewDescriptor("duration",
+					sdkapi.HistogramInstrumentKind, numberKinds["Float64"], "", "")}},
+			}
+
+			exporter := &otlpMetricsExporter{client: a.otlp}
+			ctl := controller.New(
+				processor.NewFactory(simple.NewWithHistogramDistribution(), exporter),
+				controller.WithExporter(exporter),
+				controller.WithCollectPeriod(time.Hour),
+			)
+			require.NoError(t, ctl.Start(context.Background()))
+			require.NoError(t, a.registerInstruments(ctl.Meter("TriggerMesh")))
+
+			measures := `[
+				{"name":"requests","value":2,"attributes":[{"key":"host","type":"string","value":"tm1"}]},
+				{"name":"requests","value":3,"attributes":[{"key":"host","type":"string","value":"tm1"}]},
+				{"name":"duration","value":52.1}
+			]`
+			_, res := a.dispatch(context.Background(), newTestEvent(t, v1alpha1.EventTypeOpenTelemetryMetricsPush, measures))
+			require.True(t, cloudevents.IsACK(res), "Unexpected result: %v", res)
+
+			// stopping the controller pushes the collected metrics
+			require.NoError(t, ctl.Stop(context.Background()))
+
+			reqs := rcv.metricsRequests()
+			require.Len(t, reqs, 1)
+			require.Len(t, reqs[0].ResourceMetrics, 1)
+			require.Len(t, reqs[0].ResourceMetrics[0].ScopeMetrics, 1)
+
+			sm := reqs[0].ResourceMetrics[0].ScopeMetrics[0]
+			assert.Equal(t, "TriggerMesh", sm.Scope.Name)
+
+			ms := make(map[string]*metricspb.Metric, len(sm.Metrics))
+			for _, m := range sm.Metrics {
+				ms[m.Name] = m
+			}
+			require.Len(t, ms, 2)
+
+			sum := ms["requests"].GetSum()
+			require.NotNil(t, sum)
+			assert.True(t, sum.IsMonotonic)
+			assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+			require.Len(t, sum.DataPoints, 1)
+			assert.Equal(t, int64(5), sum.DataPoints[0].GetAsInt())
+			require.Len(t, sum.DataPoints[0].Attributes, 1)
+			assert.Equal(t, "host", sum.DataPoints[0].Attributes[0].Key)
+			assert.Equal(t, "tm1", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+
+			hist := ms["duration"].GetHistogram()
+			require.NotNil(t, hist)
+			require.Len(t, hist.DataPoints, 1)
+			assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+			assert.Equal(t, 52.1, hist.DataPoints[0].GetSum())
+			assert.Len(t, hist.DataPoints[0].BucketCounts, len(hist.DataPoints[0].ExplicitBounds)+1)
+
+			assert.Equal(t, []string{tHeaderValue}, rcv.headers(tHeaderKey))
+		})
+	}
+}
+
+func TestOTLPSignalsPush(t *testing.T) {
+	const (
+		logs = `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+			"scopeLogs":[{"scope":{"name":"app"},"logRecords":[{"timeUnixNano":"1678460400000000000","severityNumber":9,
+			"body":{"stringValue":"order placed"},"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174",
+			"unknownField":true}]}]}]}`
+
+		traces = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},
+			"scopeSpans":[{"scope":{"name":"app"},"spans":[{"traceId":"5b8efff798038103d269b633813fc60c",
+			"spanId":"eee19b7ec3c1b174","parentSpanId":"eee19b7ec3c1b173","name":"place-order","kind":2,
+			"startTimeUnixNano":"1678460400000000000","endTimeUnixNano":"1678460401000000000"}]}]}]}`
+	)
+
+	traceID := []byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c}
+	spanID := []byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74}
+
+	for _, protocol := range []string{otlpProtocolGRPC, otlpProtocolHTTP} {
+		//nolint:scopelint
+		t.Run(protocol, func(t *testing.T) {
+			rcv := &otlpReceiver{}
+			a := newTestOTLPAdapter(t, rcv, protocol)
+
+			_, res := a.dispatch(context.Background(), newTestEvent(t, v1alpha1.EventTypeOpenTelemetryLogsPush, logs))
+			require.True(t, cloudevents.IsACK(res), "Unexpected result: %v", res)
+
+			_, res = a.dispatch(context.Background(), newTestEvent(t, v1alpha1.EventTypeOpenTelemetryTracesPush, traces))
+			require.True(t, cloudevents.IsACK(res), "Unexpected result: %v", res)
+
+			logReqs := rcv.logsRequests()
+			require.Len(t, logReqs, 1)
+			lr := logReqs[0].ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+			assert.Equal(t, "order placed", lr.Body.GetStringValue())
+			assert.Equal(t, uint64(1678460400000000000), lr.TimeUnixNano)
+			assert.Equal(t, traceID, lr.TraceId)
+			assert.Equal(t, spanID, lr.SpanId)
+
+			traceReqs := rcv.tracesRequests()
+			require.Len(t, traceReqs, 1)
+			span := traceReqs[0].ResourceSpans[0].ScopeSpans[0].Spans[0]
+			assert.Equal(t, "place-order", span.Name)
+			assert.Equal(t, traceID, span.TraceId)
+			assert.Equal(t, spanID, span.SpanId)
+			assert.Equal(t, "checkout",
+				traceReqs[0].ResourceSpans[0].Resource.Attributes[0].Value.GetStringValue())
+		})
+	}
+
+	t.Run("protobuf payload", func(t *testing.T) {
+		rcv := &otlpReceiver{}
+		a := newTestOTLPAdapter(t, rcv, otlpProtocolGRPC)
+
+		jsonEvent := newTestEvent(t, v1alpha1.EventTypeOpenTelemetryTracesPush, traces)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		require.NoError(t, unmarshalOTLP(&jsonEvent, req))
+		data, err := proto.Marshal(req)
+		require.NoError(t, err)
+
+		event := cloudevents.NewEvent()
+		event.SetID("1")
+		event.SetSource("test")
+		event.SetType(v1alpha1.EventTypeOpenTelemetryTracesPush)
+		require.NoError(t, event.SetData(contentTypeProtobuf, data))
+
+		_, res := a.dispatch(context.Background(), event)
+		require.True(t, cloudevents.IsACK(res), "Unexpected result: %v", res)
+		require.Len(t, rcv.tracesRequests(), 1)
+		assert.True(t, proto.Equal(req, rcv.tracesRequests()[0]))
+	})
+
+	t.Run("invalid identifier", func(t *testing.T) {
+		rcv := &otlpReceiver{}
+		a := newTestOTLPAdapter(t, rcv, otlpProtocolGRPC)
+
+		out, _ := a.dispatch(context.Background(), newTestEvent(t, v1alpha1.EventTypeOpenTelemetryTracesPush,
+			`{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"not-hex"}]}]}]}`))
+		require.NotNil(t, out)
+
+		var eerr targetce.EventError
+		require.NoError(t, out.DataAs(&eerr))
+		assert.Equal(t, targetce.ErrorCodeRequestParsing, eerr.Code)
+		assert.Empty(t, rcv.tracesRequests())
+	})
+
+	t.Run("cortex exporter", func(t *testing.T) {
+		a := &opentelemetryAdapter{replier: newTestReplier(t)}
+
+		out, _ := a.dispatch(context.Background(), newTestEvent(t, v1alpha1.EventTypeOpenTelemetryLogsPush, logs))
+		require.NotNil(t, out)
+
+		var eerr targetce.EventError
+		require.NoError(t, out.DataAs(&eerr))
+		assert.Equal(t, targetce.ErrorCodeEventContext, eerr.Code)
+	})
+}
+
+// otlpReceiver is an OTLP receiver stub which records the requests it
+// receives over both gRPC and HTTP.
+type otlpReceiver struct {
+	mu   sync.Mutex
+	reqs []proto.Message
+	md   metadata.MD
+}
+
+func (r *otlpReceiver) record(req proto.Message, md metadata.MD) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reqs = append(r.reqs, req)
+	r.md = md
+}
+
+func (r *otlpReceiver) headers(key string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.md.Get(key)
+}
+
+func (r *otlpReceiver) metricsRequests() []*colmetricspb.ExportMetricsServiceRequest {
+	return requestsOf[*colmetricspb.ExportMetricsServiceRequest](r)
+}
+
+func (r *otlpReceiver) logsRequests() []*collogspb.ExportLogsServiceRequest {
+	return requestsOf[*collogspb.ExportLogsServiceRequest](r)
+}
+
+func (r *otlpReceiver) tracesRequests() []*coltracepb.ExportTraceServiceRequest {
+	return requestsOf[*coltracepb.ExportTraceServiceRequest](r)
+}
+
+func requestsOf[T proto.Message](r *otlpReceiver) []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reqs []T
+	for _, req := range r.reqs {
+		if t, ok := req.(T); ok {
+			reqs = append(reqs, t)
+		}
+	}
+	return reqs
+}
+
+// grpcMetricsService, grpcLogsService and grpcTraceService expose the
+// receiver as the gRPC service of each signal.
+type (
+	grpcMetricsService struct {
+		colmetricspb.UnimplementedMetricsServiceServer
+		*otlpReceiver
+	}
+	grpcLogsService struct {
+		collogspb.UnimplementedLogsServiceServer
+		*otlpReceiver
+	}
+	grpcTraceService struct {
+		coltracepb.UnimplementedTraceServiceServer
+		*otlpReceiver
+	}
+)
+
+func (s grpcMetricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.record(req, md)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func (s grpcLogsService) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.record(req, md)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (s grpcTraceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.record(req, md)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// serveGRPC starts serving the receiver over gRPC and returns its address.
+func (r *otlpReceiver) serveGRPC(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(srv, grpcMetricsService{otlpReceiver: r})
+	collogspb.RegisterLogsServiceServer(srv, grpcLogsService{otlpReceiver: r})
+	coltracepb.RegisterTraceServiceServer(srv, grpcTraceService{otlpReceiver: r})
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// serveHTTP starts serving the receiver over HTTP and returns its URL.
+func (r *otlpReceiver) serveHTTP(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var msg proto.Message
+		var res proto.Message
+
+		switch req.URL.Path {
+		case otlpHTTPMetricsPath:
+			msg, res = &colmetricspb.ExportMetricsServiceRequest{}, &colmetricspb.ExportMetricsServiceResponse{}
+		case otlpHTTPLogsPath:
+			msg, res = &collogspb.ExportLogsServiceRequest{}, &collogspb.ExportLogsServiceResponse{}
+		case otlpHTTPTracesPath:
+			msg, res = &coltracepb.ExportTraceServiceRequest{}, &coltracepb.ExportTraceServiceResponse{}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if req.Header.Get("Content-Type") != contentTypeProtobuf {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil || proto.Unmarshal(body, msg) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		md := metadata.MD{}
+		for k, v := range req.Header {
+			md.Append(k, v...)
+		}
+		r.record(msg, md)
+
+		out, _ := proto.Marshal(res)
+		w.Header().Set("Content-Type", contentTypeProtobuf)
+		_, _ = w.Write(out)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func newTestOTLPAdapter(t *testing.T, rcv *otlpReceiver, protocol string) *otlpAdapter {
+	t.Helper()
+
+	env := &envAccessor{
+		OTLPProtocol: protocol,
+		OTLPInsecure: true,
+		OTLPHeaders:  map[string]string{tHeaderKey: tHeaderValue},
+		OTLPTimeout:  5 * time.Second,
+	}
+
+	switch protocol {
+	case otlpProtocolGRPC:
+		env.OTLPEndpoint = rcv.serveGRPC(t)
+	case otlpProtocolHTTP:
+		env.OTLPEndpoint = rcv.serveHTTP(t)
+	}
+
+	client, err := newOTLPClient(env)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.close() })
+
+	return &otlpAdapter{
+		opentelemetryAdapter: opentelemetryAdapter{
+			otlp:    client,
+			replier: newTestReplier(t),
+			logger:  loggingtesting.TestLogger(t),
+		},
+	}
+}
+
+func newTestReplier(t *testing.T) *targetce.Replier {
+	t.Helper()
+
+	replier, err := targetce.New("test", loggingtesting.TestLogger(t))
+	require.NoError(t, err)
+	return replier
+}
+
+func newTestEvent(t *testing.T, typ, data string) cloudevents.Event {
+	t.Helper()
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetSource("test")
+	event.SetType(typ)
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON, json.RawMessage(data)))
+	return event
+}
@@ -18,6 +18,9 @@ package slacktarget
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 
@@ -29,7 +32,9 @@ import (
 	"knative.dev/pkg/logging"
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
+	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
 	"github.com/triggermesh/triggermesh/pkg/targets/adapter/slacktarget/slack"
 )
 
@@ -54,6 +59,21 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 
 	env := envAcc.(*envAccessor)
 
+	replier, err := targetce.New(env.Component, logger.Named("replier"),
+		targetce.ReplierWithStatefulHeaders(env.BridgeIdentifier),
+		targetce.ReplierWithStaticResponseType(v1alpha1.EventTypeResponse),
+		targetce.ReplierWithPayloadPolicy(targetce.PayloadPolicy(env.CloudEventPayloadPolicy)))
+	if err != nil {
+		logger.Panicf("Error creating CloudEvents replier: %v", err)
+	}
+
+	var tmpl *messageTemplate
+	if env.Template != "" {
+		if tmpl, err = newMessageTemplate(env.Template); err != nil {
+			logger.Panicw("Invalid Slack message template", zap.Error(err))
+		}
+	}
+
 	// catalog is the supported operations registry, which can also
 	// be used in the future to filter available methods from the
 	// adapter. At this moment we use the full available cataglo and
@@ -63,8 +83,13 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 
 	return &slackAdapter{
 		slackClient: slack.NewWebAPIClient(env.Token, apiURL, &http.Client{}, catalog),
-		ceClient:    ceClient,
-		logger:      logger,
+
+		template:       tmpl,
+		templateMethod: env.TemplateMethod,
+
+		replier:  replier,
+		ceClient: ceClient,
+		logger:   logger,
 
 		sr: metrics.MustNewEventProcessingStatsReporter(mt),
 	}
@@ -75,6 +100,11 @@ var _ pkgadapter.Adapter = (*slackAdapter)(nil)
 type slackAdapter struct {
 	slackClient slack.WebAPIClient
 
+	// renders the payload of events which aren't Slack Web API requests.
+	template       *messageTemplate
+	templateMethod string
+
+	replier  *targetce.Replier
 	ceClient cloudevents.Client
 	logger   *zap.SugaredLogger
 
@@ -91,20 +121,35 @@ func (t *slackAdapter) Start(ctx context.Context) error {
 	return nil
 }
 
-func (t *slackAdapter) dispatch(event cloudevents.Event) cloudevents.Result {
-	// Take a cloud event as passed in, and submit a message
-
-	et := event.Type()
-	if !strings.HasPrefix(et, eventTypePrefix) {
-		t.logger.Errorw("Unsupported event type", zap.String("error", "event type is not supported: "+et))
-		return cloudevents.ResultNACK
+func (t *slackAdapter) dispatch(event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	var methodURL string
+	var data []byte
+
+	switch et := event.Type(); {
+	case strings.HasPrefix(et, eventTypePrefix):
+		methodURL = et[len(eventTypePrefix):]
+		data = event.Data()
+
+	case t.template != nil:
+		var err error
+		if data, err = t.template.render(&event); err != nil {
+			return t.replier.Error(&event, targetce.ErrorCodeRequestParsing, err, nil)
+		}
+		methodURL = t.templateMethod
+
+	default:
+		return t.replier.Error(&event, targetce.ErrorCodeEventContext,
+			fmt.Errorf("event type %q is not supported", et), nil)
 	}
 
-	methodURL := et[len(eventTypePrefix):]
-	res, err := t.slackClient.Do(methodURL, event.Data())
+	res, err := t.slackClient.Do(methodURL, data)
 	if err != nil {
-		t.logger.Errorw("Unable to send message", zap.Error(err))
-		return cloudevents.ResultNACK
+		// the request did not reach Slack, let the sender retry
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return t.replier.ErrorKnativeManaged(&event, err)
+		}
+		return t.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, nil)
 	}
 
 	if res.Warning() != "" {
@@ -112,12 +157,28 @@ func (t *slackAdapter) dispatch(event cloudevents.Event) cloudevents.Result {
 	}
 
 	if !res.IsOK() {
-		t.logger.Errorw("Request failed", zap.String("error", res.Error()))
-		return cloudevents.ResultNACK
+		err := fmt.Errorf("slack API request failed: %s", res.Error())
+		if isRetryable(res) {
+			return t.replier.ErrorKnativeManaged(&event, err)
+		}
+		return t.replier.Error(&event, targetce.ErrorCodeAdapterProcess, err, res)
 	}
 
-	// TODO return event containing response structure
-	// See: https://github.com/triggermesh/knative-targets/issues/165
+	// the response contains references to the message, such as its
+	// channel and timestamp, which allow following up on it.
+	return t.replier.Ok(&event, res, targetce.ResponseWithSubject(methodURL))
+}
+
+// isRetryable returns whether a failed Slack API request may succeed if it is
+// sent again later.
+func isRetryable(res slack.Response) bool {
+	if code := res.StatusCode(); code == http.StatusTooManyRequests || code >= http.StatusInternalServerError {
+		return true
+	}
 
-	return cloudevents.ResultACK
+	switch res.Error() {
+	case "ratelimited", "rate_limited", "request_timeout", "service_unavailable", "internal_error", "fatal_error":
+		return true
+	}
+	return false
 }
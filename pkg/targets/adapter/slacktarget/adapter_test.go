@@ -0,0 +1,190 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slacktarget
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	loggingtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+	targetce "github.com/triggermesh/triggermesh/pkg/targets/adapter/cloudevents"
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/slacktarget/slack"
+)
+
+type mockedWebAPIClient struct {
+	res slack.Response
+	err error
+
+	methodURL string
+	body      string
+}
+
+var _ slack.WebAPIClient = (*mockedWebAPIClient)(nil)
+
+func (c *mockedWebAPIClient) Do(methodURL string, body []byte) (slack.Response, error) {
+	c.methodURL = methodURL
+	c.body = string(body)
+	return c.res, c.err
+}
+
+func TestDispatch(t *testing.T) {
+	const tmpl = `{"channel": "C0123", "text": {{ json .data.message }}}`
+
+	okResponse := slack.Response{"ok": true, "channel": "C0123", "ts": "1503435956.000247"}
+
+	testCases := map[string]struct {
+		template  string
+		eventType string
+		res       slack.Response
+		err       error
+
+		expectMethod   string
+		expectBody     string
+		expectCategory string
+		expectData     map[string]interface{}
+		expectRetry    bool
+	}{
+		"Web API request": {
+			eventType: v1alpha1.EventTypeSlackPostMessage,
+			res:       okResponse,
+
+			expectMethod:   "chat.postMessage",
+			expectBody:     `{"message":"hello"}`,
+			expectCategory: targetce.ExtensionCategoryValueSuccess,
+			expectData:     map[string]interface{}{"ok": true, "channel": "C0123", "ts": "1503435956.000247"},
+		},
+		"Templated message": {
+			template:  tmpl,
+			eventType: "some.event",
+			res:       okResponse,
+
+			expectMethod:   "chat.postMessage",
+			expectBody:     `{"channel": "C0123", "text": "hello"}`,
+			expectCategory: targetce.ExtensionCategoryValueSuccess,
+			expectData:     map[string]interface{}{"ok": true, "channel": "C0123", "ts": "1503435956.000247"},
+		},
+		"Unsupported event type": {
+			eventType: "some.event",
+
+			expectCategory: targetce.ExtensionCategoryValueError,
+		},
+		"Failed request": {
+			eventType: v1alpha1.EventTypeSlackPostMessage,
+			res:       slack.Response{"ok": false, "error": "channel_not_found"},
+
+			expectMethod:   "chat.postMessage",
+			expectBody:     `{"message":"hello"}`,
+			expectCategory: targetce.ExtensionCategoryValueError,
+		},
+		"Rate limited request": {
+			eventType: v1alpha1.EventTypeSlackPostMessage,
+			res:       slack.Response{"ok": false, "error": "rate_limited", "status": http.StatusOK},
+
+			expectMethod: "chat.postMessage",
+			expectBody:   `{"message":"hello"}`,
+			expectRetry:  true,
+		},
+		"Server error": {
+			eventType: v1alpha1.EventTypeSlackPostMessage,
+			res:       slack.Response{"ok": "false", "error": "invalid character '<'", "status": http.StatusBadGateway},
+
+			expectMethod: "chat.postMessage",
+			expectBody:   `{"message":"hello"}`,
+			expectRetry:  true,
+		},
+		"Transport error": {
+			eventType: v1alpha1.EventTypeSlackPostMessage,
+			err:       &url.Error{Op: "Post", URL: "https://slack.com/api/chat.postMessage", Err: errors.New("connection refused")},
+
+			expectMethod: "chat.postMessage",
+			expectBody:   `{"message":"hello"}`,
+			expectRetry:  true,
+		},
+		"Unsupported method": {
+			eventType: "com.slack.webapi.some.method",
+			err:       errors.New(`slack method "some.method" not supported`),
+
+			expectMethod:   "some.method",
+			expectBody:     `{"message":"hello"}`,
+			expectCategory: targetce.ExtensionCategoryValueError,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			logger := loggingtesting.TestLogger(t)
+
+			replier, err := targetce.New("slacktarget", logger,
+				targetce.ReplierWithStaticResponseType(v1alpha1.EventTypeResponse),
+				targetce.ReplierWithPayloadPolicy(targetce.PayloadPolicyAlways))
+			require.NoError(t, err)
+
+			client := &mockedWebAPIClient{res: tc.res, err: tc.err}
+
+			a := &slackAdapter{
+				slackClient:    client,
+				templateMethod: "chat.postMessage",
+				replier:        replier,
+				logger:         logger,
+			}
+			if tc.template != "" {
+				a.template, err = newMessageTemplate(tc.template)
+				require.NoError(t, err)
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("1234")
+			event.SetType(tc.eventType)
+			event.SetSource("test.source")
+			require.NoError(t, event.SetData(cloudevents.ApplicationJSON, map[string]string{"message": "hello"}))
+
+			out, res := a.dispatch(event)
+
+			assert.Equal(t, tc.expectMethod, client.methodURL)
+			assert.Equal(t, tc.expectBody, client.body)
+
+			if tc.expectRetry {
+				assert.False(t, cloudevents.IsACK(res), "retryable errors are not acknowledged")
+				assert.Nil(t, out)
+				return
+			}
+			assert.True(t, cloudevents.IsACK(res))
+
+			require.NotNil(t, out)
+			assert.Equal(t, v1alpha1.EventTypeResponse, out.Type())
+			assert.Equal(t, tc.expectCategory, out.Extensions()[targetce.ExtensionCategory])
+
+			if tc.expectData != nil {
+				assert.Equal(t, tc.expectMethod, out.Subject())
+
+				var data map[string]interface{}
+				require.NoError(t, out.DataAs(&data))
+				assert.Subset(t, data, tc.expectData)
+			}
+		})
+	}
+}
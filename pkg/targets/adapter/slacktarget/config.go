@@ -29,4 +29,16 @@ type envAccessor struct {
 	pkgadapter.EnvConfig
 
 	Token string `envconfig:"SLACK_TOKEN" required:"true"`
+
+	// Go template which renders the payload of Slack Web API calls from
+	// events of any type.
+	Template string `envconfig:"SLACK_TEMPLATE"`
+	// Slack Web API method invoked with the payloads rendered by Template.
+	TemplateMethod string `envconfig:"SLACK_TEMPLATE_METHOD" default:"chat.postMessage"`
+
+	// CloudEvents responses parametrization
+	CloudEventPayloadPolicy string `envconfig:"EVENTS_PAYLOAD_POLICY" default:"error"`
+
+	// BridgeIdentifier is the name of the bridge workflow this target is part of
+	BridgeIdentifier string `envconfig:"EVENTS_BRIDGE_IDENTIFIER"`
 }
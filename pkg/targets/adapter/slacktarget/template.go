@@ -0,0 +1,56 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slacktarget
+
+import (
+	"encoding/json"
+	"errors"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/eventtemplate"
+)
+
+// messageTemplate renders the JSON payload of Slack Web API calls, such as
+// Block Kit messages, from arbitrary events.
+type messageTemplate struct {
+	tmpl *eventtemplate.Template
+}
+
+// newMessageTemplate returns a messageTemplate for the given Go template.
+func newMessageTemplate(text string) (*messageTemplate, error) {
+	tmpl, err := eventtemplate.New("message", text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messageTemplate{tmpl: tmpl}, nil
+}
+
+// render returns the payload rendered for the given event.
+func (m *messageTemplate) render(event *cloudevents.Event) ([]byte, error) {
+	payload, err := m.tmpl.Execute(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid([]byte(payload)) {
+		return nil, errors.New("message template did not generate a valid JSON payload")
+	}
+
+	return []byte(payload), nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slacktarget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestMessageTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		template  string
+		data      interface{}
+		expect    string
+		expectErr bool
+	}{
+		"block kit message": {
+			template: `{"channel": "C0123", "blocks": [` +
+				`{"type": "header", "text": {"type": "plain_text", "text": {{ json .type }}}},` +
+				`{"type": "section", "text": {"type": "mrkdwn", "text": {{ printf "*%s* from %s" .data.title .source | json }}}}]}`,
+			data: map[string]interface{}{"title": `Build "42" failed`},
+			expect: `{"channel": "C0123", "blocks": [` +
+				`{"type": "header", "text": {"type": "plain_text", "text": "test.type"}},` +
+				`{"type": "section", "text": {"type": "mrkdwn", "text": "*Build \"42\" failed* from test.source"}}]}`,
+		},
+		"thread reply": {
+			template: `{"channel": {{ json .data.channel }}, "thread_ts": {{ json .data.ts }}, "text": "ok"}`,
+			data:     map[string]interface{}{"channel": "C0123", "ts": "1503435956.000247"},
+			expect:   `{"channel": "C0123", "thread_ts": "1503435956.000247", "text": "ok"}`,
+		},
+		"extension attribute": {
+			template: `{"channel": "C0123", "text": {{ json .tenant }}}`,
+			expect:   `{"channel": "C0123", "text": "acme"}`,
+		},
+		"data is not JSON": {
+			template: `{"channel": "C0123", "text": {{ json .data }}}`,
+			data:     "plain text",
+			expect:   `{"channel": "C0123", "text": "plain text"}`,
+		},
+		"missing attribute": {
+			template:  `{"channel": "C0123", "text": {{ json .nope }}}`,
+			expectErr: true,
+		},
+		"invalid JSON": {
+			template:  `{"channel": "C0123", "text": {{ .type }}}`,
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := newMessageTemplate(tc.template)
+			require.NoError(t, err)
+
+			event := newTemplateEvent(t, tc.data)
+
+			payload, err := tmpl.render(&event)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, string(payload))
+		})
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := newMessageTemplate(`{"text": {{ .type }`)
+		assert.Error(t, err)
+	})
+}
+
+func newTemplateEvent(t *testing.T, data interface{}) cloudevents.Event {
+	t.Helper()
+
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	event.SetExtension("tenant", "acme")
+
+	switch d := data.(type) {
+	case nil:
+	case string:
+		require.NoError(t, event.SetData(cloudevents.TextPlain, d))
+	default:
+		require.NoError(t, event.SetData(cloudevents.ApplicationJSON, d))
+	}
+
+	return event
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/triggermesh/triggermesh/pkg/apis/targets"
 	"github.com/triggermesh/triggermesh/pkg/metrics"
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 )
 
 // SplunkClient is the interface that must be implemented by Splunk HEC
@@ -50,6 +53,8 @@ type adapter struct {
 
 	ceClient cloudevents.Client
 	spClient SplunkClient
+	// batcher is only set when batching is enabled.
+	batcher *batch.Batcher
 
 	defaultIndex string
 
@@ -70,6 +75,8 @@ type envConfig struct {
 
 	SkipTLSVerify    bool `envconfig:"SPLUNK_SKIP_TLS_VERIFY"`
 	DiscardCEContext bool `envconfig:"DISCARD_CE_CONTEXT" default:"false"`
+
+	batch.Config
 }
 
 // NewEnvConfig returns an accessor for the source's adapter envConfig.
@@ -101,11 +108,13 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 		logger.Panicw("Invalid HEC endpoint URL "+env.HECEndpoint, zap.Error(err))
 	}
 
-	return &adapter{
+	spClient := newClient(*hecURL, env.HECToken, env.Index, hostname(envAcc), env.SkipTLSVerify)
+
+	a := &adapter{
 		logger: logger,
 
 		ceClient: ceClient,
-		spClient: newClient(*hecURL, env.HECToken, env.Index, hostname(envAcc), env.SkipTLSVerify),
+		spClient: spClient,
 
 		defaultIndex: env.Index,
 
@@ -113,6 +122,13 @@ func NewTarget(ctx context.Context, envAcc pkgadapter.EnvConfigAccessor, ceClien
 
 		discardCEContext: env.DiscardCEContext,
 	}
+
+	if env.Config.Enabled() {
+		// HEC accepts batches of JSON events which are simply concatenated.
+		a.batcher = batch.New(batchSender(spClient), batch.EncodeNDJSON, env.Config)
+	}
+
+	return a
 }
 
 // newClient returns a Splunk HEC client.
@@ -147,6 +163,11 @@ func hostname(env pkgadapter.EnvConfigAccessor) string {
 
 // Start implements adapter.Adapter.
 func (a *adapter) Start(ctx context.Context) error {
+	if a.batcher != nil {
+		// send buffered events before returning
+		defer a.batcher.Flush(context.Background())
+	}
+
 	errCh := make(chan error)
 	go func() {
 		errCh <- a.ceClient.StartReceiver(ctx, a.receive)
@@ -173,8 +194,21 @@ func (a *adapter) receive(ctx context.Context, event cloudevents.Event) cloudeve
 		}
 	}
 
-	err := a.spClient.LogEvent(e)
+	if a.batcher == nil {
+		if err := a.spClient.LogEvent(e); err != nil {
+			a.logger.Errorw("Failed to send event to HEC", zap.Error(err))
+			return cloudevents.NewHTTPResult(a.extractHTTPStatus(err), "failed to send event to HEC: %s", err)
+		}
+		return cloudevents.ResultACK
+	}
+
+	data, err := json.Marshal(e)
 	if err != nil {
+		a.logger.Errorw("Failed to serialize HEC event", zap.Error(err))
+		return cloudevents.NewHTTPResult(http.StatusBadRequest, "failed to serialize HEC event: %s", err)
+	}
+
+	if _, err := a.batcher.Add(ctx, data); err != nil {
 		a.logger.Errorw("Failed to send event to HEC", zap.Error(err))
 		return cloudevents.NewHTTPResult(a.extractHTTPStatus(err), "failed to send event to HEC: %s", err)
 	}
@@ -185,7 +219,8 @@ func (a *adapter) receive(ctx context.Context, event cloudevents.Event) cloudeve
 // extractHTTPStatus attempts to extract the HTTP status code from the given
 // error, returns "400 Bad Request" otherwise.
 func (a *adapter) extractHTTPStatus(err error) int {
-	if splunkErr, ok := err.(*splunk.EventCollectorResponse); ok {
+	var splunkErr *splunk.EventCollectorResponse
+	if errors.As(err, &splunkErr) {
 		code, err := splunkErr.Code.HTTPCode()
 		if err != nil {
 			a.logger.Warnw("Couldn't determine HTTP status code", zap.Error(err))
@@ -196,3 +231,57 @@ func (a *adapter) extractHTTPStatus(err error) int {
 
 	return http.StatusBadRequest
 }
+
+// batchSender returns a batch.SendFunc which sends batches of events to the
+// HEC using the given client's parameters.
+func batchSender(c *splunk.Client) batch.SendFunc {
+	return func(ctx context.Context, b *batch.Request) ([]byte, error) {
+		return sendBatch(ctx, c, b)
+	}
+}
+
+// sendBatch sends a batch of events to the HEC.
+func sendBatch(ctx context.Context, c *splunk.Client, b *batch.Request) ([]byte, error) {
+	req, err := b.NewHTTPRequest(ctx, c.URL)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Splunk "+c.Token)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, batch.Retryable(err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, batch.Retryable(err)
+	}
+
+	if res.StatusCode == http.StatusOK {
+		return resBody, nil
+	}
+
+	hecResp := &splunk.EventCollectorResponse{}
+	if err := json.Unmarshal(resBody, hecResp); err != nil {
+		return nil, batch.ResponseError(res.StatusCode, resBody)
+	}
+
+	// The HEC stops processing a batch at its first invalid event. Events
+	// which precede it were indexed, events which follow it can be retried.
+	if n := hecResp.InvalidEventNumber; n != nil && *n >= 0 && *n < len(b.Items) {
+		errs := make(map[int]error, len(b.Items)-*n)
+		errs[*n] = hecResp
+		for i := *n + 1; i < len(b.Items); i++ {
+			errs[i] = batch.Retryable(errors.New("event not processed due to a preceding invalid event"))
+		}
+		return nil, &batch.PartialError{Errors: errs}
+	}
+
+	if batch.IsRetryableStatus(res.StatusCode) {
+		return nil, batch.Retryable(hecResp)
+	}
+	return nil, hecResp
+}
@@ -17,13 +17,19 @@ limitations under the License.
 package splunktarget
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
@@ -31,9 +37,14 @@ import (
 	logtesting "knative.dev/pkg/logging/testing"
 
 	"github.com/ZachtimusPrime/Go-Splunk-HTTP/splunk/v2"
+
+	"github.com/triggermesh/triggermesh/pkg/targets/adapter/batch"
 )
 
-const tDefaultIndex = "fake-index"
+const (
+	tDefaultIndex = "fake-index"
+	tToken        = "fake-token"
+)
 
 type mockedSplunkClient struct {
 	err           error
@@ -85,7 +96,7 @@ func TestReceive(t *testing.T) {
 			}
 
 			// invoke event callback
-			res := a.receive(context.Background(), newEvent(t))
+			res := a.receive(context.Background(), newEvent(t, "1234567890"))
 
 			assert.Lenf(t, tc.client.inputRecorder, 1, "Client records a single request")
 			assert.EqualError(t, res, tc.expectResult.Error())
@@ -93,11 +104,223 @@ func TestReceive(t *testing.T) {
 	}
 }
 
-func newEvent(t *testing.T) cloudevents.Event {
+// TestReceiveBatch verifies that events are sent to Splunk in batches, and
+// that events rejected by the HEC are reported individually.
+func TestReceiveBatch(t *testing.T) {
+	testCases := map[string]struct {
+		responses      []hecResponse
+		expectRequests int
+		// position in the batch of the event expected to fail, if any
+		expectFailed int
+	}{
+		"Successful batch": {
+			expectRequests: 1,
+			expectFailed:   -1,
+		},
+		"Invalid event": {
+			responses: []hecResponse{{
+				code: http.StatusBadRequest,
+				body: `{"text":"Invalid data format","code":6,"invalid-event-number":1}`,
+			}},
+			// the events which follow the invalid event are retried
+			expectRequests: 2,
+			expectFailed:   1,
+		},
+		"Transient failure": {
+			responses: []hecResponse{{
+				code: http.StatusServiceUnavailable,
+				body: `{"text":"Server is busy","code":9}`,
+			}},
+			expectRequests: 2,
+			expectFailed:   -1,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			hec := newFakeHEC(t, tc.responses...)
+
+			a := newTestAdapter(t, hec.URL, batch.Config{
+				MaxEvents:    3,
+				Linger:       time.Hour,
+				Compress:     true,
+				MaxRetries:   1,
+				RetryBackoff: time.Millisecond,
+			})
+
+			results := receiveAll(a, newEvent(t, "1"), newEvent(t, "2"), newEvent(t, "3"))
+
+			assert.Equal(t, tc.expectRequests, hec.requestCount(), "HEC receives the expected number of requests")
+
+			events := hec.receivedEvents()
+			require.GreaterOrEqual(t, len(events), 3)
+
+			for i, e := range events[:3] {
+				assert.Equal(t, "test.source", e["source"])
+				assert.Equal(t, "test.type", e["sourcetype"])
+				assert.Equal(t, tDefaultIndex, e["index"])
+
+				res := results[eventID(t, e)]
+				if i == tc.expectFailed {
+					assert.False(t, cloudevents.IsACK(res), "Event at position %d is rejected", i)
+				} else {
+					assert.Equal(t, cloudevents.ResultACK, res, "Event at position %d is accepted", i)
+				}
+			}
+		})
+	}
+}
+
+// TestReceiveBatchLinger verifies that buffered events are sent once the
+// linger time has elapsed.
+func TestReceiveBatchLinger(t *testing.T) {
+	hec := newFakeHEC(t)
+
+	a := newTestAdapter(t, hec.URL, batch.Config{
+		MaxEvents: 100,
+		Linger:    50 * time.Millisecond,
+	})
+
+	done := make(chan map[string]cloudevents.Result)
+	go func() {
+		done <- receiveAll(a, newEvent(t, "1"), newEvent(t, "2"))
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(hec.receivedEvents()) == 2
+	}, time.Second, time.Millisecond)
+
+	for id, res := range <-done {
+		assert.Equal(t, cloudevents.ResultACK, res, "Event %s is accepted", id)
+	}
+	assert.Equal(t, 1, hec.requestCount(), "Events are sent in a single batch")
+}
+
+// hecResponse is a response returned by a fake HEC.
+type hecResponse struct {
+	code int
+	body string
+}
+
+// fakeHEC is a HEC server which records the events it receives.
+type fakeHEC struct {
+	*httptest.Server
+
+	m         sync.Mutex
+	requests  int
+	events    []map[string]interface{}
+	responses []hecResponse
+}
+
+func newFakeHEC(t *testing.T, responses ...hecResponse) *fakeHEC {
+	t.Helper()
+
+	hec := &fakeHEC{responses: responses}
+
+	hec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hec.m.Lock()
+		defer hec.m.Unlock()
+
+		assert.Equal(t, "Splunk "+tToken, r.Header.Get("Authorization"))
+		assert.Equal(t, eventURLPath, r.URL.Path)
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == batch.ContentEncodingGzip {
+			zr, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = zr
+		}
+
+		dec := json.NewDecoder(body)
+		for dec.More() {
+			var e map[string]interface{}
+			require.NoError(t, dec.Decode(&e))
+			hec.events = append(hec.events, e)
+		}
+
+		res := hecResponse{code: http.StatusOK, body: `{"text":"Success","code":0}`}
+		if hec.requests < len(hec.responses) {
+			res = hec.responses[hec.requests]
+		}
+		hec.requests++
+
+		w.WriteHeader(res.code)
+		_, _ = w.Write([]byte(res.body))
+	}))
+	t.Cleanup(hec.Close)
+
+	return hec
+}
+
+// requestCount returns the number of requests received by the HEC.
+func (hec *fakeHEC) requestCount() int {
+	hec.m.Lock()
+	defer hec.m.Unlock()
+	return hec.requests
+}
+
+// receivedEvents returns the events received by the HEC, in order.
+func (hec *fakeHEC) receivedEvents() []map[string]interface{} {
+	hec.m.Lock()
+	defer hec.m.Unlock()
+	return append([]map[string]interface{}(nil), hec.events...)
+}
+
+func newTestAdapter(t *testing.T, hecURL string, cfg batch.Config) *adapter {
+	t.Helper()
+
+	u, err := url.Parse(hecURL)
+	require.NoError(t, err)
+
+	spClient := newClient(*u, tToken, tDefaultIndex, "", false)
+
+	return &adapter{
+		logger:       logtesting.TestLogger(t),
+		ceClient:     adaptertest.NewTestClient(),
+		spClient:     spClient,
+		batcher:      batch.New(batchSender(spClient), batch.EncodeNDJSON, cfg),
+		defaultIndex: tDefaultIndex,
+	}
+}
+
+// receiveAll invokes the event callback concurrently for each of the given
+// events, and returns the results indexed by event ID.
+func receiveAll(a *adapter, events ...cloudevents.Event) map[string]cloudevents.Result {
+	var m sync.Mutex
+	results := make(map[string]cloudevents.Result, len(events))
+
+	var wg sync.WaitGroup
+	for _, e := range events {
+		wg.Add(1)
+		go func(e cloudevents.Event) {
+			defer wg.Done()
+			res := a.receive(context.Background(), e)
+
+			m.Lock()
+			defer m.Unlock()
+			results[e.ID()] = res
+		}(e)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// eventID returns the ID of the CloudEvent contained in a HEC event.
+func eventID(t *testing.T, e map[string]interface{}) string {
+	t.Helper()
+
+	ce, ok := e["event"].(map[string]interface{})
+	require.True(t, ok, "HEC event contains a CloudEvent")
+	return ce["id"].(string)
+}
+
+func newEvent(t *testing.T, id string) cloudevents.Event {
 	t.Helper()
 
 	ce := cloudevents.NewEvent()
-	ce.SetID("1234567890")
+	ce.SetID(id)
 	ce.SetSource("test.source")
 	ce.SetType("test.type")
 	if err := ce.SetData(cloudevents.TextPlain, "Lorem Ipsum"); err != nil {
@@ -17,6 +17,8 @@ limitations under the License.
 package awsdynamodbtarget
 
 import (
+	"encoding/json"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"knative.dev/eventing/pkg/reconciler/source"
@@ -30,6 +32,15 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/targets/reconciler"
 )
 
+const (
+	envOperation                 = "DYNAMODB_OPERATION"
+	envKey                       = "DYNAMODB_KEY"
+	envUpdateExpression          = "DYNAMODB_UPDATE_EXPRESSION"
+	envConditionExpression       = "DYNAMODB_CONDITION_EXPRESSION"
+	envExpressionAttributeNames  = "DYNAMODB_EXPRESSION_ATTRIBUTE_NAMES"
+	envExpressionAttributeValues = "DYNAMODB_EXPRESSION_ATTRIBUTE_VALUES"
+)
+
 // adapterConfig contains properties used to configure the target's adapter.
 // Public fields are automatically populated by envconfig.
 type adapterConfig struct {
@@ -56,9 +67,59 @@ func (r *Reconciler) BuildAdapter(trg commonv1alpha1.Reconcilable, _ *apis.URL)
 // MakeAppEnv extracts environment variables from the object.
 // Exported to be used in external tools for local test environments.
 func MakeAppEnv(o *v1alpha1.AWSDynamoDBTarget) []corev1.EnvVar {
-	return append(reconciler.MakeAWSAuthEnvVars(o.Spec.Auth),
+	env := append(reconciler.MakeAWSAuthEnvVars(o.Spec.Auth),
 		corev1.EnvVar{
 			Name:  common.EnvARN,
 			Value: o.Spec.ARN,
 		})
+
+	if o.Spec.Operation != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envOperation,
+			Value: string(*o.Spec.Operation),
+		})
+	}
+
+	if o.Spec.Key != nil {
+		if k, err := json.Marshal(o.Spec.Key); err == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envKey,
+				Value: string(k),
+			})
+		}
+	}
+
+	if o.Spec.UpdateExpression != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envUpdateExpression,
+			Value: *o.Spec.UpdateExpression,
+		})
+	}
+
+	if o.Spec.ConditionExpression != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envConditionExpression,
+			Value: *o.Spec.ConditionExpression,
+		})
+	}
+
+	if len(o.Spec.ExpressionAttributeNames) > 0 {
+		if n, err := json.Marshal(o.Spec.ExpressionAttributeNames); err == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envExpressionAttributeNames,
+				Value: string(n),
+			})
+		}
+	}
+
+	if len(o.Spec.ExpressionAttributeValues) > 0 {
+		if v, err := json.Marshal(o.Spec.ExpressionAttributeValues); err == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envExpressionAttributeValues,
+				Value: string(v),
+			})
+		}
+	}
+
+	return env
 }
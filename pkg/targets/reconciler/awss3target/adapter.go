@@ -32,6 +32,15 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/targets/reconciler"
 )
 
+const (
+	envKeyTemplate      = "S3_KEY_TEMPLATE"
+	envBatching         = "S3_BATCHING"
+	envBatchMaxEvents   = "S3_BATCH_MAX_EVENTS"
+	envBatchMaxBytes    = "S3_BATCH_MAX_BYTES"
+	envBatchMaxAge      = "S3_BATCH_MAX_AGE"
+	envBatchCompression = "S3_BATCH_COMPRESSION"
+)
+
 // adapterConfig contains properties used to configure the target's adapter.
 // Public fields are automatically populated by envconfig.
 type adapterConfig struct {
@@ -58,7 +67,7 @@ func (r *Reconciler) BuildAdapter(trg commonv1alpha1.Reconcilable, _ *apis.URL)
 // MakeAppEnv extracts environment variables from the object.
 // Exported to be used in external tools for local test environments.
 func MakeAppEnv(o *v1alpha1.AWSS3Target) []corev1.EnvVar {
-	return append(reconciler.MakeAWSAuthEnvVars(o.Spec.Auth),
+	env := append(reconciler.MakeAWSAuthEnvVars(o.Spec.Auth),
 		[]corev1.EnvVar{
 			{
 				Name:  common.EnvARN,
@@ -68,4 +77,45 @@ func MakeAppEnv(o *v1alpha1.AWSS3Target) []corev1.EnvVar {
 				Value: strconv.FormatBool(o.Spec.DiscardCEContext),
 			},
 		}...)
+
+	if o.Spec.KeyTemplate != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envKeyTemplate,
+			Value: *o.Spec.KeyTemplate,
+		})
+	}
+
+	if b := o.Spec.Batching; b != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envBatching,
+			Value: strconv.FormatBool(true),
+		})
+
+		if b.MaxEvents != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envBatchMaxEvents,
+				Value: strconv.FormatInt(int64(*b.MaxEvents), 10),
+			})
+		}
+		if b.MaxBytes != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envBatchMaxBytes,
+				Value: strconv.FormatInt(*b.MaxBytes, 10),
+			})
+		}
+		if b.MaxAge != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envBatchMaxAge,
+				Value: b.MaxAge.String(),
+			})
+		}
+		if b.Compression != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envBatchCompression,
+				Value: string(*b.Compression),
+			})
+		}
+	}
+
+	return env
 }
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 TriggerMesh Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
+	"github.com/triggermesh/triggermesh/pkg/reconciler"
+)
+
+// MakeBatchEnvVars returns environment variables for the given batching
+// options.
+func MakeBatchEnvVars(opts *v1alpha1.BatchOptions) []corev1.EnvVar {
+	if opts == nil {
+		return nil
+	}
+
+	var batchEnvVars []corev1.EnvVar
+
+	if opts.MaxEvents != nil {
+		batchEnvVars = append(batchEnvVars, corev1.EnvVar{
+			Name:  reconciler.EnvBatchMaxEvents,
+			Value: strconv.Itoa(*opts.MaxEvents),
+		})
+	}
+	if opts.MaxBytes != nil {
+		batchEnvVars = append(batchEnvVars, corev1.EnvVar{
+			Name:  reconciler.EnvBatchMaxBytes,
+			Value: strconv.Itoa(*opts.MaxBytes),
+		})
+	}
+	if opts.Linger != nil {
+		batchEnvVars = append(batchEnvVars, corev1.EnvVar{
+			Name:  reconciler.EnvBatchLinger,
+			Value: opts.Linger.String(),
+		})
+	}
+	if opts.Compress != nil {
+		batchEnvVars = append(batchEnvVars, corev1.EnvVar{
+			Name:  reconciler.EnvBatchCompress,
+			Value: strconv.FormatBool(*opts.Compress),
+		})
+	}
+	if opts.MaxRetries != nil {
+		batchEnvVars = append(batchEnvVars, corev1.EnvVar{
+			Name:  reconciler.EnvBatchMaxRetries,
+			Value: strconv.Itoa(*opts.MaxRetries),
+		})
+	}
+
+	return batchEnvVars
+}
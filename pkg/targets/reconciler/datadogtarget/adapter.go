@@ -27,6 +27,7 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	common "github.com/triggermesh/triggermesh/pkg/reconciler"
 	"github.com/triggermesh/triggermesh/pkg/reconciler/resource"
+	"github.com/triggermesh/triggermesh/pkg/targets/reconciler"
 )
 
 const (
@@ -84,5 +85,7 @@ func MakeAppEnv(o *v1alpha1.DatadogTarget) []corev1.EnvVar {
 		})
 	}
 
+	env = append(env, reconciler.MakeBatchEnvVars(o.Spec.Batch)...)
+
 	return env
 }
@@ -73,6 +73,33 @@ func MakeAppEnv(o *v1alpha1.ElasticsearchTarget) []corev1.EnvVar {
 		},
 	}
 
+	if o.Spec.DocumentIDFromEvent != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "ELASTICSEARCH_DOCUMENT_ID_FROM_EVENT",
+			Value: strconv.FormatBool(*o.Spec.DocumentIDFromEvent),
+		})
+	}
+
+	if b := o.Spec.Bulk; b != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "ELASTICSEARCH_BULK",
+			Value: strconv.FormatBool(true),
+		})
+
+		if b.FlushBytes != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "ELASTICSEARCH_BULK_FLUSH_BYTES",
+				Value: strconv.FormatInt(int64(*b.FlushBytes), 10),
+			})
+		}
+		if b.FlushInterval != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "ELASTICSEARCH_BULK_FLUSH_INTERVAL",
+				Value: b.FlushInterval.String(),
+			})
+		}
+	}
+
 	if o.Spec.Connection.SkipVerify != nil {
 		env = append(env, corev1.EnvVar{
 			Name:  "ELASTICSEARCH_SKIPVERIFY",
@@ -17,6 +17,7 @@ limitations under the License.
 package kafkatarget
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -54,6 +55,18 @@ const (
 	envKerberosUsername    = "KERBEROS_USERNAME"
 	envKerberosPassword    = "KERBEROS_PASSWORD"
 
+	envContentMode  = "CONTENT_MODE"
+	envPartitionKey = "PARTITION_KEY"
+	envHeaders      = "HEADERS"
+
+	envProducerAsync       = "PRODUCER_ASYNC"
+	envProducerLinger      = "PRODUCER_LINGER"
+	envProducerBatchSize   = "PRODUCER_BATCH_SIZE"
+	envProducerBatchBytes  = "PRODUCER_BATCH_BYTES"
+	envProducerCompression = "PRODUCER_COMPRESSION"
+	envProducerAcks        = "PRODUCER_ACKS"
+	envProducerIdempotent  = "PRODUCER_IDEMPOTENT"
+
 	krb5ConfPath   = "/etc/krb5.conf"
 	krb5KeytabPath = "/etc/krb5.keytab"
 )
@@ -251,6 +264,90 @@ func MakeAppEnv(o *v1alpha1.KafkaTarget) []corev1.EnvVar {
 		})
 	}
 
+	if o.Spec.ContentMode != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envContentMode,
+			Value: string(*o.Spec.ContentMode),
+		})
+	}
+
+	if o.Spec.PartitionKey != nil {
+		if pk, err := json.Marshal(o.Spec.PartitionKey); err == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envPartitionKey,
+				Value: string(pk),
+			})
+		}
+	}
+
+	if len(o.Spec.Headers) > 0 {
+		if hs, err := json.Marshal(o.Spec.Headers); err == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envHeaders,
+				Value: string(hs),
+			})
+		}
+	}
+
+	if p := o.Spec.Producer; p != nil {
+		env = appendProducerEnv(env, p)
+	}
+
+	return env
+}
+
+// appendProducerEnv appends the environment variables which tune the Kafka
+// producer to the given list.
+func appendProducerEnv(env []corev1.EnvVar, p *v1alpha1.KafkaTargetProducer) []corev1.EnvVar {
+	if p.Async != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerAsync,
+			Value: strconv.FormatBool(*p.Async),
+		})
+	}
+
+	if p.Linger != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerLinger,
+			Value: p.Linger.String(),
+		})
+	}
+
+	if p.BatchSize != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerBatchSize,
+			Value: strconv.Itoa(*p.BatchSize),
+		})
+	}
+
+	if p.BatchBytes != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerBatchBytes,
+			Value: strconv.Itoa(*p.BatchBytes),
+		})
+	}
+
+	if p.Compression != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerCompression,
+			Value: string(*p.Compression),
+		})
+	}
+
+	if p.Acks != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerAcks,
+			Value: string(*p.Acks),
+		})
+	}
+
+	if p.Idempotent != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envProducerIdempotent,
+			Value: strconv.FormatBool(*p.Idempotent),
+		})
+	}
+
 	return env
 }
 
@@ -18,6 +18,9 @@ package logzmetricstarget
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -32,8 +35,13 @@ import (
 )
 
 const (
+	envExporter                 = "OPENTELEMETRY_EXPORTER"
 	envCortexEndpoint           = "OPENTELEMETRY_CORTEX_ENDPOINT"
 	envCortexBearerToken        = "OPENTELEMETRY_CORTEX_BEARER_TOKEN"
+	envOTLPEndpoint             = "OPENTELEMETRY_OTLP_ENDPOINT"
+	envOTLPProtocol             = "OPENTELEMETRY_OTLP_PROTOCOL"
+	envOTLPInsecure             = "OPENTELEMETRY_OTLP_INSECURE"
+	envOTLPHeaders              = "OPENTELEMETRY_OTLP_HEADERS"
 	envOpenTelemetryInstruments = "OPENTELEMETRY_INSTRUMENTS"
 
 	envEventsPayloadPolicy = "EVENTS_PAYLOAD_POLICY"
@@ -66,21 +74,33 @@ func (r *Reconciler) BuildAdapter(trg commonv1alpha1.Reconcilable, _ *apis.URL)
 // MakeAppEnv extracts environment variables from the object.
 // Exported to be used in external tools for local test environments.
 func MakeAppEnv(o *v1alpha1.LogzMetricsTarget) []corev1.EnvVar {
-	env := []corev1.EnvVar{
-		{
-			Name: envCortexBearerToken,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: o.Spec.Connection.Token.SecretKeyRef,
+	var env []corev1.EnvVar
+
+	if o.Spec.Exporter != nil && *o.Spec.Exporter == v1alpha1.OpenTelemetryExporterOTLP {
+		env = append(env, corev1.EnvVar{
+			Name:  envExporter,
+			Value: string(*o.Spec.Exporter),
+		})
+		env = append(env, makeOTLPEnv(o.Spec.OTLP)...)
+	} else {
+		env = append(env, []corev1.EnvVar{
+			{
+				Name: envCortexBearerToken,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: o.Spec.Connection.Token.SecretKeyRef,
+				},
+			}, {
+				Name:  envCortexEndpoint,
+				Value: o.Spec.Connection.ListenerURL,
 			},
-		}, {
-			Name:  envCortexEndpoint,
-			Value: o.Spec.Connection.ListenerURL,
-		}, {
-			Name:  common.EnvBridgeID,
-			Value: common.GetStatefulBridgeID(o),
-		},
+		}...)
 	}
 
+	env = append(env, corev1.EnvVar{
+		Name:  common.EnvBridgeID,
+		Value: common.GetStatefulBridgeID(o),
+	})
+
 	if instruments, err := json.Marshal(o.Spec.Instruments); err == nil {
 		env = append(env, corev1.EnvVar{
 			Name:  envOpenTelemetryInstruments,
@@ -97,3 +117,52 @@ func MakeAppEnv(o *v1alpha1.LogzMetricsTarget) []corev1.EnvVar {
 
 	return env
 }
+
+// makeOTLPEnv returns the environment variables which configure the OTLP
+// exporter.
+func makeOTLPEnv(o *v1alpha1.OTLPConnection) []corev1.EnvVar {
+	if o == nil {
+		return nil
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  envOTLPEndpoint,
+			Value: o.Endpoint,
+		},
+	}
+
+	if o.Protocol != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envOTLPProtocol,
+			Value: string(*o.Protocol),
+		})
+	}
+
+	if o.Insecure != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envOTLPInsecure,
+			Value: strconv.FormatBool(*o.Insecure),
+		})
+	}
+
+	// Headers environment format is dictated by https://github.com/kelseyhightower/envconfig
+	// Header keys are ordered to avoid map comparison issues when reconciling.
+	if len(o.Headers) > 0 {
+		headers := make([]string, 0, len(o.Headers))
+		for k := range o.Headers {
+			headers = append(headers, k)
+		}
+		sort.Strings(headers)
+
+		for i, k := range headers {
+			headers[i] = k + ":" + o.Headers[k]
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  envOTLPHeaders,
+			Value: strings.Join(headers, ","),
+		})
+	}
+
+	return env
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/apis/targets/v1alpha1"
 	common "github.com/triggermesh/triggermesh/pkg/reconciler"
 	"github.com/triggermesh/triggermesh/pkg/reconciler/resource"
+	"github.com/triggermesh/triggermesh/pkg/targets/reconciler"
 )
 
 const (
@@ -80,5 +81,7 @@ func MakeAppEnv(o *v1alpha1.LogzTarget) []corev1.EnvVar {
 		})
 	}
 
+	env = append(env, reconciler.MakeBatchEnvVars(o.Spec.Batch)...)
+
 	return env
 }
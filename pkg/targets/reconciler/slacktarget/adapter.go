@@ -29,6 +29,13 @@ import (
 	"github.com/triggermesh/triggermesh/pkg/reconciler/resource"
 )
 
+const (
+	envSlackToken          = "SLACK_TOKEN"
+	envSlackTemplate       = "SLACK_TEMPLATE"
+	envSlackTemplateMethod = "SLACK_TEMPLATE_METHOD"
+	envEventsPayloadPolicy = "EVENTS_PAYLOAD_POLICY"
+)
+
 // adapterConfig contains properties used to configure the target's adapter.
 // Public fields are automatically populated by envconfig.
 type adapterConfig struct {
@@ -53,12 +60,45 @@ func (r *Reconciler) BuildAdapter(trg commonv1alpha1.Reconcilable, _ *apis.URL)
 }
 
 func MakeAppEnv(o *v1alpha1.SlackTarget) []corev1.EnvVar {
-	return []corev1.EnvVar{
+	env := []corev1.EnvVar{
 		{
-			Name: "SLACK_TOKEN",
+			Name: envSlackToken,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: o.Spec.Token.SecretKeyRef,
 			},
 		},
 	}
+
+	if tpl := o.Spec.Template; tpl != nil {
+		switch {
+		case tpl.Value != nil:
+			env = append(env, corev1.EnvVar{
+				Name:  envSlackTemplate,
+				Value: *tpl.Value,
+			})
+		case tpl.ValueFromConfigMap != nil:
+			env = append(env, corev1.EnvVar{
+				Name: envSlackTemplate,
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: tpl.ValueFromConfigMap,
+				},
+			})
+		}
+
+		if tpl.Method != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  envSlackTemplateMethod,
+				Value: *tpl.Method,
+			})
+		}
+	}
+
+	if o.Spec.EventOptions != nil && o.Spec.EventOptions.PayloadPolicy != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  envEventsPayloadPolicy,
+			Value: string(*o.Spec.EventOptions.PayloadPolicy),
+		})
+	}
+
+	return env
 }